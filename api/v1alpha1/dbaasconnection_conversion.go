@@ -42,7 +42,7 @@ func (src *DBaaSConnection) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	// Status
-	src.Status.ConvertTo(&dst.Status)
+	src.Status.ConvertTo(&dst.Status.DBaaSConnectionStatus)
 
 	return nil
 }
@@ -58,7 +58,7 @@ func (dst *DBaaSConnection) ConvertFrom(srcRaw conversion.Hub) error {
 	dst.Spec.ConvertFrom(&src.Spec)
 
 	// Status
-	dst.Status.ConvertFrom(&src.Status)
+	dst.Status.ConvertFrom(&src.Status.DBaaSConnectionStatus)
 
 	return nil
 }
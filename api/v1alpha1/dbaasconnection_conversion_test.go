@@ -127,19 +127,21 @@ var _ = Describe("DBaaSConnectionConversion", func() {
 					Namespace: testNamespace,
 				},
 			},
-			Status: v1beta1.DBaaSConnectionStatus{
-				Conditions: []metav1.Condition{
-					{
-						Type:   testConditionType,
-						Reason: testConditionReason,
-						Status: metav1.ConditionTrue,
+			Status: v1beta1.DBaaSOperatorConnectionStatus{
+				DBaaSConnectionStatus: v1beta1.DBaaSConnectionStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   testConditionType,
+							Reason: testConditionReason,
+							Status: metav1.ConditionTrue,
+						},
+					},
+					CredentialsRef: &corev1.LocalObjectReference{
+						Name: testSecret,
+					},
+					ConnectionInfoRef: &corev1.LocalObjectReference{
+						Name: testConfigMap,
 					},
-				},
-				CredentialsRef: &corev1.LocalObjectReference{
-					Name: testSecret,
-				},
-				ConnectionInfoRef: &corev1.LocalObjectReference{
-					Name: testConfigMap,
 				},
 			},
 		}
@@ -171,19 +173,21 @@ var _ = Describe("DBaaSConnectionConversion", func() {
 				},
 				DatabaseServiceType: &databaseServiceType,
 			},
-			Status: v1beta1.DBaaSConnectionStatus{
-				Conditions: []metav1.Condition{
-					{
-						Type:   testConditionType,
-						Reason: testConditionReason,
-						Status: metav1.ConditionTrue,
+			Status: v1beta1.DBaaSOperatorConnectionStatus{
+				DBaaSConnectionStatus: v1beta1.DBaaSConnectionStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   testConditionType,
+							Reason: testConditionReason,
+							Status: metav1.ConditionTrue,
+						},
+					},
+					CredentialsRef: &corev1.LocalObjectReference{
+						Name: testSecret,
+					},
+					ConnectionInfoRef: &corev1.LocalObjectReference{
+						Name: testConfigMap,
 					},
-				},
-				CredentialsRef: &corev1.LocalObjectReference{
-					Name: testSecret,
-				},
-				ConnectionInfoRef: &corev1.LocalObjectReference{
-					Name: testConfigMap,
 				},
 			},
 		}
@@ -174,27 +174,29 @@ var _ = Describe("DBaaSInventoryConversion", func() {
 					},
 				},
 			},
-			Status: v1beta1.DBaaSInventoryStatus{
-				Conditions: []metav1.Condition{
-					{
-						Type:   testConditionType,
-						Reason: testConditionReason,
-						Status: metav1.ConditionTrue,
-					},
-				},
-				DatabaseServices: []v1beta1.DatabaseService{
-					{
-						ServiceID:   instanceID,
-						ServiceName: instanceName,
-						ServiceInfo: map[string]string{
-							"test": "instance",
+			Status: v1beta1.DBaaSOperatorInventoryStatus{
+				DBaaSInventoryStatus: v1beta1.DBaaSInventoryStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   testConditionType,
+							Reason: testConditionReason,
+							Status: metav1.ConditionTrue,
 						},
 					},
-					{
-						ServiceID:   clusterID,
-						ServiceName: clusterName,
-						ServiceInfo: map[string]string{
-							"test": "cluster",
+					DatabaseServices: []v1beta1.DatabaseService{
+						{
+							ServiceID:   instanceID,
+							ServiceName: instanceName,
+							ServiceInfo: map[string]string{
+								"test": "instance",
+							},
+						},
+						{
+							ServiceID:   clusterID,
+							ServiceName: clusterName,
+							ServiceInfo: map[string]string{
+								"test": "cluster",
+							},
 						},
 					},
 				},
@@ -240,30 +242,32 @@ var _ = Describe("DBaaSInventoryConversion", func() {
 					},
 				},
 			},
-			Status: v1beta1.DBaaSInventoryStatus{
-				Conditions: []metav1.Condition{
-					{
-						Type:   testConditionType,
-						Reason: testConditionReason,
-						Status: metav1.ConditionTrue,
-					},
-				},
-				DatabaseServices: []v1beta1.DatabaseService{
-					{
-						ServiceID:   instanceID,
-						ServiceName: instanceName,
-						ServiceInfo: map[string]string{
-							"test": "instance",
+			Status: v1beta1.DBaaSOperatorInventoryStatus{
+				DBaaSInventoryStatus: v1beta1.DBaaSInventoryStatus{
+					Conditions: []metav1.Condition{
+						{
+							Type:   testConditionType,
+							Reason: testConditionReason,
+							Status: metav1.ConditionTrue,
 						},
-						ServiceType: &instanceType,
 					},
-					{
-						ServiceID:   clusterID,
-						ServiceName: clusterName,
-						ServiceInfo: map[string]string{
-							"test": "cluster",
+					DatabaseServices: []v1beta1.DatabaseService{
+						{
+							ServiceID:   instanceID,
+							ServiceName: instanceName,
+							ServiceInfo: map[string]string{
+								"test": "instance",
+							},
+							ServiceType: &instanceType,
+						},
+						{
+							ServiceID:   clusterID,
+							ServiceName: clusterName,
+							ServiceInfo: map[string]string{
+								"test": "cluster",
+							},
+							ServiceType: &clusterType,
 						},
-						ServiceType: &clusterType,
 					},
 				},
 			},
@@ -0,0 +1,118 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasbackuplog = logf.Log.WithName("dbaasbackup-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSBackup) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasbackup,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasbackups,verbs=create;update,versions=v1beta1,name=vdbaasbackup.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSBackup{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackup) ValidateCreate() error {
+	dbaasbackuplog.Info("validate create", "name", r.Name)
+	return validateBackup(r, nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackup) ValidateUpdate(old runtime.Object) error {
+	dbaasbackuplog.Info("validate update", "name", r.Name)
+	return validateBackup(r, old.(*DBaaSBackup))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackup) ValidateDelete() error {
+	dbaasbackuplog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+// validateBackup checks the instance and the retention of a backup, which cannot be changed once requested
+func validateBackup(backup *DBaaSBackup, old *DBaaSBackup) error {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if old != nil && !reflect.DeepEqual(backup.Spec, old.Spec) {
+		errs = append(errs, field.Forbidden(specPath, "the spec of a backup is immutable"))
+	}
+	errs = append(errs, validateBackupSpec(&backup.Spec, specPath)...)
+	if old == nil && len(errs) == 0 {
+		supportErrs, err := validateBackupsSupported(backup.Spec.InstanceRef, backup.Namespace, specPath.Child("instanceRef"))
+		if err != nil {
+			return err
+		}
+		errs = append(errs, supportErrs...)
+	}
+	if len(errs) > 0 {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSBackup").GroupKind(), backup.Name, errs)
+	}
+	return nil
+}
+
+// validateBackupSpec checks the specification of a backup, or of the backups created by a schedule
+func validateBackupSpec(spec *DBaaSBackupSpec, specPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if len(spec.InstanceRef.Name) == 0 {
+		errs = append(errs, field.Required(specPath.Child("instanceRef", "name"), "the instance must be specified"))
+	}
+	if spec.Retention != nil && spec.Retention.Duration <= 0 {
+		errs = append(errs, field.Invalid(specPath.Child("retention"), spec.Retention.Duration.String(), "retention must be positive"))
+	}
+	return errs
+}
+
+// validateBackupsSupported rejects a backup of an instance whose provider does not support backups.
+// An instance, inventory or provider that is not found is reported by the controllers.
+func validateBackupsSupported(instanceRef NamespacedName, namespace string, path *field.Path) (field.ErrorList, error) {
+	instance := &DBaaSInstance{}
+	if err := WebhookAPIClient.Get(context.TODO(), namespacedKey(instanceRef, namespace), instance); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	provider, err := getInstanceProvider(instance)
+	if err != nil || provider == nil {
+		return nil, err
+	}
+	if !provider.GetCapabilities().SupportsBackups() {
+		return field.ErrorList{field.Forbidden(path, fmt.Sprintf("provider %s does not support backups", provider.Name))}, nil
+	}
+	return nil, nil
+}
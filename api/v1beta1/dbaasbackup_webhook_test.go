@@ -0,0 +1,104 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testDBaaSBackup = &DBaaSBackup{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-backup",
+		Namespace: testNamespace,
+	},
+	Spec: DBaaSBackupSpec{
+		InstanceRef: NamespacedName{Name: "test-instance"},
+		Retention:   &metav1.Duration{Duration: 24 * time.Hour},
+	},
+}
+
+var _ = Describe("DBaaSBackup Webhook", func() {
+	Context("after creating DBaaSBackup", func() {
+		BeforeEach(assertResourceCreation(testDBaaSBackup))
+		AfterEach(assertResourceDeletion(testDBaaSBackup))
+
+		It("should not allow updating the spec", func() {
+			updatedDBaaSBackup := &DBaaSBackup{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSBackup), updatedDBaaSBackup)).Should(Succeed())
+			updatedDBaaSBackup.Spec.Retention.Duration = 48 * time.Hour
+			err := k8sClient.Update(ctx, updatedDBaaSBackup)
+			Expect(err).Should(MatchError(ContainSubstring("the spec of a backup is immutable")))
+		})
+	})
+
+	Context("after trying to create an invalid DBaaSBackup", func() {
+		DescribeTable("checking invalid DBaaSBackup specs",
+			func(specFn func(*DBaaSBackupSpec), expectedErr string) {
+				backup := testDBaaSBackup.DeepCopy()
+				backup.SetResourceVersion("")
+				specFn(&backup.Spec)
+				err := k8sClient.Create(ctx, backup)
+				Expect(err).Should(MatchError(ContainSubstring(expectedErr)))
+			},
+			Entry("not allow creating the DBaaSBackup without the instance",
+				func(spec *DBaaSBackupSpec) {
+					spec.InstanceRef.Name = ""
+				},
+				"the instance must be specified"),
+			Entry("not allow creating the DBaaSBackup with a negative retention",
+				func(spec *DBaaSBackupSpec) {
+					spec.Retention.Duration = -time.Hour
+				},
+				"retention must be positive"),
+		)
+	})
+
+	Context("after creating DBaaSInstance for a provider without backups", func() {
+		instance := &DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-backup-instance", Namespace: testNamespace},
+			Spec: DBaaSInstanceSpec{
+				InventoryRef: NamespacedName{Name: testProvisioningInventory.Name, Namespace: testNamespace},
+				ProvisioningParameters: map[ProvisioningParameterType]string{
+					ProvisioningName: "test-backup-instance",
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(&testProvisioningProvider))
+		BeforeEach(assertResourceCreation(&testProvisioningSecret))
+		BeforeEach(assertResourceCreation(&testProvisioningInventory))
+		BeforeEach(assertResourceCreation(instance))
+		AfterEach(assertResourceDeletion(instance))
+		AfterEach(assertResourceDeletion(&testProvisioningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningSecret))
+		AfterEach(assertResourceDeletion(&testProvisioningProvider))
+
+		It("should not allow backing up the instance", func() {
+			backup := testDBaaSBackup.DeepCopy()
+			backup.SetResourceVersion("")
+			backup.Spec.InstanceRef.Name = instance.Name
+			err := k8sClient.Create(ctx, backup)
+			Expect(err).Should(MatchError(ContainSubstring("spec.instanceRef: Forbidden: provider test-provisioning-provider does not support backups")))
+		})
+	})
+})
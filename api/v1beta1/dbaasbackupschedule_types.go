@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.backupTemplate.instanceRef.name`
+//+kubebuilder:printcolumn:name="Interval",type=string,JSONPath=`.spec.interval`
+//+kubebuilder:printcolumn:name="Last Schedule",type=date,JSONPath=`.status.lastScheduleTime`
+
+// The schema for the DBaaSBackupSchedule API.
+// Creates DBaaSBackups of a DBaaSInstance at a regular interval.
+// +operator-sdk:csv:customresourcedefinitions:displayName="DBaaSBackupSchedule"
+type DBaaSBackupSchedule struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSBackupScheduleSpec   `json:"spec,omitempty"`
+	Status DBaaSBackupScheduleStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of DBaaSBackupSchedules.
+type DBaaSBackupScheduleList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSBackupSchedule `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSBackupSchedule{}, &DBaaSBackupScheduleList{})
+}
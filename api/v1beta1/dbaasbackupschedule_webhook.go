@@ -0,0 +1,91 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"reflect"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// minBackupInterval is the shortest interval between two backups of a schedule
+const minBackupInterval = time.Hour
+
+// log is for logging in this package.
+var dbaasbackupschedulelog = logf.Log.WithName("dbaasbackupschedule-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSBackupSchedule) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasbackupschedule,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasbackupschedules,verbs=create;update,versions=v1beta1,name=vdbaasbackupschedule.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSBackupSchedule{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackupSchedule) ValidateCreate() error {
+	dbaasbackupschedulelog.Info("validate create", "name", r.Name)
+	return validateBackupSchedule(r, nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackupSchedule) ValidateUpdate(old runtime.Object) error {
+	dbaasbackupschedulelog.Info("validate update", "name", r.Name)
+	return validateBackupSchedule(r, old.(*DBaaSBackupSchedule))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSBackupSchedule) ValidateDelete() error {
+	dbaasbackupschedulelog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+// validateBackupSchedule checks the backup template and the interval of a schedule
+func validateBackupSchedule(schedule *DBaaSBackupSchedule, old *DBaaSBackupSchedule) error {
+	specPath := field.NewPath("spec")
+	errs := validateBackupSpec(&schedule.Spec.BackupTemplate, specPath.Child("backupTemplate"))
+	if schedule.Spec.Interval.Duration < minBackupInterval {
+		errs = append(errs, field.Invalid(specPath.Child("interval"), schedule.Spec.Interval.Duration.String(),
+			"interval must be at least "+minBackupInterval.String()))
+	}
+	if old != nil && !reflect.DeepEqual(schedule.Spec.BackupTemplate.InstanceRef, old.Spec.BackupTemplate.InstanceRef) {
+		errs = append(errs, field.Invalid(specPath.Child("backupTemplate", "instanceRef"), schedule.Spec.BackupTemplate.InstanceRef, "instanceRef is immutable"))
+	}
+	if old == nil && len(errs) == 0 {
+		supportErrs, err := validateBackupsSupported(schedule.Spec.BackupTemplate.InstanceRef, schedule.Namespace, specPath.Child("backupTemplate", "instanceRef"))
+		if err != nil {
+			return err
+		}
+		errs = append(errs, supportErrs...)
+	}
+	if len(errs) > 0 {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSBackupSchedule").GroupKind(), schedule.Name, errs)
+	}
+	return nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testDBaaSBackupSchedule = &DBaaSBackupSchedule{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-backup-schedule",
+		Namespace: testNamespace,
+	},
+	Spec: DBaaSBackupScheduleSpec{
+		BackupTemplate: DBaaSBackupSpec{InstanceRef: NamespacedName{Name: "test-instance"}},
+		Interval:       metav1.Duration{Duration: 24 * time.Hour},
+	},
+}
+
+var _ = Describe("DBaaSBackupSchedule Webhook", func() {
+	Context("after creating DBaaSBackupSchedule", func() {
+		BeforeEach(assertResourceCreation(testDBaaSBackupSchedule))
+		AfterEach(assertResourceDeletion(testDBaaSBackupSchedule))
+
+		It("should not allow updating the instance", func() {
+			updatedDBaaSBackupSchedule := &DBaaSBackupSchedule{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSBackupSchedule), updatedDBaaSBackupSchedule)).Should(Succeed())
+			updatedDBaaSBackupSchedule.Spec.BackupTemplate.InstanceRef.Name = "other-instance"
+			err := k8sClient.Update(ctx, updatedDBaaSBackupSchedule)
+			Expect(err).Should(MatchError(ContainSubstring("instanceRef is immutable")))
+		})
+		It("should allow updating the interval and suspending the schedule", func() {
+			updatedDBaaSBackupSchedule := &DBaaSBackupSchedule{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSBackupSchedule), updatedDBaaSBackupSchedule)).Should(Succeed())
+			updatedDBaaSBackupSchedule.Spec.Interval.Duration = 12 * time.Hour
+			updatedDBaaSBackupSchedule.Spec.Suspend = true
+			Expect(k8sClient.Update(ctx, updatedDBaaSBackupSchedule)).Should(Succeed())
+		})
+	})
+
+	Context("after trying to create an invalid DBaaSBackupSchedule", func() {
+		DescribeTable("checking invalid DBaaSBackupSchedule specs",
+			func(specFn func(*DBaaSBackupScheduleSpec), expectedErr string) {
+				schedule := testDBaaSBackupSchedule.DeepCopy()
+				schedule.SetResourceVersion("")
+				specFn(&schedule.Spec)
+				err := k8sClient.Create(ctx, schedule)
+				Expect(err).Should(MatchError(ContainSubstring(expectedErr)))
+			},
+			Entry("not allow creating the DBaaSBackupSchedule without the instance",
+				func(spec *DBaaSBackupScheduleSpec) {
+					spec.BackupTemplate.InstanceRef.Name = ""
+				},
+				"spec.backupTemplate.instanceRef.name: Required value"),
+			Entry("not allow creating the DBaaSBackupSchedule with a short interval",
+				func(spec *DBaaSBackupScheduleSpec) {
+					spec.Interval.Duration = time.Minute
+				},
+				"interval must be at least 1h0m0s"),
+		)
+	})
+})
@@ -0,0 +1,60 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ClusterPolicyName is the name of the only DBaaSClusterPolicy object applied.
+const ClusterPolicyName = "cluster"
+
+// Defines the observed state of a DBaaSClusterPolicy object.
+type DBaaSClusterPolicyStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:resource:scope=Cluster
+//+kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.conditions[0].status`
+//+kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
+
+// Sets the organization-wide inventory policy, named cluster.
+// The DBaaSPolicy objects and the inventories can only narrow this policy: provisioning stays disabled when the cluster policy disables it,
+// connections are only allowed in the namespaces allowed by both policies, the allowed provisioning values are intersected, and the lowest limits apply.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Cluster Provider Account Policy"
+type DBaaSClusterPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSPolicySpec          `json:"spec,omitempty"`
+	Status DBaaSClusterPolicyStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of DBaaSClusterPolicy objects.
+type DBaaSClusterPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSClusterPolicy `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSClusterPolicy{}, &DBaaSClusterPolicyList{})
+}
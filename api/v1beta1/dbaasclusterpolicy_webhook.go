@@ -0,0 +1,73 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasclusterpolicylog = logf.Log.WithName("dbaasclusterpolicy-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSClusterPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasclusterpolicy,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasclusterpolicies,verbs=create;update,versions=v1beta1,name=vdbaasclusterpolicy.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSClusterPolicy{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSClusterPolicy) ValidateCreate() error {
+	dbaasclusterpolicylog.Info("validate create", "name", r.Name)
+	if r.Name != ClusterPolicyName {
+		return field.Invalid(field.NewPath("metadata").Child("name"), r.Name, fmt.Sprintf("the cluster policy must be named %s", ClusterPolicyName))
+	}
+	return validateClusterPolicy(r)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSClusterPolicy) ValidateUpdate(_ runtime.Object) error {
+	dbaasclusterpolicylog.Info("validate update", "name", r.Name)
+	return validateClusterPolicy(r)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSClusterPolicy) ValidateDelete() error {
+	dbaasclusterpolicylog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func validateClusterPolicy(policy *DBaaSClusterPolicy) error {
+	// Check ns selector
+	if policy.Spec.Connections.NsSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(policy.Spec.Connections.NsSelector); err != nil {
+			return err
+		}
+	}
+	return validateAccessModeRules(policy.Spec.Connections.AccessModes)
+}
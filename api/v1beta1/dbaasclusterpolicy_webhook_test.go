@@ -0,0 +1,137 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DBaaSClusterPolicy Webhook", func() {
+	Context("creation", func() {
+		It("should fail with another name", func() {
+			policy := &DBaaSClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+			err := k8sClient.Create(ctx, policy)
+			Expect(err).Should(MatchError(ContainSubstring("the cluster policy must be named " + ClusterPolicyName)))
+		})
+		It("should succeed with the cluster name", func() {
+			policy := &DBaaSClusterPolicy{ObjectMeta: metav1.ObjectMeta{Name: ClusterPolicyName}}
+			assertResourceCreation(policy)()
+			assertResourceDeletion(policy)()
+		})
+	})
+
+	Context("narrowing", func() {
+		isTrue := true
+		isFalse := false
+		nodes := int32(5)
+		clusterNodes := int32(3)
+		clusterNamespaces := []string{"tenant-1", "tenant-2"}
+		clusterPolicy := &DBaaSClusterPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: ClusterPolicyName},
+			Spec: DBaaSPolicySpec{
+				DBaaSInventoryPolicy: DBaaSInventoryPolicy{
+					DisableProvisions: &isTrue,
+					Connections:       DBaaSConnectionPolicy{Namespaces: &clusterNamespaces},
+					Provisioning: &DBaaSProvisioningPolicy{
+						AllowedPlans: []string{ProvisioningPlanFreeTrial, ProvisioningPlanServerless},
+						MaxNodes:     &clusterNodes,
+					},
+				},
+				Quotas: &DBaaSQuotaPolicy{MaxInstancesPerNamespace: &nodes},
+			},
+		}
+
+		It("should narrow a namespace policy", func() {
+			namespaces := []string{"*"}
+			spec := &DBaaSPolicySpec{
+				DBaaSInventoryPolicy: DBaaSInventoryPolicy{
+					DisableProvisions: &isFalse,
+					Connections:       DBaaSConnectionPolicy{Namespaces: &namespaces},
+					Provisioning: &DBaaSProvisioningPolicy{
+						AllowedPlans: []string{ProvisioningPlanDedicated, ProvisioningPlanServerless},
+						MaxNodes:     &nodes,
+					},
+				},
+			}
+			effective := NarrowPolicy(clusterPolicy, spec)
+			Expect(*effective.DisableProvisions).Should(BeTrue())
+			Expect(*effective.Connections.Namespaces).Should(Equal(clusterNamespaces))
+			Expect(effective.Provisioning.AllowedPlans).Should(Equal([]string{ProvisioningPlanServerless}))
+			Expect(*effective.Provisioning.MaxNodes).Should(Equal(clusterNodes))
+			Expect(*effective.Quotas.MaxInstancesPerNamespace).Should(Equal(nodes))
+
+			// the namespace policy is left unchanged
+			Expect(*spec.DisableProvisions).Should(BeFalse())
+			Expect(spec.Provisioning.AllowedPlans).Should(HaveLen(2))
+		})
+		It("should not allow values outside of the cluster policy", func() {
+			spec := &DBaaSPolicySpec{
+				DBaaSInventoryPolicy: DBaaSInventoryPolicy{
+					Provisioning: &DBaaSProvisioningPolicy{AllowedPlans: []string{ProvisioningPlanDedicated}},
+				},
+			}
+			effective := NarrowPolicy(clusterPolicy, spec)
+			Expect(effective.Provisioning.AllowedPlans).ShouldNot(BeNil())
+			Expect(effective.Provisioning.AllowedPlans).Should(BeEmpty())
+			Expect(effective.Provisioning.ValidateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningPlan: ProvisioningPlanDedicated,
+			})).Should(HaveLen(1))
+		})
+		It("should apply the access mode rules of both policies", func() {
+			cluster := clusterPolicy.DeepCopy()
+			cluster.Spec.Connections.AccessModes = []DBaaSAccessModeRule{
+				{Namespaces: []string{"*"}, AllowedAccessModes: []ConnectionAccessMode{AccessModeReadWrite, AccessModeReadOnly}},
+			}
+			spec := &DBaaSPolicySpec{
+				DBaaSInventoryPolicy: DBaaSInventoryPolicy{
+					Connections: DBaaSConnectionPolicy{AccessModes: []DBaaSAccessModeRule{
+						{NsSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "analytics"}}, AllowedAccessModes: []ConnectionAccessMode{AccessModeReadOnly}},
+					}},
+				},
+			}
+			rules := NarrowPolicy(cluster, spec).Connections.AccessModes
+			Expect(rules).Should(HaveLen(2))
+
+			analytics := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "analytics", Labels: map[string]string{"team": "analytics"}}}
+			other := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "other"}}
+			for _, check := range []struct {
+				namespace  *corev1.Namespace
+				accessMode ConnectionAccessMode
+				allowed    bool
+			}{
+				{analytics, AccessModeReadOnly, true},
+				{analytics, AccessModeReadWrite, false},
+				{other, AccessModeReadWrite, true},
+				{other, AccessModeAdmin, false},
+			} {
+				allowed, err := AllowsAccessMode(rules, check.namespace, check.accessMode)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(allowed).Should(Equal(check.allowed), "%s in %s", check.accessMode, check.namespace.Name)
+			}
+			allowed, err := AllowsAccessMode(nil, other, AccessModeAdmin)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(allowed).Should(BeTrue())
+		})
+		It("should not change a policy without a cluster policy", func() {
+			spec := &DBaaSPolicySpec{DBaaSInventoryPolicy: DBaaSInventoryPolicy{DisableProvisions: &isFalse}}
+			Expect(NarrowPolicy(nil, spec)).Should(Equal(spec))
+		})
+	})
+})
@@ -0,0 +1,40 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExpiryTime returns the time the connection expires, from its expiresAt or from its ttl, nil if it does not expire
+func (r *DBaaSConnection) ExpiryTime() *metav1.Time {
+	if r.Spec.ExpiresAt != nil {
+		return r.Spec.ExpiresAt.DeepCopy()
+	}
+	if r.Spec.TTL != nil {
+		return &metav1.Time{Time: r.CreationTimestamp.Add(r.Spec.TTL.Duration)}
+	}
+	return nil
+}
+
+// GetAccessMode returns the access mode of the connection, ReadWrite if not set
+func (r *DBaaSConnection) GetAccessMode() ConnectionAccessMode {
+	if len(r.Spec.AccessMode) == 0 {
+		return AccessModeReadWrite
+	}
+	return r.Spec.AccessMode
+}
@@ -17,12 +17,32 @@ limitations under the License.
 package v1beta1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
 // NOTE: json tags are required.  Any new fields you add must have json tags for the fields to be serialized.
 
+// Defines the observed state of a DBaaSConnection object.
+type DBaaSOperatorConnectionStatus struct {
+	// The status reported by the provider's operator.
+	DBaaSConnectionStatus `json:",inline"`
+
+	// The secret projecting the credentials and connection information as a binding, following the Service Binding specification.
+	Binding *corev1.LocalObjectReference `json:"binding,omitempty"`
+
+	// A fingerprint of the data of the credentials secret and connection information config map last observed for the connection.
+	// A change of the fingerprint restarts the workloads bound to the connection.
+	CredentialsHash string `json:"credentialsHash,omitempty"`
+
+	// The last time the workloads bound to the connection were restarted after a change of its credentials or connection information.
+	LastRolloutRestart *metav1.Time `json:"lastRolloutRestart,omitempty"`
+
+	// The time the connection expires, from either its expiresAt or its ttl counted from its creation.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
 //+kubebuilder:storageversion
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -33,8 +53,8 @@ type DBaaSConnection struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   DBaaSConnectionSpec   `json:"spec,omitempty"`
-	Status DBaaSConnectionStatus `json:"status,omitempty"`
+	Spec   DBaaSConnectionSpec           `json:"spec,omitempty"`
+	Status DBaaSOperatorConnectionStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -17,13 +17,26 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
 	"reflect"
+	"strings"
 
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	inventoryRefKey       = "spec.inventoryRef"
+	databaseServiceRefKey = "spec.databaseServiceRef"
 )
 
 // log is for logging in this package.
@@ -31,11 +44,38 @@ var dbaasconnectionlog = logf.Log.WithName("dbaasconnection-resource")
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSConnection) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
+		WithDefaulter(&connectionRequesterDefaulter{}).
 		Complete()
 }
 
+//+kubebuilder:webhook:path=/mutate-dbaas-redhat-com-v1beta1-dbaasconnection,mutating=true,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasconnections,verbs=create,versions=v1beta1,name=mdbaasconnection.kb.io,admissionReviewVersions=v1beta1
+
+// connectionRequesterDefaulter records the user creating a DBaaSConnection in the ConnectionRequesterAnnotation
+// +kubebuilder:object:generate=false
+type connectionRequesterDefaulter struct{}
+
+var _ admission.CustomDefaulter = &connectionRequesterDefaulter{}
+
+// Default implements admission.CustomDefaulter so a webhook will be registered for the type
+func (d *connectionRequesterDefaulter) Default(ctx context.Context, obj runtime.Object) error {
+	connection := obj.(*DBaaSConnection)
+	dbaasconnectionlog.Info("default", "name", connection.Name)
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if connection.Annotations == nil {
+		connection.Annotations = map[string]string{}
+	}
+	connection.Annotations[ConnectionRequesterAnnotation] = req.UserInfo.Username
+	return nil
+}
+
 //+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasconnection,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasconnections,verbs=create;update,versions=v1beta1,name=vdbaasconnection.kb.io,admissionReviewVersions=v1beta1
 
 var _ webhook.Validator = &DBaaSConnection{}
@@ -43,7 +83,10 @@ var _ webhook.Validator = &DBaaSConnection{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSConnection) ValidateCreate() error {
 	dbaasconnectionlog.Info("validate create", "name", r.Name)
-	return r.validateCreateDBaaSConnectionSpec()
+	if err := r.validateCreateDBaaSConnectionSpec(); err != nil {
+		return err
+	}
+	return r.validateConnectionQuotas()
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -68,6 +111,102 @@ func (r *DBaaSConnection) validateCreateDBaaSConnectionSpec() error {
 	if r.Spec.DatabaseServiceRef != nil && r.Spec.DatabaseServiceType != nil {
 		return field.Invalid(field.NewPath("spec").Child("databaseServiceRef"), r.Spec.DatabaseServiceRef, "when using databaseServiceRef, databaseServiceType must not be specified")
 	}
+	if err := r.validateConnectionExpiry(); err != nil {
+		return err
+	}
+	return r.validateConnectionAccessMode()
+}
+
+// validateConnectionAccessMode checks the access mode and the database service type of a connection against the capabilities of the provider,
+// and the access mode against the access mode rules of the policies of its inventory
+func (r *DBaaSConnection) validateConnectionAccessMode() error {
+	rolesPath := field.NewPath("spec").Child("roles")
+	roles := map[string]bool{}
+	for i, role := range r.Spec.Roles {
+		if len(role) == 0 {
+			return field.Required(rolesPath.Index(i), "the role name must be specified")
+		}
+		if roles[role] {
+			return field.Duplicate(rolesPath.Index(i), role)
+		}
+		roles[role] = true
+	}
+
+	inventory := &DBaaSInventory{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: r.Spec.InventoryRef.Name, Namespace: r.Spec.InventoryRef.Namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	accessMode := r.GetAccessMode()
+	provider, err := getInventoryProvider(inventory)
+	if err != nil {
+		return err
+	}
+	if provider != nil {
+		capabilities := provider.GetCapabilities()
+		if !containsAccessMode(capabilities.AccessModes, accessMode) {
+			values := make([]string, len(capabilities.AccessModes))
+			for i := range capabilities.AccessModes {
+				values[i] = string(capabilities.AccessModes[i])
+			}
+			return field.NotSupported(field.NewPath("spec").Child("accessMode"), accessMode, values)
+		}
+		if r.Spec.DatabaseServiceType != nil && len(capabilities.DatabaseServiceTypes) > 0 &&
+			!containsServiceType(capabilities.DatabaseServiceTypes, *r.Spec.DatabaseServiceType) {
+			values := make([]string, len(capabilities.DatabaseServiceTypes))
+			for i := range capabilities.DatabaseServiceTypes {
+				values[i] = string(capabilities.DatabaseServiceTypes[i])
+			}
+			return field.NotSupported(field.NewPath("spec").Child("databaseServiceType"), *r.Spec.DatabaseServiceType, values)
+		}
+	}
+
+	activePolicy, err := getActivePolicy(inventory.Namespace)
+	if err != nil {
+		return err
+	}
+	clusterPolicy, err := getClusterPolicy()
+	if err != nil {
+		return err
+	}
+	rules := EffectiveAccessModeRules(inventory, activePolicy, clusterPolicy)
+	if len(rules) == 0 {
+		return nil
+	}
+	namespace := &corev1.Namespace{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: r.Namespace}, namespace); err != nil {
+		return err
+	}
+	allowed, err := AllowsAccessMode(rules, namespace, accessMode)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.NewForbidden(GroupVersion.WithResource("dbaasconnections").GroupResource(), r.Name,
+			fmt.Errorf("access mode %s is not allowed by the policy in namespace %s", accessMode, r.Namespace))
+	}
+	return nil
+}
+
+// containsServiceType returns true if a database service type is in the given list
+func containsServiceType(serviceTypes []DatabaseServiceType, serviceType DatabaseServiceType) bool {
+	for _, t := range serviceTypes {
+		if t == serviceType {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *DBaaSConnection) validateConnectionExpiry() error {
+	if r.Spec.ExpiresAt != nil && r.Spec.TTL != nil {
+		return field.Invalid(field.NewPath("spec").Child("ttl"), r.Spec.TTL.Duration.String(), "both expiresAt and ttl are specified")
+	}
+	if r.Spec.TTL != nil && r.Spec.TTL.Duration <= 0 {
+		return field.Invalid(field.NewPath("spec").Child("ttl"), r.Spec.TTL.Duration.String(), "ttl must be positive")
+	}
 	return nil
 }
 
@@ -88,5 +227,111 @@ func (r *DBaaSConnection) validateUpdateDBaaSConnectionSpec(old *DBaaSConnection
 		return field.Invalid(field.NewPath("spec").Child("databaseServiceType"), r.Spec.DatabaseServiceType, "databaseServiceType is immutable")
 	}
 
+	if r.GetAccessMode() != old.GetAccessMode() {
+		return field.Invalid(field.NewPath("spec").Child("accessMode"), r.Spec.AccessMode, "accessMode is immutable")
+	}
+
+	if !reflect.DeepEqual(r.Spec.Roles, old.Spec.Roles) {
+		return field.Invalid(field.NewPath("spec").Child("roles"), r.Spec.Roles, "roles is immutable")
+	}
+
+	if r.Annotations[ConnectionRequesterAnnotation] != old.Annotations[ConnectionRequesterAnnotation] {
+		return field.Invalid(field.NewPath("metadata").Child("annotations").Key(ConnectionRequesterAnnotation),
+			r.Annotations[ConnectionRequesterAnnotation], ConnectionRequesterAnnotation+" is immutable")
+	}
+
+	return r.validateConnectionExpiry()
+}
+
+// validateConnectionQuotas rejects the creation of a connection exceeding the quotas of the active policy of its inventory,
+// as a best-effort limit like validateInstanceQuotas
+func (r *DBaaSConnection) validateConnectionQuotas() error {
+	inventory := &DBaaSInventory{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: r.Spec.InventoryRef.Name, Namespace: r.Spec.InventoryRef.Namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	activePolicy, err := getActivePolicy(inventory.Namespace)
+	if err != nil || activePolicy == nil {
+		return err
+	}
+	clusterPolicy, err := getClusterPolicy()
+	if err != nil {
+		return err
+	}
+	quotas := NarrowPolicy(clusterPolicy, &activePolicy.Spec).Quotas
+	if quotas == nil || quotas.MaxConnectionsPerInstance == nil {
+		return nil
+	}
+	inventoryList := &DBaaSInventoryList{}
+	if err := WebhookAPIClient.List(context.TODO(), inventoryList, client.InNamespace(inventory.Namespace)); err != nil {
+		return err
+	}
+	instanceList := &DBaaSInstanceList{}
+	if err := WebhookAPIClient.List(context.TODO(), instanceList); err != nil {
+		return err
+	}
+	connectionList := &DBaaSConnectionList{}
+	if err := WebhookAPIClient.List(context.TODO(), connectionList); err != nil {
+		return err
+	}
+	instances := make([]*DBaaSInstance, len(instanceList.Items))
+	for i := range instanceList.Items {
+		instances[i] = &instanceList.Items[i]
+	}
+	inst := connectionInstance(r, instances)
+	if inst == nil {
+		return nil
+	}
+	name := types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}.String()
+	for _, usage := range quotas.QuotaUsage(inventoryList.Items, instanceList.Items, append(connectionList.Items, *r)) {
+		if usage.Type == QuotaConnectionsPerInstance && usage.Name == name && usage.Used > usage.Limit {
+			return errors.NewForbidden(GroupVersion.WithResource("dbaasconnections").GroupResource(), r.Name,
+				fmt.Errorf("exceeded quota %s of %s, limited to %d connections", usage.Type, usage.Name, usage.Limit))
+		}
+	}
+	return nil
+}
+
+// indexConnectionRef returns the index function for a connection reference, keyed by "namespace/name".
+// The connection namespace is used when the reference does not set one.
+func indexConnectionRef(refFn func(*DBaaSConnection) *NamespacedName) client.IndexerFunc {
+	return func(rawObj client.Object) []string {
+		connection := rawObj.(*DBaaSConnection)
+		ref := refFn(connection)
+		if ref == nil || len(ref.Name) == 0 {
+			return nil
+		}
+		ns := ref.Namespace
+		if len(ns) == 0 {
+			ns = connection.Namespace
+		}
+		return []string{types.NamespacedName{Namespace: ns, Name: ref.Name}.String()}
+	}
+}
+
+// validateNoDependentConnections rejects the deletion of an object still referenced by DBaaSConnections,
+// unless the object is annotated for force deletion.
+func validateNoDependentConnections(obj client.Object, indexKey string) error {
+	if obj.GetAnnotations()[ForceDeleteAnnotation] == "true" {
+		return nil
+	}
+	connectionList := &DBaaSConnectionList{}
+	if err := WebhookAPIClient.List(context.TODO(), connectionList, client.MatchingFields{indexKey: client.ObjectKeyFromObject(obj).String()}); err != nil {
+		return err
+	}
+	var dependents []string
+	for _, connection := range connectionList.Items {
+		if connection.DeletionTimestamp != nil {
+			continue
+		}
+		dependents = append(dependents, client.ObjectKeyFromObject(&connection).String())
+	}
+	if len(dependents) > 0 {
+		return fmt.Errorf("%s is still used by DBaaSConnections %s, delete them first or set the %s annotation to true",
+			obj.GetName(), strings.Join(dependents, ", "), ForceDeleteAnnotation)
+	}
 	return nil
 }
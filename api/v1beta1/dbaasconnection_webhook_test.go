@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/ginkgo/extensions/table"
 	. "github.com/onsi/gomega"
@@ -114,7 +116,30 @@ var _ = Describe("DBaaSConnection Webhook", func() {
 				},
 				"admission webhook \"vdbaasconnection.kb.io\" denied the request: "+
 					"spec.databaseServiceType: Invalid value: \"test-databaseServiceType\": databaseServiceType is immutable"),
+			Entry("not allow updating accessMode",
+				func(spec *DBaaSConnectionSpec) {
+					spec.AccessMode = AccessModeReadOnly
+				},
+				"admission webhook \"vdbaasconnection.kb.io\" denied the request: "+
+					"spec.accessMode: Invalid value: \"ReadOnly\": accessMode is immutable"),
+			Entry("not allow updating roles",
+				func(spec *DBaaSConnectionSpec) {
+					spec.Roles = []string{"reporting"}
+				},
+				"admission webhook \"vdbaasconnection.kb.io\" denied the request: "+
+					"spec.roles: Invalid value: []string{\"reporting\"}: roles is immutable"),
 		)
+
+		It("should record the requester", func() {
+			connection := &DBaaSConnection{}
+			err := k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSConnection), connection)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(connection.Annotations).Should(HaveKey(ConnectionRequesterAnnotation))
+
+			connection.Annotations[ConnectionRequesterAnnotation] = "system:serviceaccount:tenant:app"
+			err = k8sClient.Update(ctx, connection)
+			Expect(err).Should(MatchError(ContainSubstring(ConnectionRequesterAnnotation + " is immutable")))
+		})
 	})
 
 	Context("after trying to create DBaaSConnection without database service info", func() {
@@ -187,6 +212,116 @@ var _ = Describe("DBaaSConnection Webhook", func() {
 		})
 	})
 
+	Context("after trying to create DBaaSConnection with both expiresAt and ttl", func() {
+		It("should not allow creating the DBaaSConnection", func() {
+			testDBaaSConnectionBothExpiries := &DBaaSConnection{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      connectionName,
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSConnectionSpec{
+					InventoryRef: NamespacedName{
+						Name:      inventoryName,
+						Namespace: testNamespace,
+					},
+					DatabaseServiceID: databaseServiceID,
+					ExpiresAt:         &metav1.Time{Time: time.Now().Add(time.Hour)},
+					TTL:               &metav1.Duration{Duration: time.Hour},
+				},
+			}
+			err := k8sClient.Create(ctx, testDBaaSConnectionBothExpiries)
+			Expect(err).Should(MatchError("admission webhook \"vdbaasconnection.kb.io\" denied the request: " +
+				"spec.ttl: Invalid value: \"1h0m0s\": both expiresAt and ttl are specified"))
+		})
+		It("should not allow a negative ttl", func() {
+			testDBaaSConnectionNegativeTTL := testDBaaSConnection.DeepCopy()
+			testDBaaSConnectionNegativeTTL.SetResourceVersion("")
+			testDBaaSConnectionNegativeTTL.Spec.TTL = &metav1.Duration{Duration: -time.Hour}
+			err := k8sClient.Create(ctx, testDBaaSConnectionNegativeTTL)
+			Expect(err).Should(MatchError(ContainSubstring("ttl must be positive")))
+		})
+		It("should compute the expiry time", func() {
+			created := metav1.Now()
+			connection := &DBaaSConnection{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: created}}
+			Expect(connection.ExpiryTime()).Should(BeNil())
+			connection.Spec.TTL = &metav1.Duration{Duration: time.Hour}
+			Expect(connection.ExpiryTime().Time).Should(Equal(created.Add(time.Hour)))
+			connection.Spec.TTL = nil
+			connection.Spec.ExpiresAt = &metav1.Time{Time: created.Add(time.Minute)}
+			Expect(connection.ExpiryTime().Time).Should(Equal(created.Add(time.Minute)))
+		})
+	})
+
+	Context("after trying to create DBaaSConnection with duplicate roles", func() {
+		It("should not allow creating the DBaaSConnection", func() {
+			testDBaaSConnectionDuplicateRoles := testDBaaSConnection.DeepCopy()
+			testDBaaSConnectionDuplicateRoles.SetResourceVersion("")
+			testDBaaSConnectionDuplicateRoles.Spec.Roles = []string{"reporting", "reporting"}
+			err := k8sClient.Create(ctx, testDBaaSConnectionDuplicateRoles)
+			Expect(err).Should(MatchError(ContainSubstring("spec.roles[1]: Duplicate value")))
+		})
+	})
+
+	Context("after trying to create DBaaSConnection with an unsupported access mode", func() {
+		BeforeEach(assertResourceCreation(&testProvisioningProvider))
+		BeforeEach(assertResourceCreation(&testProvisioningSecret))
+		BeforeEach(assertResourceCreation(&testProvisioningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningSecret))
+		AfterEach(assertResourceDeletion(&testProvisioningProvider))
+
+		It("should not allow creating the DBaaSConnection", func() {
+			testDBaaSConnectionReadOnly := testDBaaSConnection.DeepCopy()
+			testDBaaSConnectionReadOnly.SetResourceVersion("")
+			testDBaaSConnectionReadOnly.Spec.InventoryRef.Name = testProvisioningInventory.Name
+			testDBaaSConnectionReadOnly.Spec.AccessMode = AccessModeReadOnly
+			err := k8sClient.Create(ctx, testDBaaSConnectionReadOnly)
+			Expect(err).Should(MatchError(ContainSubstring(`spec.accessMode: Unsupported value: "ReadOnly": supported values: "ReadWrite"`)))
+		})
+	})
+
+	Context("after trying to create DBaaSConnection with an access mode forbidden by the policy", func() {
+		accessModeProvider := testProvisioningProvider.DeepCopy()
+		accessModeProvider.Name = "test-access-mode-provider"
+		accessModeProvider.Spec.Provider.Name = accessModeProvider.Name
+		accessModeProvider.Spec.Capabilities = &DBaaSProviderCapabilities{
+			AccessModes: []ConnectionAccessMode{AccessModeReadWrite, AccessModeReadOnly},
+		}
+		accessModeInventory := testProvisioningInventory.DeepCopy()
+		accessModeInventory.Name = "test-access-mode-inventory"
+		accessModeInventory.Spec.ProviderRef.Name = accessModeProvider.Name
+		accessModeInventory.Spec.Policy = &DBaaSInventoryPolicy{
+			Connections: DBaaSConnectionPolicy{
+				AccessModes: []DBaaSAccessModeRule{
+					{Namespaces: []string{testNamespace}, AllowedAccessModes: []ConnectionAccessMode{AccessModeReadOnly}},
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(accessModeProvider))
+		BeforeEach(assertResourceCreation(&testProvisioningSecret))
+		BeforeEach(assertResourceCreation(accessModeInventory))
+		AfterEach(assertResourceDeletion(accessModeInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningSecret))
+		AfterEach(assertResourceDeletion(accessModeProvider))
+
+		It("should not allow creating the DBaaSConnection", func() {
+			testDBaaSConnectionReadWrite := testDBaaSConnection.DeepCopy()
+			testDBaaSConnectionReadWrite.SetResourceVersion("")
+			testDBaaSConnectionReadWrite.Spec.InventoryRef.Name = accessModeInventory.Name
+			testDBaaSConnectionReadWrite.Spec.AccessMode = AccessModeReadWrite
+			err := k8sClient.Create(ctx, testDBaaSConnectionReadWrite)
+			Expect(err).Should(MatchError(ContainSubstring("access mode ReadWrite is not allowed by the policy in namespace " + testNamespace)))
+		})
+		It("should allow creating the DBaaSConnection with an allowed access mode", func() {
+			testDBaaSConnectionReadOnly := testDBaaSConnection.DeepCopy()
+			testDBaaSConnectionReadOnly.SetResourceVersion("")
+			testDBaaSConnectionReadOnly.Spec.InventoryRef.Name = accessModeInventory.Name
+			testDBaaSConnectionReadOnly.Spec.AccessMode = AccessModeReadOnly
+			assertResourceCreation(testDBaaSConnectionReadOnly)()
+			assertResourceDeletion(testDBaaSConnectionReadOnly)()
+		})
+	})
+
 	Context("after creating DBaaSConnection without database service ID", func() {
 		var testDBaaSConnectionNoDatabaseServiceID = &DBaaSConnection{
 			ObjectMeta: metav1.ObjectMeta{
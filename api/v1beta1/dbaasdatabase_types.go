@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Instance",type=string,JSONPath=`.spec.databaseServiceRef.name`
+//+kubebuilder:printcolumn:name="Ready",type=string,JSONPath=`.status.conditions[?(@.type=="DatabaseReady")].status`
+
+// The schema for the DBaaSDatabase API.
+// Declares logical databases, schemas and roles inside a DBaaSInstance, so that the workloads sharing an instance
+// each get their own database and least-privilege user.
+// +operator-sdk:csv:customresourcedefinitions:displayName="DBaaSDatabase"
+type DBaaSDatabase struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSDatabaseSpec   `json:"spec,omitempty"`
+	Status DBaaSDatabaseStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of DBaaSDatabases.
+type DBaaSDatabaseList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSDatabase `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSDatabase{}, &DBaaSDatabaseList{})
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasdatabaselog = logf.Log.WithName("dbaasdatabase-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSDatabase) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasdatabase,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasdatabases,verbs=create;update,versions=v1beta1,name=vdbaasdatabase.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSDatabase{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSDatabase) ValidateCreate() error {
+	dbaasdatabaselog.Info("validate create", "name", r.Name)
+	return validateDatabase(r, nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSDatabase) ValidateUpdate(old runtime.Object) error {
+	dbaasdatabaselog.Info("validate update", "name", r.Name)
+	return validateDatabase(r, old.(*DBaaSDatabase))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSDatabase) ValidateDelete() error {
+	dbaasdatabaselog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+// validateDatabase checks the references between the databases and the roles, and that an update does not change the instance
+func validateDatabase(database *DBaaSDatabase, old *DBaaSDatabase) error {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if len(database.Spec.DatabaseServiceRef.Name) == 0 {
+		errs = append(errs, field.Required(specPath.Child("databaseServiceRef", "name"), "the instance must be specified"))
+	}
+	if old != nil && database.Spec.DatabaseServiceRef != old.Spec.DatabaseServiceRef {
+		errs = append(errs, field.Invalid(specPath.Child("databaseServiceRef"), database.Spec.DatabaseServiceRef, "databaseServiceRef is immutable"))
+	}
+
+	roles := map[string]bool{}
+	for i, role := range database.Spec.Roles {
+		if roles[role.Name] {
+			errs = append(errs, field.Duplicate(specPath.Child("roles").Index(i).Child("name"), role.Name))
+		}
+		roles[role.Name] = true
+	}
+	databases := map[string]bool{}
+	for i, db := range database.Spec.Databases {
+		dbPath := specPath.Child("databases").Index(i)
+		if databases[db.Name] {
+			errs = append(errs, field.Duplicate(dbPath.Child("name"), db.Name))
+		}
+		databases[db.Name] = true
+		if len(db.Owner) > 0 && !roles[db.Owner] {
+			errs = append(errs, field.NotFound(dbPath.Child("owner"), db.Owner))
+		}
+	}
+	for i, role := range database.Spec.Roles {
+		for j, grant := range role.Grants {
+			if !databases[grant.Database] {
+				errs = append(errs, field.NotFound(specPath.Child("roles").Index(i).Child("grants").Index(j).Child("database"), grant.Database))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSDatabase").GroupKind(), database.Name, errs)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testDBaaSDatabase = &DBaaSDatabase{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-database",
+		Namespace: testNamespace,
+	},
+	Spec: DBaaSDatabaseSpec{
+		DatabaseServiceRef: NamespacedName{Name: "test-instance"},
+		Databases:          []LogicalDatabase{{Name: "orders", Owner: "orders-owner", Schemas: []string{"public"}}},
+		Roles: []DatabaseRole{
+			{Name: "orders-owner"},
+			{Name: "orders-reader", Grants: []DatabaseGrant{{Database: "orders", Schema: "public", Privileges: []string{"SELECT"}}}},
+		},
+	},
+}
+
+var _ = Describe("DBaaSDatabase Webhook", func() {
+	Context("after creating DBaaSDatabase", func() {
+		BeforeEach(assertResourceCreation(testDBaaSDatabase))
+		AfterEach(assertResourceDeletion(testDBaaSDatabase))
+
+		It("should not allow updating the instance", func() {
+			updatedDBaaSDatabase := &DBaaSDatabase{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSDatabase), updatedDBaaSDatabase)).Should(Succeed())
+			updatedDBaaSDatabase.Spec.DatabaseServiceRef.Name = "other-instance"
+			err := k8sClient.Update(ctx, updatedDBaaSDatabase)
+			Expect(err).Should(MatchError(ContainSubstring("databaseServiceRef is immutable")))
+		})
+	})
+
+	Context("after trying to create an invalid DBaaSDatabase", func() {
+		DescribeTable("checking invalid DBaaSDatabase specs",
+			func(specFn func(*DBaaSDatabaseSpec), expectedErr string) {
+				database := testDBaaSDatabase.DeepCopy()
+				database.SetResourceVersion("")
+				specFn(&database.Spec)
+				err := k8sClient.Create(ctx, database)
+				Expect(err).Should(MatchError(ContainSubstring(expectedErr)))
+			},
+			Entry("not allow creating the DBaaSDatabase without the instance",
+				func(spec *DBaaSDatabaseSpec) {
+					spec.DatabaseServiceRef.Name = ""
+				},
+				"the instance must be specified"),
+			Entry("not allow creating the DBaaSDatabase with duplicate roles",
+				func(spec *DBaaSDatabaseSpec) {
+					spec.Roles = append(spec.Roles, DatabaseRole{Name: "orders-owner"})
+				},
+				"spec.roles[2].name: Duplicate value"),
+			Entry("not allow creating the DBaaSDatabase with an undeclared owner",
+				func(spec *DBaaSDatabaseSpec) {
+					spec.Databases[0].Owner = "unknown"
+				},
+				"spec.databases[0].owner: Not found"),
+			Entry("not allow creating the DBaaSDatabase with a grant on an undeclared database",
+				func(spec *DBaaSDatabaseSpec) {
+					spec.Roles[1].Grants[0].Database = "unknown"
+				},
+				"spec.roles[1].grants[0].database: Not found"),
+		)
+	})
+})
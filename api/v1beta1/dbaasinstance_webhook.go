@@ -17,17 +17,563 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
 
 // log is for logging in this package.
 var dbaasinstancelog = logf.Log.WithName("dbaasinstance-resource")
 
+// SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSInstance) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	// index connection by `spec.databaseServiceRef`
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSConnection{}, databaseServiceRefKey, indexConnectionRef(func(connection *DBaaSConnection) *NamespacedName {
+		return connection.Spec.DatabaseServiceRef
+	})); err != nil {
+		return err
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
-// TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
+//+kubebuilder:webhook:path=/mutate-dbaas-redhat-com-v1beta1-dbaasinstance,mutating=true,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create,versions=v1beta1,name=mdbaasinstance.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Defaulter = &DBaaSInstance{}
+
+// Default implements webhook.Defaulter so a webhook will be registered for the type.
+// The webhook is only called on creation, the provisioning parameters of an existing instance are never defaulted.
+func (r *DBaaSInstance) Default() {
+	dbaasinstancelog.Info("default", "name", r.Name)
+	provider, err := getInstanceProvider(r)
+	if err != nil {
+		dbaasinstancelog.Error(err, "provisioning parameters not defaulted", "name", r.Name)
+		return
+	}
+	if provider != nil {
+		defaultProvisioningParameters(r, provider.Spec.ProvisioningParameters)
+	}
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasinstance,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinstances,verbs=create;update;delete,versions=v1beta1,name=vdbaasinstance.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSInstance{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateCreate() error {
+	dbaasinstancelog.Info("validate create", "name", r.Name)
+	return validateInstance(r, nil)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateUpdate(old runtime.Object) error {
+	dbaasinstancelog.Info("validate update", "name", r.Name)
+	return validateInstance(r, old.(*DBaaSInstance))
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInstance) ValidateDelete() error {
+	dbaasinstancelog.Info("validate delete", "name", r.Name)
+	return validateNoDependentConnections(r, databaseServiceRefKey)
+}
+
+func validateInstance(inst *DBaaSInstance, oldInst *DBaaSInstance) error {
+	if oldInst != nil && inst.DeletionTimestamp == nil && !reflect.DeepEqual(inst.Spec.Source, oldInst.Spec.Source) {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSInstance").GroupKind(), inst.Name, field.ErrorList{
+			field.Invalid(field.NewPath("spec", "source"), inst.Spec.Source, "source is immutable"),
+		})
+	}
+	if oldInst == nil {
+		if errs := validateInstanceSourceSpec(inst.Spec.Source); len(errs) > 0 {
+			return errors.NewInvalid(GroupVersion.WithKind("DBaaSInstance").GroupKind(), inst.Name, errs)
+		}
+	}
+	// Metadata updates, such as the removal of the finalizer, must not be blocked by a change of the provider
+	if oldInst != nil && (inst.DeletionTimestamp != nil ||
+		reflect.DeepEqual(oldInst.Spec.ProvisioningParameters, inst.Spec.ProvisioningParameters)) {
+		return nil
+	}
+	inventory, err := getInstanceInventory(inst)
+	if err != nil || inventory == nil {
+		return err
+	}
+	activePolicy, err := getActivePolicy(inventory.Namespace)
+	if err != nil {
+		return err
+	}
+	clusterPolicy, err := getClusterPolicy()
+	if err != nil {
+		return err
+	}
+	if oldInst == nil && activePolicy != nil {
+		if err := validateInstanceQuotas(inst, inventory, NarrowPolicy(clusterPolicy, &activePolicy.Spec).Quotas); err != nil {
+			return err
+		}
+	}
+	errs := EffectiveProvisioningPolicy(inventory, activePolicy, clusterPolicy).ValidateProvisioningParameters(inst.Spec.ProvisioningParameters)
+	provider, err := getInventoryProvider(inventory)
+	if err != nil {
+		return err
+	}
+	if provider != nil {
+		capabilities := provider.GetCapabilities()
+		if oldInst == nil && !capabilities.SupportsProvisioning() {
+			return errors.NewForbidden(GroupVersion.WithResource("dbaasinstances").GroupResource(), inst.Name,
+				fmt.Errorf("provider %s does not support provisioning instances", provider.Name))
+		}
+		errs = append(errs, validateProvisioningParameters(inst.Spec.ProvisioningParameters, provider.Spec.ProvisioningParameters)...)
+		if oldInst != nil {
+			errs = append(errs, validateProvisioningParameterUpdates(inst, oldInst, provider.Spec.ProvisioningParameters, capabilities.SupportsUpdates())...)
+		}
+	}
+	if oldInst == nil && inst.Spec.Source != nil {
+		sourceErrs, err := validateInstanceSource(inst, provider)
+		if err != nil {
+			return err
+		}
+		errs = append(errs, sourceErrs...)
+	}
+	if len(errs) > 0 {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSInstance").GroupKind(), inst.Name, errs)
+	}
+	return nil
+}
+
+// validateInstanceSourceSpec checks that the source of a clone references either an instance or a backup,
+// and that a point in time is only requested for an instance
+func validateInstanceSourceSpec(source *DBaaSInstanceSource) field.ErrorList {
+	if source == nil {
+		return nil
+	}
+	var errs field.ErrorList
+	path := field.NewPath("spec", "source")
+	switch {
+	case source.InstanceRef == nil && source.BackupRef == nil:
+		errs = append(errs, field.Required(path, "either instanceRef or backupRef must be specified"))
+	case source.InstanceRef != nil && source.BackupRef != nil:
+		errs = append(errs, field.Invalid(path.Child("backupRef"), source.BackupRef.Name, "both instanceRef and backupRef are specified"))
+	case source.InstanceRef != nil && len(source.InstanceRef.Name) == 0:
+		errs = append(errs, field.Required(path.Child("instanceRef", "name"), "the source instance must be specified"))
+	case source.BackupRef != nil && len(source.BackupRef.Name) == 0:
+		errs = append(errs, field.Required(path.Child("backupRef", "name"), "the source backup must be specified"))
+	}
+	if source.PointInTime != nil && source.InstanceRef == nil {
+		errs = append(errs, field.Invalid(path.Child("pointInTime"), source.PointInTime, "a point in time requires instanceRef"))
+	}
+	return errs
+}
+
+// validateInstanceSource rejects a clone if its provider does not support cloning, or if its source uses another inventory.
+// A source that is not found is reported by the instance controller.
+func validateInstanceSource(inst *DBaaSInstance, provider *DBaaSProvider) (field.ErrorList, error) {
+	path := field.NewPath("spec", "source")
+	if provider != nil && !provider.GetCapabilities().SupportsCloning() {
+		return field.ErrorList{field.Forbidden(path, fmt.Sprintf("provider %s does not support cloning instances", provider.Name))}, nil
+	}
+	source, sourcePath, err := getInstanceSource(inst)
+	if err != nil || source == nil {
+		return nil, err
+	}
+	if source.Spec.InventoryRef != inst.Spec.InventoryRef {
+		return field.ErrorList{field.Invalid(path.Child(sourcePath), source.Name,
+			fmt.Sprintf("the source must use the inventory %s/%s of the instance", inst.Spec.InventoryRef.Namespace, inst.Spec.InventoryRef.Name))}, nil
+	}
+	return nil, nil
+}
+
+// getInstanceSource retrieves the instance a clone is created from, directly or through a backup, nil if it is not found.
+// It also returns the field of the source referencing the instance.
+func getInstanceSource(inst *DBaaSInstance) (*DBaaSInstance, string, error) {
+	sourceRef := inst.Spec.Source.InstanceRef
+	sourcePath := "instanceRef"
+	namespace := inst.Namespace
+	if inst.Spec.Source.BackupRef != nil {
+		sourcePath = "backupRef"
+		backup := &DBaaSBackup{}
+		if err := WebhookAPIClient.Get(context.TODO(), namespacedKey(*inst.Spec.Source.BackupRef, inst.Namespace), backup); err != nil {
+			if errors.IsNotFound(err) {
+				return nil, sourcePath, nil
+			}
+			return nil, sourcePath, err
+		}
+		sourceRef = &backup.Spec.InstanceRef
+		namespace = backup.Namespace
+	}
+	source := &DBaaSInstance{}
+	if err := WebhookAPIClient.Get(context.TODO(), namespacedKey(*sourceRef, namespace), source); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, sourcePath, nil
+		}
+		return nil, sourcePath, err
+	}
+	return source, sourcePath, nil
+}
+
+// validateProvisioningParameterUpdates checks that only the provisioning parameters declared as mutable by the provider are changed,
+// and that no parameter is changed if the provider does not support updates.
+// A parameter added with its default value, or a defaulted parameter removed, is not a change, as the provider applies the same default value.
+func validateProvisioningParameterUpdates(inst *DBaaSInstance, oldInst *DBaaSInstance, params map[ProvisioningParameterType]ProvisioningParameter, updates bool) field.ErrorList {
+	var errs field.ErrorList
+	path := field.NewPath("spec").Child("provisioningParameters")
+	defaulted := map[ProvisioningParameterType]string{}
+	if annotation, ok := inst.Annotations[DefaultedParametersAnnotation]; ok {
+		_ = json.Unmarshal([]byte(annotation), &defaulted)
+	}
+	for _, key := range changedParameterTypes(oldInst.Spec.ProvisioningParameters, inst.Spec.ProvisioningParameters) {
+		param, ok := params[key]
+		if !ok && updates {
+			continue
+		}
+		oldValue, existed := oldInst.Spec.ProvisioningParameters[key]
+		value, exists := inst.Spec.ProvisioningParameters[key]
+		if defaultValue, ok := defaulted[key]; ok && existed != exists &&
+			((exists && defaultValue == value) || (existed && defaultValue == oldValue)) {
+			continue
+		}
+		if !updates {
+			errs = append(errs, field.Forbidden(path.Key(string(key)),
+				fmt.Sprintf("%s cannot be changed from %q to %q, the provider does not support updating instances", key, oldValue, value)))
+		} else if param.Mutability != ParameterUpdatable && param.Mutability != ParameterRequiresRestart {
+			errs = append(errs, field.Forbidden(path.Key(string(key)),
+				fmt.Sprintf("%s is immutable, it cannot be changed from %q to %q", key, oldValue, value)))
+		}
+	}
+	return errs
+}
+
+// changedParameterTypes returns the parameters added, removed or changed between two sets of provisioning parameters
+func changedParameterTypes(oldValues, values map[ProvisioningParameterType]string) []ProvisioningParameterType {
+	changed := map[ProvisioningParameterType]bool{}
+	for key, value := range values {
+		if oldValue, ok := oldValues[key]; !ok || oldValue != value {
+			changed[key] = true
+		}
+	}
+	for key := range oldValues {
+		if _, ok := values[key]; !ok {
+			changed[key] = true
+		}
+	}
+	return sortedParameterTypes(changed)
+}
+
+// validateInstanceQuotas rejects the creation of an instance exceeding the quotas of the active policy of its inventory.
+// The quotas are a best-effort limit: the instances are counted from the cache of the webhook, without any lock,
+// so concurrent creations can all be admitted and exceed a quota. The policy status reports the actual usage.
+func validateInstanceQuotas(inst *DBaaSInstance, inventory *DBaaSInventory, quotas *DBaaSQuotaPolicy) error {
+	if quotas == nil || (quotas.MaxInstancesPerNamespace == nil && quotas.MaxInstancesPerInventory == nil && quotas.MaxInstancesPerProvider == nil) {
+		return nil
+	}
+	inventoryList := &DBaaSInventoryList{}
+	if err := WebhookAPIClient.List(context.TODO(), inventoryList, client.InNamespace(inventory.Namespace)); err != nil {
+		return err
+	}
+	instanceList := &DBaaSInstanceList{}
+	if err := WebhookAPIClient.List(context.TODO(), instanceList); err != nil {
+		return err
+	}
+	instances := append(instanceList.Items, *inst)
+	names := map[DBaaSQuotaType]string{
+		QuotaInstancesPerNamespace: inst.Namespace,
+		QuotaInstancesPerInventory: inventory.Name,
+		QuotaInstancesPerProvider:  inventory.Spec.ProviderRef.Name,
+	}
+	for _, usage := range quotas.QuotaUsage(inventoryList.Items, instances, nil) {
+		if names[usage.Type] == usage.Name && usage.Used > usage.Limit {
+			return errors.NewForbidden(GroupVersion.WithResource("dbaasinstances").GroupResource(), inst.Name,
+				fmt.Errorf("exceeded quota %s of %s, limited to %d instances", usage.Type, usage.Name, usage.Limit))
+		}
+	}
+	return nil
+}
+
+// getActivePolicy retrieves the active policy of a namespace, nil if there is none
+func getActivePolicy(namespace string) (*DBaaSPolicy, error) {
+	policyList := &DBaaSPolicyList{}
+	if err := WebhookAPIClient.List(context.TODO(), policyList, client.InNamespace(namespace)); err != nil {
+		return nil, err
+	}
+	if policy := ElectActivePolicy(policyList.Items); policy != nil && apimeta.IsStatusConditionTrue(policy.Status.Conditions, DBaaSPolicyReadyType) {
+		return policy, nil
+	}
+	return nil, nil
+}
+
+// getClusterPolicy retrieves the cluster policy, nil if there is none
+func getClusterPolicy() (*DBaaSClusterPolicy, error) {
+	clusterPolicy := &DBaaSClusterPolicy{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: ClusterPolicyName}, clusterPolicy); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return clusterPolicy, nil
+}
+
+// getInstanceProvider retrieves the provider of the inventory referenced by an instance.
+// It returns nil if the inventory or the provider is not found, which is reported by the instance controller.
+func getInstanceProvider(inst *DBaaSInstance) (*DBaaSProvider, error) {
+	inventory, err := getInstanceInventory(inst)
+	if err != nil || inventory == nil {
+		return nil, err
+	}
+	return getInventoryProvider(inventory)
+}
+
+// getInstanceInventory retrieves the inventory referenced by an instance, nil if it is not found
+func getInstanceInventory(inst *DBaaSInstance) (*DBaaSInventory, error) {
+	inventory := &DBaaSInventory{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inst.Spec.InventoryRef.Name, Namespace: inst.Spec.InventoryRef.Namespace}, inventory); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return inventory, nil
+}
+
+// namespacedKey returns the key of a reference, in the given namespace if the reference does not set one
+func namespacedKey(ref NamespacedName, namespace string) types.NamespacedName {
+	if len(ref.Namespace) > 0 {
+		namespace = ref.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+}
+
+// getInventoryProvider retrieves the provider of an inventory, nil if it is not found
+func getInventoryProvider(inventory *DBaaSInventory) (*DBaaSProvider, error) {
+	provider := &DBaaSProvider{}
+	if err := WebhookAPIClient.Get(context.TODO(), types.NamespacedName{Name: inventory.Spec.ProviderRef.Name, Namespace: ""}, provider); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return provider, nil
+}
+
+// defaultProvisioningParameters fills the omitted provisioning parameters of an instance with the default values of the provider,
+// and records the defaulted values in the DefaultedParametersAnnotation annotation.
+func defaultProvisioningParameters(inst *DBaaSInstance, params map[ProvisioningParameterType]ProvisioningParameter) {
+	resolved := resolveProvisioningParameters(inst.Spec.ProvisioningParameters, params)
+	defaulted := map[ProvisioningParameterType]string{}
+	for key, value := range resolved {
+		if _, ok := inst.Spec.ProvisioningParameters[key]; !ok {
+			if inst.Spec.ProvisioningParameters == nil {
+				inst.Spec.ProvisioningParameters = map[ProvisioningParameterType]string{}
+			}
+			inst.Spec.ProvisioningParameters[key] = value
+			defaulted[key] = value
+		}
+	}
+
+	if len(defaulted) == 0 {
+		delete(inst.Annotations, DefaultedParametersAnnotation)
+		return
+	}
+	annotation, err := json.Marshal(defaulted)
+	if err != nil {
+		dbaasinstancelog.Error(err, "defaulted provisioning parameters not recorded", "name", inst.Name)
+		return
+	}
+	if inst.Annotations == nil {
+		inst.Annotations = map[string]string{}
+	}
+	inst.Annotations[DefaultedParametersAnnotation] = string(annotation)
+}
+
+// validateProvisioningParameters checks the provisioning parameters of an instance against the parameters supported by the provider.
+// Each value must be one of the options of the conditional data matching the other parameters, with the default values applied.
+func validateProvisioningParameters(values map[ProvisioningParameterType]string, params map[ProvisioningParameterType]ProvisioningParameter) field.ErrorList {
+	// a provider without provisioning parameters does not describe the parameters it supports
+	if len(params) == 0 {
+		return nil
+	}
+	var errs field.ErrorList
+	path := field.NewPath("spec").Child("provisioningParameters")
+	resolved := resolveProvisioningParameters(values, params)
+	for _, key := range sortedParameterTypes(values) {
+		value := values[key]
+		param, ok := params[key]
+		if !ok {
+			errs = append(errs, field.NotSupported(path.Key(string(key)), key, sortedParameterNames(params)))
+			continue
+		}
+		if len(param.ConditionalData) == 0 {
+			continue
+		}
+		data := matchConditionalData(param, resolved)
+		if data == nil {
+			errs = append(errs, field.Invalid(path.Key(string(key)), value,
+				fmt.Sprintf("%s is not available for %s", key, describeDependencies(param, resolved))))
+			continue
+		}
+		if len(data.Options) > 0 && !hasOption(data.Options, value) {
+			var options []string
+			for _, option := range data.Options {
+				options = append(options, option.Value)
+			}
+			errs = append(errs, field.NotSupported(path.Key(string(key)), value, options))
+		}
+	}
+	return errs
+}
+
+// provisioningParameterOrder is the order the default values of the parameters depending on each other are evaluated in
+var provisioningParameterOrder = []ProvisioningParameterType{
+	ProvisioningPlan,
+	ProvisioningCloudProvider,
+	ProvisioningRegions,
+	ProvisioningMachineType,
+}
+
+// resolveProvisioningParameters returns the provisioning parameters with the default values of the missing parameters applied.
+// The default value of a parameter is evaluated once the parameters it depends on are resolved, in the provisioningParameterOrder order,
+// followed by the other parameters in alphabetical order.
+func resolveProvisioningParameters(values map[ProvisioningParameterType]string, params map[ProvisioningParameterType]ProvisioningParameter) map[ProvisioningParameterType]string {
+	resolved := make(map[ProvisioningParameterType]string, len(params))
+	for key, value := range values {
+		resolved[key] = value
+	}
+	pending := map[ProvisioningParameterType]bool{}
+	for key := range params {
+		if _, ok := resolved[key]; !ok {
+			pending[key] = true
+		}
+	}
+	applyDefault := func(key ProvisioningParameterType) {
+		delete(pending, key)
+		if data := matchConditionalData(params[key], resolved); data != nil && len(data.DefaultValue) > 0 {
+			resolved[key] = data.DefaultValue
+		}
+	}
+	for progress := true; progress; {
+		progress = false
+		for _, key := range orderedParameterTypes(pending) {
+			if !dependsOnPending(params[key], key, pending) {
+				applyDefault(key)
+				progress = true
+			}
+		}
+	}
+	// parameters depending on each other
+	for _, key := range orderedParameterTypes(pending) {
+		applyDefault(key)
+	}
+	return resolved
+}
+
+// dependsOnPending returns true if the conditional data of a parameter depends on another parameter not resolved yet
+func dependsOnPending(param ProvisioningParameter, key ProvisioningParameterType, pending map[ProvisioningParameterType]bool) bool {
+	for _, data := range param.ConditionalData {
+		for _, dependency := range data.Dependencies {
+			if dependency.Field != key && pending[dependency.Field] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// orderedParameterTypes sorts parameters in the provisioningParameterOrder order, followed by the other parameters in alphabetical order
+func orderedParameterTypes(keys map[ProvisioningParameterType]bool) []ProvisioningParameterType {
+	var ordered []ProvisioningParameterType
+	for _, key := range provisioningParameterOrder {
+		if keys[key] {
+			ordered = append(ordered, key)
+		}
+	}
+	for _, key := range sortedParameterTypes(keys) {
+		if !hasParameterType(provisioningParameterOrder, key) {
+			ordered = append(ordered, key)
+		}
+	}
+	return ordered
+}
+
+func hasParameterType(keys []ProvisioningParameterType, key ProvisioningParameterType) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+// matchConditionalData returns the most specific conditional data of a parameter whose dependencies all match the given values
+func matchConditionalData(param ProvisioningParameter, values map[ProvisioningParameterType]string) *ConditionalProvisioningParameterData {
+	var match *ConditionalProvisioningParameterData
+	for i := range param.ConditionalData {
+		data := &param.ConditionalData[i]
+		matches := true
+		for _, dependency := range data.Dependencies {
+			if values[dependency.Field] != dependency.Value {
+				matches = false
+				break
+			}
+		}
+		if matches && (match == nil || len(data.Dependencies) > len(match.Dependencies)) {
+			match = data
+		}
+	}
+	return match
+}
+
+// describeDependencies lists the values of the fields the conditional data of a parameter depends on
+func describeDependencies(param ProvisioningParameter, values map[ProvisioningParameterType]string) string {
+	fields := map[ProvisioningParameterType]string{}
+	for _, data := range param.ConditionalData {
+		for _, dependency := range data.Dependencies {
+			fields[dependency.Field] = values[dependency.Field]
+		}
+	}
+	var dependencies []string
+	for _, key := range sortedParameterTypes(fields) {
+		dependencies = append(dependencies, fmt.Sprintf("%s=%q", key, fields[key]))
+	}
+	return strings.Join(dependencies, ", ")
+}
+
+func hasOption(options []Option, value string) bool {
+	for _, option := range options {
+		if option.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedParameterTypes[V any](m map[ProvisioningParameterType]V) []ProvisioningParameterType {
+	keys := make([]ProvisioningParameterType, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+	return keys
+}
+
+func sortedParameterNames(params map[ProvisioningParameterType]ProvisioningParameter) []string {
+	var names []string
+	for _, key := range sortedParameterTypes(params) {
+		names = append(names, string(key))
+	}
+	return names
+}
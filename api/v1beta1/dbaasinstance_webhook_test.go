@@ -0,0 +1,603 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	testDBaaSInstance = DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-instance",
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSInstanceSpec{
+			InventoryRef: NamespacedName{
+				Name:      inventoryName,
+				Namespace: testNamespace,
+			},
+			ProvisioningParameters: map[ProvisioningParameterType]string{
+				ProvisioningName: "test-instance",
+			},
+		},
+	}
+	testProvisioningParameters = map[ProvisioningParameterType]ProvisioningParameter{
+		ProvisioningName: {
+			DisplayName: "Cluster name",
+		},
+		ProvisioningPlan: {
+			DisplayName: "Hosting plan",
+			ConditionalData: []ConditionalProvisioningParameterData{
+				{
+					Options: []Option{
+						{Value: "SERVERLESS", DisplayValue: "Serverless"},
+						{Value: "DEDICATED", DisplayValue: "Dedicated"},
+					},
+					DefaultValue: "SERVERLESS",
+				},
+			},
+		},
+		ProvisioningCloudProvider: {
+			DisplayName: "Cloud provider",
+			ConditionalData: []ConditionalProvisioningParameterData{
+				{
+					Options: []Option{
+						{Value: "AWS", DisplayValue: "Amazon Web Services"},
+						{Value: "GCP", DisplayValue: "Google Cloud Platform"},
+					},
+					DefaultValue: "AWS",
+				},
+			},
+		},
+		ProvisioningRegions: {
+			DisplayName: "Region",
+			ConditionalData: []ConditionalProvisioningParameterData{
+				{
+					Dependencies: []FieldDependency{{Field: ProvisioningCloudProvider, Value: "AWS"}},
+					Options:      []Option{{Value: "us-east-1"}, {Value: "eu-west-1"}},
+					DefaultValue: "us-east-1",
+				},
+				{
+					Dependencies: []FieldDependency{{Field: ProvisioningCloudProvider, Value: "GCP"}},
+					Options:      []Option{{Value: "us-central1"}, {Value: "europe-west1"}},
+					DefaultValue: "us-central1",
+				},
+			},
+			Mutability: ParameterUpdatable,
+		},
+		ProvisioningMachineType: {
+			DisplayName: "Instance size",
+			ConditionalData: []ConditionalProvisioningParameterData{
+				{
+					Dependencies: []FieldDependency{{Field: ProvisioningPlan, Value: "DEDICATED"}, {Field: ProvisioningCloudProvider, Value: "AWS"}},
+					Options:      []Option{{Value: "M10"}, {Value: "M20"}},
+					DefaultValue: "M10",
+				},
+			},
+			Mutability: ParameterRequiresRestart,
+		},
+	}
+	testProvisioningProvider = DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "test-provisioning-provider",
+		},
+		Spec: DBaaSProviderSpec{
+			Provider: DatabaseProviderInfo{
+				Name: "test-provisioning-provider",
+			},
+			InventoryKind:          testInventoryKind,
+			ConnectionKind:         testConnectionKind,
+			InstanceKind:           testInstanceKind,
+			CredentialFields:       []CredentialField{},
+			ProvisioningParameters: testProvisioningParameters,
+		},
+	}
+	testProvisioningSecret = corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-provisioning-secret",
+			Namespace: testNamespace,
+		},
+	}
+	testProvisioningInventory = DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-provisioning-inventory",
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSOperatorInventorySpec{
+			ProviderRef: NamespacedName{
+				Name: testProvisioningProvider.Name,
+			},
+			DBaaSInventorySpec: DBaaSInventorySpec{
+				CredentialsRef: &LocalObjectReference{
+					Name: testProvisioningSecret.Name,
+				},
+			},
+		},
+	}
+)
+
+var _ = Describe("DBaaSInstance Webhook", func() {
+	Context("deletion", func() {
+		Context("with a DBaaSConnection referencing the instance", func() {
+			conn := testDBaaSConnection.DeepCopy()
+			conn.Name = "test-connection-instance"
+			conn.Spec.DatabaseServiceID = ""
+			conn.Spec.DatabaseServiceRef = &NamespacedName{
+				Name: testDBaaSInstance.Name,
+			}
+			BeforeEach(assertResourceCreation(&testDBaaSInstance))
+			BeforeEach(assertResourceCreation(conn))
+			AfterEach(assertResourceDeletion(conn))
+			AfterEach(assertResourceDeletion(&testDBaaSInstance))
+			It("should not allow deleting the instance", func() {
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, &testDBaaSInstance)
+				}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinstance.kb.io\" denied the request: " +
+					"test-instance is still used by DBaaSConnections " + testNamespace + "/test-connection-instance, delete them first or set the dbaas.redhat.com/force-delete annotation to true"))
+			})
+		})
+		It("should allow deleting the instance with the force delete annotation", func() {
+			instance := testDBaaSInstance.DeepCopy()
+			instance.Name = "test-instance-force"
+			conn := testDBaaSConnection.DeepCopy()
+			conn.Name = "test-connection-instance-force"
+			conn.Spec.DatabaseServiceID = ""
+			conn.Spec.DatabaseServiceRef = &NamespacedName{
+				Name: instance.Name,
+			}
+			assertResourceCreation(instance)()
+			assertResourceCreation(conn)()
+			Eventually(func() error {
+				return k8sClient.Delete(ctx, instance)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			instance.SetAnnotations(map[string]string{ForceDeleteAnnotation: "true"})
+			Expect(k8sClient.Update(ctx, instance)).Should(Succeed())
+			assertResourceDeletion(instance)()
+			assertResourceDeletion(conn)()
+		})
+	})
+
+	Context("provisioning parameters", func() {
+		BeforeEach(assertResourceCreation(&testProvisioningProvider))
+		BeforeEach(assertResourceCreation(&testProvisioningSecret))
+		BeforeEach(assertResourceCreation(&testProvisioningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningSecret))
+		AfterEach(assertResourceDeletion(&testProvisioningProvider))
+
+		provisioningInstance := func(name string, parameters map[ProvisioningParameterType]string) *DBaaSInstance {
+			return &DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSInstanceSpec{
+					InventoryRef: NamespacedName{
+						Name:      testProvisioningInventory.Name,
+						Namespace: testNamespace,
+					},
+					ProvisioningParameters: parameters,
+				},
+			}
+		}
+
+		It("should allow creating an instance with valid parameters", func() {
+			instance := provisioningInstance("test-instance-valid", map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-instance",
+				ProvisioningPlan:          "DEDICATED",
+				ProvisioningCloudProvider: "AWS",
+				ProvisioningRegions:       "eu-west-1",
+				ProvisioningMachineType:   "M20",
+			})
+			assertResourceCreation(instance)()
+			assertResourceDeletion(instance)()
+		})
+		It("should allow creating an instance relying on default values", func() {
+			instance := provisioningInstance("test-instance-defaults", map[ProvisioningParameterType]string{
+				ProvisioningName:    "test-instance",
+				ProvisioningRegions: "eu-west-1",
+			})
+			assertResourceCreation(instance)()
+			assertResourceDeletion(instance)()
+		})
+		It("should default the omitted parameters", func() {
+			instance := provisioningInstance("test-instance-defaulted", map[ProvisioningParameterType]string{
+				ProvisioningName:        "test-instance",
+				ProvisioningPlan:        "DEDICATED",
+				ProvisioningMachineType: "M20",
+			})
+			assertResourceCreation(instance)()
+			Expect(instance.Spec.ProvisioningParameters).Should(Equal(map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-instance",
+				ProvisioningPlan:          "DEDICATED",
+				ProvisioningCloudProvider: "AWS",
+				ProvisioningRegions:       "us-east-1",
+				ProvisioningMachineType:   "M20",
+			}))
+			Expect(instance.Annotations).Should(HaveKeyWithValue(DefaultedParametersAnnotation, `{"cloudProvider":"AWS","regions":"us-east-1"}`))
+
+			By("removing a defaulted parameter")
+			delete(instance.Spec.ProvisioningParameters, ProvisioningCloudProvider)
+			Expect(k8sClient.Update(ctx, instance)).Should(Succeed())
+			Expect(instance.Spec.ProvisioningParameters).ShouldNot(HaveKey(ProvisioningCloudProvider))
+			Expect(instance.Annotations).Should(HaveKeyWithValue(DefaultedParametersAnnotation, `{"cloudProvider":"AWS","regions":"us-east-1"}`))
+			assertResourceDeletion(instance)()
+		})
+		It("should not allow creating an instance with an invalid parameter combination", func() {
+			instance := provisioningInstance("test-instance-invalid", map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-instance",
+				ProvisioningCloudProvider: "GCP",
+				ProvisioningRegions:       "eu-west-1",
+			})
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError(ContainSubstring(
+				`spec.provisioningParameters[regions]: Unsupported value: "eu-west-1": supported values: "us-central1", "europe-west1"`)))
+		})
+		It("should only allow updating the mutable parameters", func() {
+			instance := provisioningInstance("test-instance-mutability", map[ProvisioningParameterType]string{
+				ProvisioningName:          "test-instance",
+				ProvisioningPlan:          "DEDICATED",
+				ProvisioningCloudProvider: "AWS",
+			})
+			assertResourceCreation(instance)()
+
+			By("updating mutable parameters")
+			instance.Spec.ProvisioningParameters[ProvisioningRegions] = "eu-west-1"
+			instance.Spec.ProvisioningParameters[ProvisioningMachineType] = "M20"
+			Expect(k8sClient.Update(ctx, instance)).Should(Succeed())
+
+			By("updating an immutable parameter")
+			instance.Spec.ProvisioningParameters[ProvisioningCloudProvider] = "GCP"
+			instance.Spec.ProvisioningParameters[ProvisioningRegions] = "us-central1"
+			delete(instance.Spec.ProvisioningParameters, ProvisioningMachineType)
+			Expect(k8sClient.Update(ctx, instance)).Should(MatchError(ContainSubstring(
+				`spec.provisioningParameters[cloudProvider]: Forbidden: cloudProvider is immutable, it cannot be changed from "AWS" to "GCP"`)))
+			assertResourceDeletion(instance)()
+		})
+		It("should not allow updating an instance with an invalid parameter", func() {
+			instance := provisioningInstance("test-instance-update", map[ProvisioningParameterType]string{
+				ProvisioningName: "test-instance",
+			})
+			assertResourceCreation(instance)()
+			instance.Spec.ProvisioningParameters[ProvisioningPlan] = "FREE"
+			Expect(k8sClient.Update(ctx, instance)).Should(MatchError(ContainSubstring(
+				`spec.provisioningParameters[plan]: Unsupported value: "FREE": supported values: "SERVERLESS", "DEDICATED"`)))
+			assertResourceDeletion(instance)()
+		})
+		It("should not allow creating an instance beyond the quotas of the active policy", func() {
+			maxInstances := int32(1)
+			policy := &DBaaSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-quota-policy",
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSPolicySpec{
+					Quotas: &DBaaSQuotaPolicy{MaxInstancesPerInventory: &maxInstances},
+				},
+			}
+			assertResourceCreation(policy)()
+			defer assertResourceDeletion(policy)()
+			apimeta.SetStatusCondition(&policy.Status.Conditions, metav1.Condition{
+				Type:   DBaaSPolicyReadyType,
+				Status: metav1.ConditionTrue,
+				Reason: Ready,
+			})
+			Expect(k8sClient.Status().Update(ctx, policy)).Should(Succeed())
+
+			instance := provisioningInstance("test-instance-quota", map[ProvisioningParameterType]string{
+				ProvisioningName: "test-instance",
+			})
+			assertResourceCreation(instance)()
+			defer assertResourceDeletion(instance)()
+			Eventually(func() error {
+				other := provisioningInstance("", map[ProvisioningParameterType]string{
+					ProvisioningName: "test-instance",
+				})
+				other.GenerateName = "test-instance-quota-"
+				err := k8sClient.Create(ctx, other)
+				if err == nil {
+					// the webhook is not aware of the policy yet
+					Expect(k8sClient.Delete(ctx, other)).Should(Succeed())
+				}
+				return err
+			}, timeout, interval).Should(MatchError(ContainSubstring(
+				fmt.Sprintf("exceeded quota InstancesPerInventory of %s, limited to 1 instances", testProvisioningInventory.Name))))
+		})
+		It("should not allow creating an instance beyond the provisioning guardrails", func() {
+			inventory := &DBaaSInventory{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(&testProvisioningInventory), inventory)).Should(Succeed())
+			inventory.Spec.Policy = &DBaaSInventoryPolicy{
+				Provisioning: &DBaaSProvisioningPolicy{
+					AllowedPlans:   []string{ProvisioningPlanServerless},
+					AllowedRegions: []string{"us-east-1"},
+				},
+			}
+			Expect(k8sClient.Update(ctx, inventory)).Should(Succeed())
+
+			instance := provisioningInstance("test-instance-guardrails", map[ProvisioningParameterType]string{
+				ProvisioningName:    "test-instance",
+				ProvisioningPlan:    "DEDICATED",
+				ProvisioningRegions: "us-east-1",
+			})
+			Expect(k8sClient.Create(ctx, instance)).Should(MatchError(ContainSubstring(
+				`spec.provisioningParameters[plan]: Forbidden: plan "DEDICATED" is not allowed by the policy, allowed values: ["SERVERLESS"]`)))
+
+			instance.Spec.ProvisioningParameters[ProvisioningPlan] = "SERVERLESS"
+			assertResourceCreation(instance)()
+			assertResourceDeletion(instance)()
+		})
+	})
+
+	Context("cloning", func() {
+		cloningProvider := testProvisioningProvider.DeepCopy()
+		cloningProvider.Name = "test-cloning-provider"
+		cloningProvider.Spec.Provider.Name = cloningProvider.Name
+		cloningProvider.Spec.Capabilities = &DBaaSProviderCapabilities{Cloning: pointer.Bool(true)}
+		cloningInventory := testProvisioningInventory.DeepCopy()
+		cloningInventory.Name = "test-cloning-inventory"
+		cloningInventory.Spec.ProviderRef.Name = cloningProvider.Name
+
+		BeforeEach(assertResourceCreation(&testProvisioningProvider))
+		BeforeEach(assertResourceCreation(cloningProvider))
+		BeforeEach(assertResourceCreation(&testProvisioningSecret))
+		BeforeEach(assertResourceCreation(&testProvisioningInventory))
+		BeforeEach(assertResourceCreation(cloningInventory))
+		AfterEach(assertResourceDeletion(cloningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningInventory))
+		AfterEach(assertResourceDeletion(&testProvisioningSecret))
+		AfterEach(assertResourceDeletion(cloningProvider))
+		AfterEach(assertResourceDeletion(&testProvisioningProvider))
+
+		cloningInstance := func(name string, inventory *DBaaSInventory, source *DBaaSInstanceSource) *DBaaSInstance {
+			return &DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      name,
+					Namespace: testNamespace,
+				},
+				Spec: DBaaSInstanceSpec{
+					InventoryRef: NamespacedName{
+						Name:      inventory.Name,
+						Namespace: inventory.Namespace,
+					},
+					ProvisioningParameters: map[ProvisioningParameterType]string{
+						ProvisioningName: name,
+					},
+					Source: source,
+				},
+			}
+		}
+
+		It("should not allow cloning an instance for a provider without cloning support", func() {
+			clone := cloningInstance("test-clone-unsupported", &testProvisioningInventory, &DBaaSInstanceSource{
+				InstanceRef: &NamespacedName{Name: "test-clone-source"},
+			})
+			Expect(k8sClient.Create(ctx, clone)).Should(MatchError(ContainSubstring(
+				"spec.source: Forbidden: provider test-provisioning-provider does not support cloning instances")))
+		})
+		It("should allow cloning an instance of the same inventory", func() {
+			source := cloningInstance("test-clone-source", cloningInventory, nil)
+			assertResourceCreation(source)()
+			clone := cloningInstance("test-clone", cloningInventory, &DBaaSInstanceSource{
+				InstanceRef: &NamespacedName{Name: source.Name},
+			})
+			assertResourceCreation(clone)()
+
+			By("changing the source of the clone")
+			clone.Spec.Source.PointInTime = &metav1.Time{Time: clone.CreationTimestamp.Time}
+			Expect(k8sClient.Update(ctx, clone)).Should(MatchError(ContainSubstring("source is immutable")))
+			assertResourceDeletion(clone)()
+			assertResourceDeletion(source)()
+		})
+		It("should not allow cloning an instance of another inventory", func() {
+			source := cloningInstance("test-clone-other-source", &testProvisioningInventory, nil)
+			assertResourceCreation(source)()
+			clone := cloningInstance("test-clone-other", cloningInventory, &DBaaSInstanceSource{
+				InstanceRef: &NamespacedName{Name: source.Name},
+			})
+			Expect(k8sClient.Create(ctx, clone)).Should(MatchError(ContainSubstring(
+				"spec.source.instanceRef: Invalid value: \"test-clone-other-source\": the source must use the inventory " + testNamespace + "/test-cloning-inventory of the instance")))
+			assertResourceDeletion(source)()
+		})
+	})
+
+	Context("source validation", func() {
+		It("should require either an instance or a backup", func() {
+			Expect(validateInstanceSourceSpec(&DBaaSInstanceSource{})).Should(ConsistOf(
+				field.Required(field.NewPath("spec", "source"), "either instanceRef or backupRef must be specified")))
+			Expect(validateInstanceSourceSpec(&DBaaSInstanceSource{
+				InstanceRef: &NamespacedName{Name: "test-instance"},
+				BackupRef:   &NamespacedName{Name: "test-backup"},
+			})).Should(ConsistOf(
+				field.Invalid(field.NewPath("spec", "source", "backupRef"), "test-backup", "both instanceRef and backupRef are specified")))
+		})
+		It("should only allow a point in time for an instance", func() {
+			pointInTime := &metav1.Time{}
+			Expect(validateInstanceSourceSpec(&DBaaSInstanceSource{
+				InstanceRef: &NamespacedName{Name: "test-instance"},
+				PointInTime: pointInTime,
+			})).Should(BeEmpty())
+			Expect(validateInstanceSourceSpec(&DBaaSInstanceSource{
+				BackupRef:   &NamespacedName{Name: "test-backup"},
+				PointInTime: pointInTime,
+			})).Should(ConsistOf(
+				field.Invalid(field.NewPath("spec", "source", "pointInTime"), pointInTime, "a point in time requires instanceRef")))
+		})
+		It("should accept an instance without source", func() {
+			Expect(validateInstanceSourceSpec(nil)).Should(BeEmpty())
+		})
+	})
+
+	Context("provisioning parameters validation", func() {
+		path := field.NewPath("spec").Child("provisioningParameters")
+		It("should check the parameters against the provisioning guardrails", func() {
+			maxNodes := int32(3)
+			maxStorageGib := int32(100)
+			guardrails := &DBaaSProvisioningPolicy{
+				AllowedCloudProviders: []string{"AWS"},
+				AllowedRegions:        []string{"us-east-1", "eu-west-1"},
+				MaxNodes:              &maxNodes,
+				MaxStorageGib:         &maxStorageGib,
+			}
+			Expect(guardrails.ValidateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningCloudProvider: "aws",
+				ProvisioningRegions:       "us-east-1, eu-west-1",
+				ProvisioningNodes:         "3",
+				ProvisioningPlan:          "DEDICATED",
+			})).Should(BeEmpty())
+			Expect(guardrails.ValidateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningCloudProvider: "GCP",
+				ProvisioningRegions:       "us-east-1,us-central1",
+				ProvisioningNodes:         "5",
+				ProvisioningStorageGib:    "large",
+			})).Should(ConsistOf(
+				field.Forbidden(path.Key(string(ProvisioningCloudProvider)), `cloudProvider "GCP" is not allowed by the policy, allowed values: ["AWS"]`),
+				field.Forbidden(path.Key(string(ProvisioningRegions)), `regions "us-central1" is not allowed by the policy, allowed values: ["us-east-1" "eu-west-1"]`),
+				field.Forbidden(path.Key(string(ProvisioningNodes)), "nodes 5 exceeds the maximum of 3 allowed by the policy"),
+				field.Invalid(path.Key(string(ProvisioningStorageGib)), "large", "must be an integer, to be checked against the policy"),
+			))
+			var noGuardrails *DBaaSProvisioningPolicy
+			Expect(noGuardrails.ValidateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningNodes: "100",
+			})).Should(BeEmpty())
+		})
+		It("should accept parameters matching the provider", func() {
+			Expect(validateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningPlan:        "DEDICATED",
+				ProvisioningMachineType: "M10",
+			}, testProvisioningParameters)).Should(BeEmpty())
+		})
+		It("should accept any parameter for a provider without provisioning parameters", func() {
+			Expect(validateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningPlan: "ANY",
+			}, nil)).Should(BeEmpty())
+		})
+		It("should reject an unknown parameter", func() {
+			Expect(validateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningSpendLimit: "10",
+			}, testProvisioningParameters)).Should(Equal(field.ErrorList{
+				field.NotSupported(path.Key("spendLimit"), ProvisioningSpendLimit, []string{"cloudProvider", "machineType", "name", "plan", "regions"}),
+			}))
+		})
+		It("should reject a parameter not available for the other parameters", func() {
+			Expect(validateProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningMachineType: "M10",
+			}, testProvisioningParameters)).Should(Equal(field.ErrorList{
+				field.Invalid(path.Key("machineType"), "M10", `machineType is not available for cloudProvider="AWS", plan="SERVERLESS"`),
+			}))
+		})
+		It("should apply the default values once the parameters they depend on are resolved", func() {
+			params := map[ProvisioningParameterType]ProvisioningParameter{
+				ProvisioningPlan: testProvisioningParameters[ProvisioningPlan],
+				ProvisioningAvailabilityZones: {
+					ConditionalData: []ConditionalProvisioningParameterData{
+						{
+							Dependencies: []FieldDependency{{Field: ProvisioningPlan, Value: "SERVERLESS"}},
+							DefaultValue: "none",
+						},
+						{
+							DefaultValue: "any",
+						},
+					},
+				},
+			}
+			Expect(resolveProvisioningParameters(nil, params)).Should(Equal(map[ProvisioningParameterType]string{
+				ProvisioningPlan:              "SERVERLESS",
+				ProvisioningAvailabilityZones: "none",
+			}))
+		})
+		It("should reject the changes of immutable parameters", func() {
+			oldInstance := &DBaaSInstance{
+				Spec: DBaaSInstanceSpec{
+					ProvisioningParameters: map[ProvisioningParameterType]string{
+						ProvisioningName:          "test-instance",
+						ProvisioningCloudProvider: "AWS",
+						ProvisioningRegions:       "us-east-1",
+					},
+				},
+			}
+			instance := oldInstance.DeepCopy()
+			instance.Spec.ProvisioningParameters[ProvisioningRegions] = "eu-west-1"
+			instance.Spec.ProvisioningParameters[ProvisioningPlan] = "SERVERLESS"
+			instance.Annotations = map[string]string{DefaultedParametersAnnotation: `{"plan":"SERVERLESS"}`}
+			Expect(validateProvisioningParameterUpdates(instance, oldInstance, testProvisioningParameters, true)).Should(BeEmpty())
+
+			instance.Spec.ProvisioningParameters[ProvisioningName] = "renamed"
+			delete(instance.Spec.ProvisioningParameters, ProvisioningCloudProvider)
+			Expect(validateProvisioningParameterUpdates(instance, oldInstance, testProvisioningParameters, true)).Should(Equal(field.ErrorList{
+				field.Forbidden(path.Key("cloudProvider"), `cloudProvider is immutable, it cannot be changed from "AWS" to ""`),
+				field.Forbidden(path.Key("name"), `name is immutable, it cannot be changed from "test-instance" to "renamed"`),
+			}))
+		})
+		It("should reject any change if the provider does not support updates", func() {
+			oldInstance := &DBaaSInstance{
+				Spec: DBaaSInstanceSpec{
+					ProvisioningParameters: map[ProvisioningParameterType]string{
+						ProvisioningName:    "test-instance",
+						ProvisioningRegions: "us-east-1",
+					},
+				},
+			}
+			instance := oldInstance.DeepCopy()
+			instance.Spec.ProvisioningParameters[ProvisioningPlan] = "SERVERLESS"
+			instance.Annotations = map[string]string{DefaultedParametersAnnotation: `{"plan":"SERVERLESS"}`}
+			Expect(validateProvisioningParameterUpdates(instance, oldInstance, testProvisioningParameters, false)).Should(BeEmpty())
+
+			instance.Spec.ProvisioningParameters[ProvisioningRegions] = "eu-west-1"
+			Expect(validateProvisioningParameterUpdates(instance, oldInstance, testProvisioningParameters, false)).Should(Equal(field.ErrorList{
+				field.Forbidden(path.Key("regions"), `regions cannot be changed from "us-east-1" to "eu-west-1", the provider does not support updating instances`),
+			}))
+		})
+		It("should record the defaulted parameters", func() {
+			instance := &DBaaSInstance{
+				Spec: DBaaSInstanceSpec{
+					ProvisioningParameters: map[ProvisioningParameterType]string{
+						ProvisioningCloudProvider: "GCP",
+					},
+				},
+			}
+			defaultProvisioningParameters(instance, testProvisioningParameters)
+			Expect(instance.Spec.ProvisioningParameters).Should(Equal(map[ProvisioningParameterType]string{
+				ProvisioningPlan:          "SERVERLESS",
+				ProvisioningCloudProvider: "GCP",
+				ProvisioningRegions:       "us-central1",
+			}))
+			Expect(instance.Annotations).Should(HaveKeyWithValue(DefaultedParametersAnnotation, `{"plan":"SERVERLESS","regions":"us-central1"}`))
+		})
+		It("should apply the default values depending on other parameters", func() {
+			Expect(resolveProvisioningParameters(map[ProvisioningParameterType]string{
+				ProvisioningPlan:          "DEDICATED",
+				ProvisioningCloudProvider: "AWS",
+			}, testProvisioningParameters)).Should(Equal(map[ProvisioningParameterType]string{
+				ProvisioningPlan:          "DEDICATED",
+				ProvisioningCloudProvider: "AWS",
+				ProvisioningRegions:       "us-east-1",
+				ProvisioningMachineType:   "M10",
+			}))
+		})
+	})
+})
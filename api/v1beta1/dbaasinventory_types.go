@@ -32,6 +32,21 @@ type DBaaSOperatorInventorySpec struct {
 	Policy *DBaaSInventoryPolicy `json:"policy,omitempty"`
 }
 
+// Defines the observed state of a DBaaSInventory object.
+type DBaaSOperatorInventoryStatus struct {
+	// The status reported by the provider's operator.
+	DBaaSInventoryStatus `json:",inline"`
+
+	// A fingerprint of the credential fields of the credentials secret last synced to the provider.
+	CredentialsHash string `json:"credentialsHash,omitempty"`
+
+	// The last time a new version of the credentials secret was detected and synced to the provider.
+	CredentialsLastRotated *metav1.Time `json:"credentialsLastRotated,omitempty"`
+
+	// The DBaaSPolicy governing the inventory, which is the active policy of its namespace.
+	EffectivePolicyRef *LocalObjectReference `json:"effectivePolicyRef,omitempty"`
+}
+
 //+kubebuilder:storageversion
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
@@ -43,8 +58,8 @@ type DBaaSInventory struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   DBaaSOperatorInventorySpec `json:"spec,omitempty"`
-	Status DBaaSInventoryStatus       `json:"status,omitempty"`
+	Spec   DBaaSOperatorInventorySpec   `json:"spec,omitempty"`
+	Status DBaaSOperatorInventoryStatus `json:"status,omitempty"`
 }
 
 //+kubebuilder:object:root=true
@@ -51,12 +51,18 @@ func (r *DBaaSInventory) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	}); err != nil {
 		return err
 	}
+	// index connection by `spec.inventoryRef`
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &DBaaSConnection{}, inventoryRefKey, indexConnectionRef(func(connection *DBaaSConnection) *NamespacedName {
+		return &connection.Spec.InventoryRef
+	})); err != nil {
+		return err
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
 }
 
-//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasinventory,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinventories,verbs=create;update,versions=v1beta1,name=vdbaasinventory.kb.io,admissionReviewVersions=v1beta1
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasinventory,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinventories,verbs=create;update;delete,versions=v1beta1,name=vdbaasinventory.kb.io,admissionReviewVersions=v1beta1
 
 var _ webhook.Validator = &DBaaSInventory{}
 
@@ -75,7 +81,7 @@ func (r *DBaaSInventory) ValidateUpdate(old runtime.Object) error {
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSInventory) ValidateDelete() error {
 	dbaasinventorylog.Info("validate delete", "name", r.Name)
-	return nil
+	return validateNoDependentConnections(r, inventoryRefKey)
 }
 
 func validateInventory(inv *DBaaSInventory, oldInv *DBaaSInventory) error {
@@ -107,6 +113,9 @@ func validateInventory(inv *DBaaSInventory, oldInv *DBaaSInventory) error {
 				return err
 			}
 		}
+		if err := validateAccessModeRules(inv.Spec.Policy.Connections.AccessModes); err != nil {
+			return err
+		}
 	}
 	return validateInventoryMandatoryFields(inv, secret, provider)
 }
@@ -351,6 +351,43 @@ var _ = Describe("DBaaSInventory Webhook", func() {
 				Expect(err).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: spec.providerRef.name: Invalid value: \"crunchy-registration\": provider name is immutable for provider accounts"))
 			})
 		})
+	Context("deletion", func() {
+		BeforeEach(assertResourceCreation(&testSecret))
+		BeforeEach(assertResourceCreation(&testProvider))
+		AfterEach(assertResourceDeletion(&testProvider))
+		AfterEach(assertResourceDeletion(&testSecret))
+		Context("with a DBaaSConnection referencing the inventory", func() {
+			conn := testDBaaSConnection.DeepCopy()
+			conn.Name = "test-connection-dependent"
+			BeforeEach(assertResourceCreation(&testDBaaSInventory))
+			BeforeEach(assertResourceCreation(conn))
+			AfterEach(assertResourceDeletion(conn))
+			AfterEach(assertResourceDeletion(&testDBaaSInventory))
+			It("should not allow deleting the inventory", func() {
+				Eventually(func() error {
+					return k8sClient.Delete(ctx, &testDBaaSInventory)
+				}, timeout, interval).Should(MatchError("admission webhook \"vdbaasinventory.kb.io\" denied the request: " +
+					inventoryName + " is still used by DBaaSConnections " + testNamespace + "/test-connection-dependent, delete them first or set the dbaas.redhat.com/force-delete annotation to true"))
+			})
+		})
+		It("should allow deleting the inventory with the force delete annotation", func() {
+			inv := testDBaaSInventory.DeepCopy()
+			inv.Name = "test-inventory-force"
+			conn := testDBaaSConnection.DeepCopy()
+			conn.Name = "test-connection-force"
+			conn.Spec.InventoryRef.Name = inv.Name
+			assertResourceCreation(inv)()
+			assertResourceCreation(conn)()
+			Eventually(func() error {
+				return k8sClient.Delete(ctx, inv)
+			}, timeout, interval).ShouldNot(Succeed())
+
+			inv.SetAnnotations(map[string]string{ForceDeleteAnnotation: "true"})
+			Expect(k8sClient.Update(ctx, inv)).Should(Succeed())
+			assertResourceDeletion(inv)()
+			assertResourceDeletion(conn)()
+		})
+	})
 	Context("After creating DBaaSInventory for RDS", func() {
 		testSecretRDS2 := corev1.Secret{
 			TypeMeta: metav1.TypeMeta{
@@ -0,0 +1,63 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"time"
+)
+
+// Allows returns whether the grant allows a connection to a database service of its inventory, from the namespace of the connection,
+// or from the service account that created it. An expired grant, or a grant being deleted, allows no connection.
+func (g *DBaaSInventoryGrant) Allows(connection *DBaaSConnection, serviceID string, now time.Time) bool {
+	if g.DeletionTimestamp != nil || g.IsExpired(now) || connection.Spec.InventoryRef.Name != g.Spec.InventoryRef.Name ||
+		connection.Spec.InventoryRef.Namespace != g.Namespace || !containsString(g.Spec.ServiceIDs, serviceID) {
+		return false
+	}
+	if containsString(g.Spec.Namespaces, connection.Namespace) {
+		return true
+	}
+	requester, ok := connection.Annotations[ConnectionRequesterAnnotation]
+	if !ok {
+		return false
+	}
+	for _, serviceAccount := range g.Spec.ServiceAccounts {
+		if requester == ServiceAccountUsername(serviceAccount) {
+			return true
+		}
+	}
+	return false
+}
+
+// IsExpired returns whether the grant expired
+func (g *DBaaSInventoryGrant) IsExpired(now time.Time) bool {
+	return g.Spec.ExpiresAt != nil && !now.Before(g.Spec.ExpiresAt.Time)
+}
+
+// ServiceAccountUsername returns the name of the user authenticated with the token of a service account
+func ServiceAccountUsername(serviceAccount NamespacedName) string {
+	return fmt.Sprintf("system:serviceaccount:%s:%s", serviceAccount.Namespace, serviceAccount.Name)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Defines the desired state of a DBaaSInventoryGrant object.
+type DBaaSInventoryGrantSpec struct {
+	// The inventory the grant gives access to, in the namespace of the grant.
+	InventoryRef LocalObjectReference `json:"inventoryRef"`
+
+	// The database services of the inventory the grantees can connect to, by service ID.
+	// +kubebuilder:validation:MinItems=1
+	ServiceIDs []string `json:"serviceIDs"`
+
+	// The namespaces allowed to create connections to the database services.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// The service accounts allowed to create connections to the database services, from any namespace.
+	ServiceAccounts []NamespacedName `json:"serviceAccounts,omitempty"`
+
+	// The time the grant expires, the connections it allowed are then in an invalid namespace.
+	// The grant does not expire if not set.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+}
+
+// Defines the observed state of a DBaaSInventoryGrant object.
+type DBaaSInventoryGrantStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Inventory",type=string,JSONPath=`.spec.inventoryRef.name`
+//+kubebuilder:printcolumn:name="Active",type=string,JSONPath=`.status.conditions[0].status`
+//+kubebuilder:printcolumn:name="Expires",type=date,JSONPath=`.spec.expiresAt`
+
+// Grants namespaces or service accounts access to specific database services of an inventory.
+// An inventory owner creates a grant in the namespace of the inventory, so that tenants can connect to some databases,
+// in addition to the connection namespaces allowed by the policy of the inventory.
+// +operator-sdk:csv:customresourcedefinitions:displayName="Provider Account Grant"
+type DBaaSInventoryGrant struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInventoryGrantSpec   `json:"spec,omitempty"`
+	Status DBaaSInventoryGrantStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of DBaaSInventoryGrant objects.
+type DBaaSInventoryGrantList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSInventoryGrant `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSInventoryGrant{}, &DBaaSInventoryGrantList{})
+}
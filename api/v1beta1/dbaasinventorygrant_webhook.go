@@ -0,0 +1,86 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+)
+
+// log is for logging in this package.
+var dbaasinventorygrantlog = logf.Log.WithName("dbaasinventorygrant-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSInventoryGrant) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasinventorygrant,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasinventorygrants,verbs=create;update,versions=v1beta1,name=vdbaasinventorygrant.kb.io,admissionReviewVersions=v1beta1
+
+var _ webhook.Validator = &DBaaSInventoryGrant{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInventoryGrant) ValidateCreate() error {
+	dbaasinventorygrantlog.Info("validate create", "name", r.Name)
+	return validateInventoryGrant(r)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInventoryGrant) ValidateUpdate(_ runtime.Object) error {
+	dbaasinventorygrantlog.Info("validate update", "name", r.Name)
+	return validateInventoryGrant(r)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type
+func (r *DBaaSInventoryGrant) ValidateDelete() error {
+	dbaasinventorygrantlog.Info("validate delete", "name", r.Name)
+	return nil
+}
+
+func validateInventoryGrant(grant *DBaaSInventoryGrant) error {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if len(grant.Spec.Namespaces) == 0 && len(grant.Spec.ServiceAccounts) == 0 {
+		errs = append(errs, field.Required(specPath.Child("namespaces"), "either namespaces or serviceAccounts must be specified"))
+	}
+	if len(grant.Spec.ServiceIDs) == 0 {
+		errs = append(errs, field.Required(specPath.Child("serviceIDs"), "at least one service ID must be specified"))
+	}
+	for i, serviceID := range grant.Spec.ServiceIDs {
+		if len(serviceID) == 0 {
+			errs = append(errs, field.Required(specPath.Child("serviceIDs").Index(i), "the service ID must not be empty"))
+		}
+	}
+	for i, serviceAccount := range grant.Spec.ServiceAccounts {
+		if len(serviceAccount.Name) == 0 {
+			errs = append(errs, field.Required(specPath.Child("serviceAccounts").Index(i).Child("name"), "the service account name must be specified"))
+		}
+		if len(serviceAccount.Namespace) == 0 {
+			errs = append(errs, field.Required(specPath.Child("serviceAccounts").Index(i).Child("namespace"), "the service account namespace must be specified"))
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSInventoryGrant").GroupKind(), grant.Name, errs)
+	}
+	return nil
+}
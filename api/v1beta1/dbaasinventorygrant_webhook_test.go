@@ -0,0 +1,123 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("DBaaSInventoryGrant Webhook", func() {
+	Context("creation", func() {
+		It("should fail without grantees", func() {
+			grant := &DBaaSInventoryGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant-no-grantee", Namespace: testNamespace},
+				Spec: DBaaSInventoryGrantSpec{
+					InventoryRef: LocalObjectReference{Name: "test-inventory"},
+					ServiceIDs:   []string{"test-instance-id"},
+				},
+			}
+			err := k8sClient.Create(ctx, grant)
+			Expect(err).Should(MatchError(ContainSubstring("either namespaces or serviceAccounts must be specified")))
+		})
+		It("should fail without service IDs", func() {
+			grant := &DBaaSInventoryGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant-no-service", Namespace: testNamespace},
+				Spec: DBaaSInventoryGrantSpec{
+					InventoryRef: LocalObjectReference{Name: "test-inventory"},
+					Namespaces:   []string{testNamespace2},
+				},
+			}
+			// the CRD schema requires the field, the webhook also rejects an empty list
+			Expect(validateInventoryGrant(grant)).Should(MatchError(ContainSubstring("at least one service ID must be specified")))
+			grant.Spec.ServiceIDs = []string{}
+			Expect(k8sClient.Create(ctx, grant)).ShouldNot(Succeed())
+		})
+		It("should fail with an incomplete service account", func() {
+			grant := &DBaaSInventoryGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant-no-sa-ns", Namespace: testNamespace},
+				Spec: DBaaSInventoryGrantSpec{
+					InventoryRef:    LocalObjectReference{Name: "test-inventory"},
+					ServiceIDs:      []string{"test-instance-id"},
+					ServiceAccounts: []NamespacedName{{Name: "app"}},
+				},
+			}
+			err := k8sClient.Create(ctx, grant)
+			Expect(err).Should(MatchError(ContainSubstring("the service account namespace must be specified")))
+		})
+		It("should succeed with grantees", func() {
+			grant := &DBaaSInventoryGrant{
+				ObjectMeta: metav1.ObjectMeta{Name: "grant-valid", Namespace: testNamespace},
+				Spec: DBaaSInventoryGrantSpec{
+					InventoryRef:    LocalObjectReference{Name: "test-inventory"},
+					ServiceIDs:      []string{"test-instance-id"},
+					Namespaces:      []string{testNamespace2},
+					ServiceAccounts: []NamespacedName{{Namespace: "tenant", Name: "app"}},
+				},
+			}
+			assertResourceCreation(grant)()
+			assertResourceDeletion(grant)()
+		})
+	})
+
+	Context("allowing connections", func() {
+		now := time.Now()
+		grant := &DBaaSInventoryGrant{
+			ObjectMeta: metav1.ObjectMeta{Name: "grant", Namespace: "owner"},
+			Spec: DBaaSInventoryGrantSpec{
+				InventoryRef:    LocalObjectReference{Name: "inventory"},
+				ServiceIDs:      []string{"db-1"},
+				Namespaces:      []string{"tenant-1"},
+				ServiceAccounts: []NamespacedName{{Namespace: "tenant-2", Name: "app"}},
+			},
+		}
+		connection := func(namespace, requester string) *DBaaSConnection {
+			return &DBaaSConnection{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "connection",
+					Namespace:   namespace,
+					Annotations: map[string]string{ConnectionRequesterAnnotation: requester},
+				},
+				Spec: DBaaSConnectionSpec{InventoryRef: NamespacedName{Namespace: "owner", Name: "inventory"}},
+			}
+		}
+
+		It("should allow the granted namespaces and service accounts", func() {
+			Expect(grant.Allows(connection("tenant-1", "alice"), "db-1", now)).Should(BeTrue())
+			Expect(grant.Allows(connection("tenant-3", "system:serviceaccount:tenant-2:app"), "db-1", now)).Should(BeTrue())
+		})
+		It("should not allow other namespaces, service accounts or services", func() {
+			Expect(grant.Allows(connection("tenant-3", "alice"), "db-1", now)).Should(BeFalse())
+			Expect(grant.Allows(connection("tenant-3", "system:serviceaccount:tenant-3:app"), "db-1", now)).Should(BeFalse())
+			Expect(grant.Allows(connection("tenant-1", "alice"), "db-2", now)).Should(BeFalse())
+			other := connection("tenant-1", "alice")
+			other.Spec.InventoryRef.Name = "other"
+			Expect(grant.Allows(other, "db-1", now)).Should(BeFalse())
+		})
+		It("should not allow connections once expired", func() {
+			expiring := grant.DeepCopy()
+			expiring.Spec.ExpiresAt = &metav1.Time{Time: now.Add(time.Hour)}
+			Expect(expiring.IsExpired(now)).Should(BeFalse())
+			Expect(expiring.Allows(connection("tenant-1", "alice"), "db-1", now)).Should(BeTrue())
+			Expect(expiring.IsExpired(now.Add(time.Hour))).Should(BeTrue())
+			Expect(expiring.Allows(connection("tenant-1", "alice"), "db-1", now.Add(time.Hour))).Should(BeFalse())
+		})
+	})
+})
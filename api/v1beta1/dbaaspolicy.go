@@ -0,0 +1,394 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// The types of the quotas of a policy
+const (
+	QuotaInstancesPerNamespace  DBaaSQuotaType = "InstancesPerNamespace"
+	QuotaInstancesPerInventory  DBaaSQuotaType = "InstancesPerInventory"
+	QuotaInstancesPerProvider   DBaaSQuotaType = "InstancesPerProvider"
+	QuotaConnectionsPerInstance DBaaSQuotaType = "ConnectionsPerInstance"
+)
+
+// ElectActivePolicy returns the policy to activate in a namespace, nil if there is none.
+// The oldest policy not being deleted is elected, by creation timestamp then name, so that the election does not depend on the list order.
+func ElectActivePolicy(policies []DBaaSPolicy) *DBaaSPolicy {
+	var elected *DBaaSPolicy
+	for i := range policies {
+		policy := &policies[i]
+		if policy.DeletionTimestamp != nil {
+			continue
+		}
+		if elected == nil || policy.CreationTimestamp.Before(&elected.CreationTimestamp) ||
+			(policy.CreationTimestamp.Equal(&elected.CreationTimestamp) && policy.Name < elected.Name) {
+			elected = policy
+		}
+	}
+	return elected
+}
+
+// EffectiveProvisioningPolicy returns the provisioning guardrails applying to an inventory, nil if there are none.
+// The guardrails set by the inventory can only narrow the ones of the active DBaaSPolicy, themselves narrowed by the cluster policy.
+func EffectiveProvisioningPolicy(inventory *DBaaSInventory, activePolicy *DBaaSPolicy, clusterPolicy *DBaaSClusterPolicy) *DBaaSProvisioningPolicy {
+	provisioning := namespacePolicy(activePolicy, clusterPolicy).Provisioning
+	if inventory.Spec.Policy != nil && inventory.Spec.Policy.Provisioning != nil {
+		provisioning = narrowProvisioning(provisioning, inventory.Spec.Policy.Provisioning)
+	}
+	return provisioning
+}
+
+// EffectiveAccessModeRules returns the access mode rules applying to the connections of an inventory.
+// The rules of the cluster policy, of the active DBaaSPolicy and of the connection policy set by the inventory all apply.
+func EffectiveAccessModeRules(inventory *DBaaSInventory, activePolicy *DBaaSPolicy, clusterPolicy *DBaaSClusterPolicy) []DBaaSAccessModeRule {
+	rules := namespacePolicy(activePolicy, clusterPolicy).Connections.AccessModes
+	if inventory.Spec.Policy != nil {
+		rules = append(rules, inventory.Spec.Policy.Connections.AccessModes...)
+	}
+	return rules
+}
+
+// namespacePolicy returns the policy in effect in the namespace of an inventory, the active DBaaSPolicy narrowed by the cluster policy
+func namespacePolicy(activePolicy *DBaaSPolicy, clusterPolicy *DBaaSClusterPolicy) *DBaaSPolicySpec {
+	spec := &DBaaSPolicySpec{}
+	if activePolicy != nil {
+		spec = &activePolicy.Spec
+	}
+	return NarrowPolicy(clusterPolicy, spec)
+}
+
+// AllowsAccessMode checks an access mode against the rules matching a connection namespace.
+// An access mode is allowed when all the matching rules allow it, or when no rule matches the namespace.
+func AllowsAccessMode(rules []DBaaSAccessModeRule, namespace *corev1.Namespace, accessMode ConnectionAccessMode) (bool, error) {
+	for _, rule := range rules {
+		matches := containsValue(rule.Namespaces, "*") || containsValue(rule.Namespaces, namespace.Name)
+		if !matches && rule.NsSelector != nil {
+			selector, err := metav1.LabelSelectorAsSelector(rule.NsSelector)
+			if err != nil {
+				return false, err
+			}
+			matches = selector.Matches(labels.Set(namespace.Labels))
+		}
+		if matches && !containsAccessMode(rule.AllowedAccessModes, accessMode) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// validateAccessModeRules checks the namespace selectors of the access mode rules
+func validateAccessModeRules(rules []DBaaSAccessModeRule) error {
+	for _, rule := range rules {
+		if rule.NsSelector != nil {
+			if _, err := metav1.LabelSelectorAsSelector(rule.NsSelector); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func containsAccessMode(accessModes []ConnectionAccessMode, accessMode ConnectionAccessMode) bool {
+	for _, mode := range accessModes {
+		if mode == accessMode {
+			return true
+		}
+	}
+	return false
+}
+
+// NarrowPolicy returns the policy in effect for a DBaaSPolicy, which can only narrow the cluster policy.
+// Provisioning stays disabled when the cluster policy disables it, the allowed values are intersected, and the lowest limits apply.
+// The connection namespaces are the ones allowed by both policies, and the access mode rules of both policies apply.
+// A nil cluster policy leaves the policy unchanged.
+func NarrowPolicy(clusterPolicy *DBaaSClusterPolicy, spec *DBaaSPolicySpec) *DBaaSPolicySpec {
+	effective := spec.DeepCopy()
+	if clusterPolicy == nil {
+		return effective
+	}
+	cluster := clusterPolicy.Spec.DeepCopy()
+	if cluster.DisableProvisions != nil && *cluster.DisableProvisions {
+		effective.DisableProvisions = cluster.DisableProvisions
+	}
+	effective.Connections = narrowConnections(cluster.Connections, effective.Connections)
+	if len(cluster.Connections.AccessModes) > 0 {
+		effective.Connections.AccessModes = append(cluster.Connections.AccessModes, effective.Connections.AccessModes...)
+	}
+	effective.Provisioning = narrowProvisioning(cluster.Provisioning, effective.Provisioning)
+	effective.Quotas = narrowQuotas(cluster.Quotas, effective.Quotas)
+	return effective
+}
+
+// narrowConnections intersects the connection namespaces of the cluster policy with the ones of a policy.
+// Without namespaces, a policy only allows connections from the namespace of the inventory, which the cluster policy cannot narrow.
+func narrowConnections(cluster, connections DBaaSConnectionPolicy) DBaaSConnectionPolicy {
+	if connections.Namespaces == nil && connections.NsSelector == nil {
+		return connections
+	}
+	if cluster.Namespaces != nil && !containsValue(*cluster.Namespaces, "*") {
+		if connections.Namespaces == nil || containsValue(*connections.Namespaces, "*") {
+			connections.Namespaces = cluster.Namespaces
+		} else {
+			namespaces := narrowValues(*cluster.Namespaces, *connections.Namespaces)
+			connections.Namespaces = &namespaces
+		}
+	}
+	if cluster.NsSelector != nil {
+		if connections.NsSelector == nil {
+			connections.NsSelector = cluster.NsSelector
+		} else {
+			// both selectors must match
+			selector := connections.NsSelector.DeepCopy()
+			selector.MatchExpressions = append(selector.MatchExpressions, cluster.NsSelector.MatchExpressions...)
+			for key, value := range cluster.NsSelector.MatchLabels {
+				selector.MatchExpressions = append(selector.MatchExpressions, metav1.LabelSelectorRequirement{
+					Key:      key,
+					Operator: metav1.LabelSelectorOpIn,
+					Values:   []string{value},
+				})
+			}
+			connections.NsSelector = selector
+		}
+	}
+	return connections
+}
+
+func narrowProvisioning(cluster, provisioning *DBaaSProvisioningPolicy) *DBaaSProvisioningPolicy {
+	if cluster == nil {
+		return provisioning
+	}
+	if provisioning == nil {
+		return cluster
+	}
+	return &DBaaSProvisioningPolicy{
+		AllowedPlans:          narrowValues(cluster.AllowedPlans, provisioning.AllowedPlans),
+		AllowedCloudProviders: narrowValues(cluster.AllowedCloudProviders, provisioning.AllowedCloudProviders),
+		AllowedRegions:        narrowValues(cluster.AllowedRegions, provisioning.AllowedRegions),
+		MaxNodes:              narrowLimit(cluster.MaxNodes, provisioning.MaxNodes),
+		MaxStorageGib:         narrowLimit(cluster.MaxStorageGib, provisioning.MaxStorageGib),
+		MaxSpendLimit:         narrowLimit(cluster.MaxSpendLimit, provisioning.MaxSpendLimit),
+	}
+}
+
+func narrowQuotas(cluster, quotas *DBaaSQuotaPolicy) *DBaaSQuotaPolicy {
+	if cluster == nil {
+		return quotas
+	}
+	if quotas == nil {
+		return cluster
+	}
+	return &DBaaSQuotaPolicy{
+		MaxInstancesPerNamespace:  narrowLimit(cluster.MaxInstancesPerNamespace, quotas.MaxInstancesPerNamespace),
+		MaxInstancesPerInventory:  narrowLimit(cluster.MaxInstancesPerInventory, quotas.MaxInstancesPerInventory),
+		MaxInstancesPerProvider:   narrowLimit(cluster.MaxInstancesPerProvider, quotas.MaxInstancesPerProvider),
+		MaxConnectionsPerInstance: narrowLimit(cluster.MaxConnectionsPerInstance, quotas.MaxConnectionsPerInstance),
+	}
+}
+
+// narrowValues intersects two lists of allowed values, a nil list allowing all values.
+// The intersection of lists without common values is an empty list, allowing no value.
+func narrowValues(cluster, values []string) []string {
+	if cluster == nil {
+		return values
+	}
+	if values == nil {
+		return cluster
+	}
+	narrowed := []string{}
+	for _, value := range values {
+		if containsValue(cluster, value) {
+			narrowed = append(narrowed, value)
+		}
+	}
+	return narrowed
+}
+
+// narrowLimit returns the lowest limit, a nil limit being unlimited
+func narrowLimit[T int32 | int64](cluster, limit *T) *T {
+	if cluster == nil || (limit != nil && *limit <= *cluster) {
+		return limit
+	}
+	return cluster
+}
+
+// ValidateProvisioningParameters checks the provisioning parameters of an instance against the guardrails.
+// A nil policy allows all parameters.
+func (p *DBaaSProvisioningPolicy) ValidateProvisioningParameters(values map[ProvisioningParameterType]string) field.ErrorList {
+	var errs field.ErrorList
+	if p == nil {
+		return errs
+	}
+	path := field.NewPath("spec").Child("provisioningParameters")
+
+	if plan, ok := values[ProvisioningPlan]; ok && p.AllowedPlans != nil && !containsValue(p.AllowedPlans, plan) {
+		errs = append(errs, notAllowedByPolicy(path.Key(string(ProvisioningPlan)), ProvisioningPlan, plan, p.AllowedPlans))
+	}
+	if cloudProvider, ok := values[ProvisioningCloudProvider]; ok && p.AllowedCloudProviders != nil &&
+		!containsValue(p.AllowedCloudProviders, cloudProvider) {
+		errs = append(errs, notAllowedByPolicy(path.Key(string(ProvisioningCloudProvider)), ProvisioningCloudProvider, cloudProvider, p.AllowedCloudProviders))
+	}
+	if regions, ok := values[ProvisioningRegions]; ok && p.AllowedRegions != nil {
+		// an instance may span several regions
+		for _, region := range strings.Split(regions, ",") {
+			if region = strings.TrimSpace(region); !containsValue(p.AllowedRegions, region) {
+				errs = append(errs, notAllowedByPolicy(path.Key(string(ProvisioningRegions)), ProvisioningRegions, region, p.AllowedRegions))
+			}
+		}
+	}
+
+	limits := []struct {
+		key ProvisioningParameterType
+		max *int64
+	}{
+		{ProvisioningNodes, int32Limit(p.MaxNodes)},
+		{ProvisioningStorageGib, int32Limit(p.MaxStorageGib)},
+		{ProvisioningSpendLimit, p.MaxSpendLimit},
+	}
+	for _, limit := range limits {
+		value, ok := values[limit.key]
+		if !ok || limit.max == nil {
+			continue
+		}
+		n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil {
+			errs = append(errs, field.Invalid(path.Key(string(limit.key)), value, "must be an integer, to be checked against the policy"))
+		} else if n > *limit.max {
+			errs = append(errs, field.Forbidden(path.Key(string(limit.key)),
+				fmt.Sprintf("%s %d exceeds the maximum of %d allowed by the policy", limit.key, n, *limit.max)))
+		}
+	}
+	return errs
+}
+
+func notAllowedByPolicy(path *field.Path, key ProvisioningParameterType, value string, allowed []string) *field.Error {
+	return field.Forbidden(path, fmt.Sprintf("%s %q is not allowed by the policy, allowed values: %q", key, value, allowed))
+}
+
+func int32Limit(limit *int32) *int64 {
+	if limit == nil {
+		return nil
+	}
+	l := int64(*limit)
+	return &l
+}
+
+// containsValue compares the values ignoring the case, as providers differ in the case of cloud providers and regions
+func containsValue(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// QuotaUsage counts the instances referencing the inventories of a policy, and the connections to these instances.
+// The inventories are the ones in the namespace of the policy, the instances and connections can be in any namespace.
+// The objects being deleted are not counted. A nil quota policy has no usage.
+func (q *DBaaSQuotaPolicy) QuotaUsage(inventories []DBaaSInventory, instances []DBaaSInstance, connections []DBaaSConnection) []DBaaSQuotaUsage {
+	if q == nil {
+		return nil
+	}
+	providers := map[types.NamespacedName]string{}
+	for i := range inventories {
+		providers[types.NamespacedName{Namespace: inventories[i].Namespace, Name: inventories[i].Name}] = inventories[i].Spec.ProviderRef.Name
+	}
+	counts := map[DBaaSQuotaType]map[string]int32{}
+	count := func(quotaType DBaaSQuotaType, name string) {
+		if counts[quotaType] == nil {
+			counts[quotaType] = map[string]int32{}
+		}
+		counts[quotaType][name]++
+	}
+
+	var counted []*DBaaSInstance
+	for i := range instances {
+		inst := &instances[i]
+		provider, ok := providers[types.NamespacedName{Namespace: inst.Spec.InventoryRef.Namespace, Name: inst.Spec.InventoryRef.Name}]
+		if !ok || inst.DeletionTimestamp != nil {
+			continue
+		}
+		counted = append(counted, inst)
+		count(QuotaInstancesPerNamespace, inst.Namespace)
+		count(QuotaInstancesPerInventory, inst.Spec.InventoryRef.Name)
+		count(QuotaInstancesPerProvider, provider)
+	}
+	for i := range connections {
+		if connections[i].DeletionTimestamp != nil {
+			continue
+		}
+		if inst := connectionInstance(&connections[i], counted); inst != nil {
+			count(QuotaConnectionsPerInstance, types.NamespacedName{Namespace: inst.Namespace, Name: inst.Name}.String())
+		}
+	}
+
+	var usage []DBaaSQuotaUsage
+	for _, quota := range []struct {
+		quotaType DBaaSQuotaType
+		limit     *int32
+	}{
+		{QuotaInstancesPerNamespace, q.MaxInstancesPerNamespace},
+		{QuotaInstancesPerInventory, q.MaxInstancesPerInventory},
+		{QuotaInstancesPerProvider, q.MaxInstancesPerProvider},
+		{QuotaConnectionsPerInstance, q.MaxConnectionsPerInstance},
+	} {
+		if quota.limit == nil {
+			continue
+		}
+		var names []string
+		for name := range counts[quota.quotaType] {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			usage = append(usage, DBaaSQuotaUsage{Type: quota.quotaType, Name: name, Used: counts[quota.quotaType][name], Limit: *quota.limit})
+		}
+	}
+	return usage
+}
+
+// connectionInstance returns the instance a connection connects to, either with a reference to the instance,
+// or with the ID of the provisioned instance in its inventory. It returns nil if the instance is not in the list.
+func connectionInstance(connection *DBaaSConnection, instances []*DBaaSInstance) *DBaaSInstance {
+	for _, inst := range instances {
+		if ref := connection.Spec.DatabaseServiceRef; ref != nil {
+			ns := ref.Namespace
+			if len(ns) == 0 {
+				ns = connection.Namespace
+			}
+			if ns == inst.Namespace && ref.Name == inst.Name {
+				return inst
+			}
+		} else if len(connection.Spec.DatabaseServiceID) > 0 && connection.Spec.DatabaseServiceID == inst.Status.InstanceID &&
+			connection.Spec.InventoryRef == inst.Spec.InventoryRef {
+			return inst
+		}
+	}
+	return nil
+}
@@ -23,6 +23,9 @@ import (
 // The specifications for a DBaaSPolicy object.
 type DBaaSPolicySpec struct {
 	DBaaSInventoryPolicy `json:",inline"`
+
+	// Limits the number of DBaaSInstance and DBaaSConnection objects using a policy's inventories.
+	Quotas *DBaaSQuotaPolicy `json:"quotas,omitempty"`
 }
 
 // Sets the inventory policy.
@@ -31,6 +34,35 @@ type DBaaSInventoryPolicy struct {
 	DisableProvisions *bool `json:"disableProvisions,omitempty"`
 	// Namespaces where DBaaSConnection and DBaaSInstance objects are only allowed to reference a policy's inventories.
 	Connections DBaaSConnectionPolicy `json:"connections,omitempty"`
+	// Restricts the provisioning parameters of DBaaSInstance objects referencing a policy's inventories.
+	Provisioning *DBaaSProvisioningPolicy `json:"provisioning,omitempty"`
+}
+
+// The DBaaSProvisioningPolicy object sets guardrails on the database instances provisioned against an inventory.
+// Only the provisioning parameters set on an instance are checked, a restriction is not applied when its list or its limit is not set.
+// An empty list allows no value.
+type DBaaSProvisioningPolicy struct {
+	// Plans allowed for the provisioned instances.
+	// +kubebuilder:validation:items:Enum=FREETRIAL;SERVERLESS;DEDICATED
+	AllowedPlans []string `json:"allowedPlans,omitempty"`
+
+	// Cloud providers allowed for the provisioned instances, such as AWS or GCP.
+	AllowedCloudProviders []string `json:"allowedCloudProviders,omitempty"`
+
+	// Regions allowed for the provisioned instances.
+	AllowedRegions []string `json:"allowedRegions,omitempty"`
+
+	// Maximum number of nodes of a provisioned instance.
+	// +kubebuilder:validation:Minimum=1
+	MaxNodes *int32 `json:"maxNodes,omitempty"`
+
+	// Maximum storage of a provisioned instance, in GiB.
+	// +kubebuilder:validation:Minimum=1
+	MaxStorageGib *int32 `json:"maxStorageGib,omitempty"`
+
+	// Maximum spend limit of a provisioned instance, in the currency unit of the provider's spendLimit parameter.
+	// +kubebuilder:validation:Minimum=0
+	MaxSpendLimit *int64 `json:"maxSpendLimit,omitempty"`
 }
 
 // The DBaaSConnectionPolicy object sets a connection policy.
@@ -46,11 +78,75 @@ type DBaaSConnectionPolicy struct {
 	// An empty label selector matches all objects.
 	// A null label selector matches no objects.
 	NsSelector *metav1.LabelSelector `json:"nsSelector,omitempty"`
+
+	// Restricts the access modes of the DBaaSConnection objects referencing a policy's inventories, by connection namespace.
+	// A connection must be allowed by all the rules matching its namespace. All access modes are allowed when no rule matches.
+	AccessModes []DBaaSAccessModeRule `json:"accessModes,omitempty"`
+}
+
+// The DBaaSAccessModeRule object restricts the access modes of the connections in some namespaces.
+type DBaaSAccessModeRule struct {
+	// Namespaces the rule applies to. Using an asterisk surrounded by single quotes ('*'), applies the rule to all namespaces.
+	Namespaces []string `json:"namespaces,omitempty"`
+
+	// Use a label selector to determine the namespaces the rule applies to, in addition to its namespaces.
+	NsSelector *metav1.LabelSelector `json:"nsSelector,omitempty"`
+
+	// The access modes allowed for the connections in the namespaces of the rule.
+	// +kubebuilder:validation:MinItems=1
+	AllowedAccessModes []ConnectionAccessMode `json:"allowedAccessModes"`
+}
+
+// The DBaaSQuotaPolicy object limits the number of objects using a policy's inventories.
+// A quota is not enforced when its limit is not set. The quotas are a best-effort limit, checked when an object is created:
+// objects created at the same time can all be admitted and exceed a quota, which is then reported in the quota usage of the policy status.
+type DBaaSQuotaPolicy struct {
+	// Maximum number of DBaaSInstance objects in a namespace, referencing a policy's inventories.
+	// +kubebuilder:validation:Minimum=0
+	MaxInstancesPerNamespace *int32 `json:"maxInstancesPerNamespace,omitempty"`
+
+	// Maximum number of DBaaSInstance objects referencing an inventory of a policy.
+	// +kubebuilder:validation:Minimum=0
+	MaxInstancesPerInventory *int32 `json:"maxInstancesPerInventory,omitempty"`
+
+	// Maximum number of DBaaSInstance objects referencing a policy's inventories of a provider.
+	// +kubebuilder:validation:Minimum=0
+	MaxInstancesPerProvider *int32 `json:"maxInstancesPerProvider,omitempty"`
+
+	// Maximum number of DBaaSConnection objects to a DBaaSInstance using a policy's inventories.
+	// +kubebuilder:validation:Minimum=0
+	MaxConnectionsPerInstance *int32 `json:"maxConnectionsPerInstance,omitempty"`
+}
+
+// DBaaSQuotaType is the type of a quota.
+type DBaaSQuotaType string
+
+// The DBaaSQuotaUsage object reports the usage of a quota, against its limit.
+type DBaaSQuotaUsage struct {
+	// The type of the quota: InstancesPerNamespace, InstancesPerInventory, InstancesPerProvider, or ConnectionsPerInstance.
+	Type DBaaSQuotaType `json:"type"`
+
+	// The name of the namespace, inventory, provider, or instance the usage is counted for.
+	// An instance is named with its namespace, as namespace/name.
+	Name string `json:"name"`
+
+	// The number of objects counted against the quota.
+	Used int32 `json:"used"`
+
+	// The limit of the quota.
+	Limit int32 `json:"limit"`
 }
 
 // Defines the observed state of a DBaaSPolicy object.
 type DBaaSPolicyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The usage of the quotas of an active policy.
+	QuotaUsage []DBaaSQuotaUsage `json:"quotaUsage,omitempty"`
+
+	// The policy in effect for an active policy, once narrowed by the DBaaSClusterPolicy.
+	// The inventories can narrow it further.
+	EffectivePolicy *DBaaSPolicySpec `json:"effectivePolicy,omitempty"`
 }
 
 //+kubebuilder:storageversion
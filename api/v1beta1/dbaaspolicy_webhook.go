@@ -17,9 +17,14 @@ limitations under the License.
 package v1beta1
 
 import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 )
@@ -29,6 +34,9 @@ var dbaaspolicylog = logf.Log.WithName("dbaaspolicy-resource")
 
 // SetupWebhookWithManager sets up the webhook with the Manager.
 func (r *DBaaSPolicy) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
 	return ctrl.NewWebhookManagedBy(mgr).
 		For(r).
 		Complete()
@@ -41,7 +49,10 @@ var _ webhook.Validator = &DBaaSPolicy{}
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type
 func (r *DBaaSPolicy) ValidateCreate() error {
 	dbaaspolicylog.Info("validate create", "name", r.Name)
-	return validatePolicy(r)
+	if err := validatePolicy(r); err != nil {
+		return err
+	}
+	return validateSinglePolicy(r)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type
@@ -63,5 +74,21 @@ func validatePolicy(policy *DBaaSPolicy) error {
 			return err
 		}
 	}
+	return validateAccessModeRules(policy.Spec.Connections.AccessModes)
+}
+
+// validateSinglePolicy rejects a policy in a namespace already having one.
+// Policies created concurrently may still both be admitted, the active one is then elected by the DBaaSPolicy controller.
+func validateSinglePolicy(policy *DBaaSPolicy) error {
+	policyList := &DBaaSPolicyList{}
+	if err := WebhookAPIClient.List(context.TODO(), policyList, client.InNamespace(policy.Namespace)); err != nil {
+		return err
+	}
+	for _, existing := range policyList.Items {
+		if existing.DeletionTimestamp == nil && existing.Name != policy.Name {
+			return errors.NewForbidden(GroupVersion.WithResource("dbaaspolicies").GroupResource(), policy.Name,
+				fmt.Errorf("the namespace %s already has the policy %s, a namespace can only have one policy", policy.Namespace, existing.Name))
+		}
+	}
 	return nil
 }
@@ -17,6 +17,8 @@ limitations under the License.
 package v1beta1
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -58,6 +60,20 @@ var _ = Describe("DBaaSPolicy Webhook", func() {
 			Expect(err).Should(BeNil())
 		})
 	})
+	Context("second policy", func() {
+		It("should not allow a second policy in the namespace", func() {
+			policy := &DBaaSPolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testpolicy-2",
+					Namespace: testNamespace,
+				},
+			}
+			Eventually(func() error {
+				return k8sClient.Create(ctx, policy)
+			}, timeout, interval).Should(MatchError(ContainSubstring(
+				"the namespace " + testNamespace + " already has the policy " + testDBaaSPolicy.Name + ", a namespace can only have one policy")))
+		})
+	})
 	Context("update",
 		func() {
 			Context("nominal", func() {
@@ -87,4 +103,93 @@ var _ = Describe("DBaaSPolicy Webhook", func() {
 				})
 			})
 		})
+	Context("election", func() {
+		policy := func(name string, created time.Time) DBaaSPolicy {
+			return DBaaSPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, CreationTimestamp: metav1.NewTime(created)}}
+		}
+		now := time.Now()
+
+		It("should elect the oldest policy", func() {
+			policies := []DBaaSPolicy{policy("policy-a", now), policy("policy-b", now.Add(-time.Hour)), policy("policy-c", now)}
+			Expect(ElectActivePolicy(policies).Name).Should(Equal("policy-b"))
+		})
+		It("should elect by name policies created at the same time", func() {
+			policies := []DBaaSPolicy{policy("policy-c", now), policy("policy-a", now), policy("policy-b", now)}
+			Expect(ElectActivePolicy(policies).Name).Should(Equal("policy-a"))
+		})
+		It("should not elect a policy being deleted", func() {
+			policies := []DBaaSPolicy{policy("policy-a", now.Add(-time.Hour)), policy("policy-b", now)}
+			deleted := metav1.NewTime(now)
+			policies[0].DeletionTimestamp = &deleted
+			Expect(ElectActivePolicy(policies).Name).Should(Equal("policy-b"))
+			Expect(ElectActivePolicy(policies[:1])).Should(BeNil())
+		})
+	})
+	Context("quota usage", func() {
+		inventory := func(name, provider string) DBaaSInventory {
+			return DBaaSInventory{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: testNamespace},
+				Spec:       DBaaSOperatorInventorySpec{ProviderRef: NamespacedName{Name: provider}},
+			}
+		}
+		instance := func(namespace, name, inventory, instanceID string) DBaaSInstance {
+			return DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: namespace},
+				Spec:       DBaaSInstanceSpec{InventoryRef: NamespacedName{Name: inventory, Namespace: testNamespace}},
+				Status:     DBaaSInstanceStatus{InstanceID: instanceID},
+			}
+		}
+		inventories := []DBaaSInventory{inventory("inventory-a", "provider-1"), inventory("inventory-b", "provider-1"), inventory("inventory-c", "provider-2")}
+		instances := []DBaaSInstance{
+			instance("tenant-1", "instance-1", "inventory-a", "id-1"),
+			instance("tenant-1", "instance-2", "inventory-b", "id-2"),
+			instance("tenant-2", "instance-3", "inventory-c", "id-3"),
+			// not using the inventories of the policy
+			instance("tenant-2", "instance-4", "other-inventory", "id-4"),
+		}
+		connections := []DBaaSConnection{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-ref", Namespace: "tenant-1"},
+				Spec: DBaaSConnectionSpec{
+					InventoryRef:       NamespacedName{Name: "inventory-a", Namespace: testNamespace},
+					DatabaseServiceRef: &NamespacedName{Name: "instance-1"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "by-id", Namespace: "tenant-3"},
+				Spec: DBaaSConnectionSpec{
+					InventoryRef:      NamespacedName{Name: "inventory-a", Namespace: testNamespace},
+					DatabaseServiceID: "id-1",
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "to-cluster", Namespace: "tenant-3"},
+				Spec: DBaaSConnectionSpec{
+					InventoryRef:      NamespacedName{Name: "inventory-a", Namespace: testNamespace},
+					DatabaseServiceID: "not-provisioned-by-an-instance",
+				},
+			},
+		}
+
+		It("should count the objects using the inventories of the policy", func() {
+			maxInstances := int32(2)
+			maxConnections := int32(5)
+			quotas := &DBaaSQuotaPolicy{
+				MaxInstancesPerNamespace:  &maxInstances,
+				MaxInstancesPerProvider:   &maxInstances,
+				MaxConnectionsPerInstance: &maxConnections,
+			}
+			Expect(quotas.QuotaUsage(inventories, instances, connections)).Should(Equal([]DBaaSQuotaUsage{
+				{Type: QuotaInstancesPerNamespace, Name: "tenant-1", Used: 2, Limit: 2},
+				{Type: QuotaInstancesPerNamespace, Name: "tenant-2", Used: 1, Limit: 2},
+				{Type: QuotaInstancesPerProvider, Name: "provider-1", Used: 2, Limit: 2},
+				{Type: QuotaInstancesPerProvider, Name: "provider-2", Used: 1, Limit: 2},
+				{Type: QuotaConnectionsPerInstance, Name: "tenant-1/instance-1", Used: 2, Limit: 5},
+			}))
+		})
+		It("should not count without quotas", func() {
+			var quotas *DBaaSQuotaPolicy
+			Expect(quotas.QuotaUsage(inventories, instances, connections)).Should(BeEmpty())
+		})
+	})
 })
@@ -26,12 +26,24 @@ const (
 	// DBaaS condition types:
 	DBaaSInventoryReadyType         string = "InventoryReady"
 	DBaaSInventoryProviderSyncType  string = "SpecSynced"
+	DBaaSInventoryCredentialsType   string = "CredentialsValid"
 	DBaaSConnectionReadyType        string = "ConnectionReady"
 	DBaaSConnectionProviderSyncType string = "ReadyForBinding"
+	DBaaSConnectionReachableType    string = "DatabaseReachable"
+	DBaaSConnectionExpiredType      string = "Expired"
+	DBaaSDatabaseReadyType          string = "DatabaseReady"
+	DBaaSDatabaseProviderSyncType   string = "SpecSynced"
 	DBaaSInstanceReadyType          string = "InstanceReady"
 	DBaaSInstanceProviderSyncType   string = "ProvisionReady"
+	DBaaSInstanceUpdateType         string = "UpdateInProgress"
 	DBaaSPolicyReadyType            string = "PolicyReady"
 	DBaaSPlatformReadyType          string = "PlatformReady"
+	DBaaSInventoryGrantReadyType    string = "GrantReady"
+	DBaaSBackupReadyType            string = "BackupReady"
+	DBaaSBackupProviderSyncType     string = "SpecSynced"
+	DBaaSBackupScheduleReadyType    string = "ScheduleReady"
+	DBaaSRestoreReadyType           string = "RestoreReady"
+	DBaaSRestoreProviderSyncType    string = "SpecSynced"
 
 	// DBaaS condition reasons:
 	Ready                          string = "Ready"
@@ -48,6 +60,28 @@ const (
 	ProviderParsingError           string = "ProviderParsingError"
 	InstallationInprogress         string = "InstallationInprogress"
 	InstallationCleanup            string = "InstallationCleanup"
+	ProviderDeletionInprogress     string = "ProviderDeletionInprogress"
+	DBaaSInvalidCredentials        string = "InvalidCredentials"
+	DBaaSCredentialsNotFound       string = "CredentialsNotFound"
+	DBaaSDatabaseUnreachable       string = "DatabaseUnreachable"
+	DBaaSUpdateRequested           string = "UpdateRequested"
+	DBaaSUpdating                  string = "Updating"
+	DBaaSUpdateCompleted           string = "UpdateCompleted"
+	DBaaSUpdateFailed              string = "UpdateFailed"
+	DBaaSGrantExpired              string = "GrantExpired"
+	DBaaSConnectionExpired         string = "ConnectionExpired"
+	DBaaSConnectionNotExpired      string = "ConnectionNotExpired"
+	DBaaSDatabaseNotSupported      string = "DatabaseNotSupported"
+	DBaaSBackupNotSupported        string = "BackupNotSupported"
+	DBaaSBackupNotCompleted        string = "BackupNotCompleted"
+	DBaaSBackupScheduled           string = "BackupScheduled"
+	DBaaSBackupScheduleSuspended   string = "ScheduleSuspended"
+	DBaaSRestoreNotSupported       string = "RestoreNotSupported"
+	DBaaSInvalidRestoreTarget      string = "InvalidRestoreTarget"
+	DBaaSCloningNotSupported       string = "CloningNotSupported"
+	DBaaSCloneSourceNotReady       string = "CloneSourceNotReady"
+	DBaaSInvalidCloneSource        string = "InvalidCloneSource"
+	DBaaSProvisioningNotSupported  string = "ProvisioningNotSupported"
 
 	// DBaaS condition messages
 	MsgProviderCRStatusSyncDone      string = "Provider Custom Resource status sync completed"
@@ -57,7 +91,62 @@ const (
 	MsgPolicyNotFound                string = "Failed to find an active Policy"
 	MsgPolicyReady                   string = "Policy is active"
 	MsgInvalidNamespace              string = "Invalid connection namespace for the referenced inventory"
+	MsgConnectionRevoked             string = "Invalid connection namespace for the referenced inventory, the connection credentials are revoked"
 	MsgPolicyNotReady                string = "Another active Policy already exists"
+	MsgProviderCRDeletionInProgress  string = "Waiting for the provider to delete the instance"
+	MsgCredentialsValid              string = "Credentials are valid for the provider"
+	MsgDatabaseReachable             string = "Database is reachable from the cluster"
+	MsgClusterPolicyNotApplied       string = "Only the cluster policy named " + ClusterPolicyName + " is applied"
+	MsgGrantReady                    string = "Grant is active"
+	MsgGrantExpired                  string = "Grant expired, it no longer allows connections"
+	MsgConnectionExpired             string = "Connection expired, the provider connection is deleted"
+	MsgConnectionNotExpired          string = "Connection has not expired"
+	MsgDatabaseNotSupported          string = "The provider does not support provisioning databases inside an instance"
+	MsgBackupNotSupported            string = "The provider does not support backups"
+	MsgBackupNotCompleted            string = "Waiting for the backup to complete"
+	MsgBackupScheduleSuspended       string = "Backup schedule is suspended"
+	MsgRestoreNotSupported           string = "The provider does not support restores"
+	MsgInvalidRestoreTarget          string = "The target instance does not use the inventory of the backup"
+	MsgRestoreTargetNamespace        string = "The target instance is not in the namespace of the restore"
+	MsgCloningNotSupported           string = "The provider does not support cloning instances"
+	MsgCloneSourceNotReady           string = "Waiting for the source of the clone to be available"
+	MsgInvalidCloneSource            string = "The source of the clone does not use the inventory of the instance"
+	MsgProvisioningNotSupported      string = "The provider does not support provisioning instances"
+
+	// DBaaSInstanceFinalizer blocks the removal of a DBaaSInstance until its deletion policy is applied to the provider instance.
+	DBaaSInstanceFinalizer = "dbaas.redhat.com/instance-deletion-policy"
+
+	// ForceDeleteAnnotation, when set to "true", allows deleting a DBaaSInventory or DBaaSInstance that still has DBaaSConnections referencing it.
+	ForceDeleteAnnotation = "dbaas.redhat.com/force-delete"
+
+	// TopologyNodeLabelKey marks the ConfigMap representing a DBaaSConnection in the topology view of the console.
+	TopologyNodeLabelKey = "dbaas.redhat.com/topology-node"
+
+	// RolloutOnCredentialsChangeAnnotation, when set to "true" on a DBaaSConnection, restarts the workloads bound to the connection
+	// when its credentials or connection information change.
+	RolloutOnCredentialsChangeAnnotation = "dbaas.redhat.com/rollout-on-credentials-change"
+
+	// RestartedAtAnnotation is set on the pod template of a workload bound to a DBaaSConnection to restart its pods.
+	RestartedAtAnnotation = "dbaas.redhat.com/restartedAt"
+
+	// InjectionRestartedForAnnotation records, on a Deployment labeled for the injection of a DBaaSConnection, the generation and credentials
+	// fingerprint of the connection the Deployment was last restarted for, so it is only restarted once if the connection is not injected.
+	InjectionRestartedForAnnotation = "dbaas.redhat.com/injection-restarted-for"
+
+	// DefaultedParametersAnnotation records, as a JSON object, the provisioning parameters of a DBaaSInstance set to the default values of the provider
+	// when the instance was created.
+	DefaultedParametersAnnotation = "dbaas.redhat.com/defaulted-provisioning-parameters"
+
+	// CredentialsHashAnnotation holds the fingerprint of the credential fields of the last synced inventory credentials secret, from the status
+	// of the DBaaSInventory, on the provider inventory to trigger a re-sync on rotation.
+	CredentialsHashAnnotation = "dbaas.redhat.com/credentials-hash"
+
+	// BackupScheduleLabel is set on the DBaaSBackups created by a DBaaSBackupSchedule, with the name of the schedule.
+	BackupScheduleLabel = "dbaas.redhat.com/backup-schedule"
+
+	// ConnectionRequesterAnnotation records the user who created a DBaaSConnection, so that a DBaaSInventoryGrant can allow
+	// the connections created by a service account. It is set by the webhook and cannot be changed.
+	ConnectionRequesterAnnotation = "dbaas.redhat.com/requester"
 
 	TypeLabelValue    = "credentials"
 	TypeLabelKey      = "db-operator/type"
@@ -93,9 +182,39 @@ const (
 // Defines the phases for instance provisioning.
 type DBaasInstancePhase string
 
+// Defines what happens to the provider instance when a DBaaSInstance is deleted.
+type DeletionPolicy string
+
+// Constants for the instance deletion policies.
+const (
+	DeletionPolicyDelete DeletionPolicy = "Delete"
+	DeletionPolicyRetain DeletionPolicy = "Retain"
+)
+
+// Defines whether a provisioning parameter can be updated once the instance is created.
+type ParameterMutability string
+
+// Constants for the mutability of the provisioning parameters.
+const (
+	ParameterImmutable       ParameterMutability = "Immutable"
+	ParameterUpdatable       ParameterMutability = "Updatable"
+	ParameterRequiresRestart ParameterMutability = "RequiresRestart"
+)
+
 // Defines the supported database service types.
 type DatabaseServiceType string
 
+// Defines the access level of the database user of a connection.
+// +kubebuilder:validation:Enum=ReadWrite;ReadOnly;Admin
+type ConnectionAccessMode string
+
+// Constants for the connection access modes.
+const (
+	AccessModeReadWrite ConnectionAccessMode = "ReadWrite"
+	AccessModeReadOnly  ConnectionAccessMode = "ReadOnly"
+	AccessModeAdmin     ConnectionAccessMode = "Admin"
+)
+
 // Constants for the instance phases.
 const (
 	InstancePhaseUnknown  DBaasInstancePhase = "Unknown"
@@ -127,6 +246,16 @@ type DBaaSProviderSpec struct {
 	// The name of the instance's custom resource definition (CRD) as defined by the provider for provisioning.
 	InstanceKind string `json:"instanceKind"`
 
+	// The name of the database's custom resource definition (CRD) as defined by the provider,
+	// for provisioning logical databases and users inside an instance. The provider does not support DBaaSDatabases if not set.
+	DatabaseKind string `json:"databaseKind,omitempty"`
+
+	// The name of the backup's custom resource definition (CRD) as defined by the provider. The provider does not support DBaaSBackups if not set.
+	BackupKind string `json:"backupKind,omitempty"`
+
+	// The name of the restore's custom resource definition (CRD) as defined by the provider. The provider does not support DBaaSRestores if not set.
+	RestoreKind string `json:"restoreKind,omitempty"`
+
 	// Indicates what information to collect from the user interface and how to display fields in a form.
 	CredentialFields []CredentialField `json:"credentialFields"`
 
@@ -141,11 +270,51 @@ type DBaaSProviderSpec struct {
 
 	// Parameter specifications used by the user interface (UI) for provisioning a database instance.
 	ProvisioningParameters map[ProvisioningParameterType]ProvisioningParameter `json:"provisioningParameters,omitempty"`
+
+	// The operations supported by the provider, checked by the operator before accepting a request.
+	// A provider not declaring its capabilities provisions, updates and deletes instances, supports backups if it declares a backupKind,
+	// and only supports ReadWrite connections.
+	Capabilities *DBaaSProviderCapabilities `json:"capabilities,omitempty"`
+}
+
+// Defines the operations supported by a provider.
+type DBaaSProviderCapabilities struct {
+	// Indicates whether the provider provisions DBaaSInstances, true if not set.
+	Provisioning *bool `json:"provisioning,omitempty"`
+
+	// Indicates whether the provider applies the changes of the provisioning parameters of a provisioned instance, true if not set.
+	Updates *bool `json:"updates,omitempty"`
+
+	// Indicates whether the provider deletes the database instance of a provider instance being deleted, and reports it as Deleted, true if not set.
+	Deletion *bool `json:"deletion,omitempty"`
+
+	// Indicates whether the provider supports DBaaSBackups, DBaaSBackupSchedules and DBaaSRestores, true if not set.
+	// Requires backupKind, and restoreKind for restores.
+	Backups *bool `json:"backups,omitempty"`
+
+	// Indicates whether the provider can create an instance as a clone of another instance, of a backup, or of another instance at a point in time,
+	// false if not set.
+	Cloning *bool `json:"cloning,omitempty"`
+
+	// The access modes supported for the connections. The provider only supports ReadWrite connections if not set.
+	AccessModes []ConnectionAccessMode `json:"accessModes,omitempty"`
+
+	// Indicates whether the connections to the database instances of the provider use TLS, false if not set.
+	TLS *bool `json:"tls,omitempty"`
+
+	// Indicates whether the provider can connect to database instances over private networking, false if not set.
+	PrivateNetworking *bool `json:"privateNetworking,omitempty"`
+
+	// The types of database services the connections can reference with databaseServiceType. Any type is supported if not set.
+	DatabaseServiceTypes []DatabaseServiceType `json:"databaseServiceTypes,omitempty"`
 }
 
 // Defines the observed state of DBaaSProvider object.
 type DBaaSProviderStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The capabilities of the provider, as applied by the operator.
+	Capabilities *DBaaSProviderCapabilities `json:"capabilities,omitempty"`
 }
 
 // Defines the information for a DBaaSProvider object.
@@ -263,9 +432,23 @@ type DBaaSConnectionSpec struct {
 
 	// The type of the database service to connect to, as seen in the status of the referenced DBaaSInventory.
 	DatabaseServiceType *DatabaseServiceType `json:"databaseServiceType,omitempty"`
+
+	// The time the connection expires. The provider connection, and with it the database user, is then deleted.
+	// Only one of expiresAt and ttl can be specified, the connection does not expire if none is set.
+	ExpiresAt *metav1.Time `json:"expiresAt,omitempty"`
+
+	// The lifetime of the connection from its creation, as an alternative to expiresAt, for example 8h.
+	TTL *metav1.Duration `json:"ttl,omitempty"`
+
+	// The access level of the database user created for the connection: ReadWrite, ReadOnly or Admin. Defaults to ReadWrite.
+	// The access mode must be supported by the provider, and allowed by the policy of the inventory in the namespace of the connection.
+	AccessMode ConnectionAccessMode `json:"accessMode,omitempty"`
+
+	// The database roles granted to the database user created for the connection, in addition to its access mode.
+	Roles []string `json:"roles,omitempty"`
 }
 
-// Defines the observed state of a DBaaSConnection object.
+// Defines the connection status that the provider's operator uses.
 type DBaaSConnectionStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 
@@ -299,6 +482,38 @@ type DBaaSInstanceSpec struct {
 
 	// Parameters with values used for provisioning.
 	ProvisioningParameters map[ProvisioningParameterType]string `json:"provisioningParameters,omitempty"`
+
+	// +kubebuilder:validation:Enum=Delete;Retain
+	// Determines what happens to the provider instance when this instance is deleted. Defaults to Delete.
+	// Delete: The provider instance is deleted, and the removal of this instance waits until the provider reports the database instance as deleted.
+	// Retain: The provider instance is detached and kept, together with the database instance in the database service.
+	DeletionPolicy DeletionPolicy `json:"deletionPolicy,omitempty"`
+
+	// The source the instance is cloned from, when the instance is a clone. Cannot be changed once the instance is created.
+	Source *DBaaSInstanceSource `json:"source,omitempty"`
+}
+
+// Defines the source of a cloned DBaaSInstance. Either instanceRef or backupRef is set.
+type DBaaSInstanceSource struct {
+	// A reference to the DBaaSInstance to clone. The instance must use the same inventory as the clone.
+	InstanceRef *NamespacedName `json:"instanceRef,omitempty"`
+
+	// A reference to the DBaaSBackup to clone. The backed up instance must use the same inventory as the clone.
+	BackupRef *NamespacedName `json:"backupRef,omitempty"`
+
+	// The point in time to clone the instance referenced by instanceRef at. The current state of the instance is cloned if not set.
+	PointInTime *metav1.Time `json:"pointInTime,omitempty"`
+}
+
+// Defines the desired state of a provider instance object.
+type DBaaSProviderInstanceSpec struct {
+	DBaaSInstanceSpec `json:",inline"`
+
+	// The provider-specific identifier of the source instance of a clone, or of the backed up instance when cloning a backup.
+	SourceInstanceID string `json:"sourceInstanceID,omitempty"`
+
+	// The provider-specific identifier of the backup to clone.
+	SourceBackupID string `json:"sourceBackupID,omitempty"`
 }
 
 // Defines the observed state of a DBaaSInstance.
@@ -331,8 +546,238 @@ type DBaaSInstanceStatus struct {
 type DBaaSProviderInstance struct {
 	metav1.ObjectMeta `json:"metadata,omitempty"`
 
-	Spec   DBaaSInstanceSpec   `json:"spec,omitempty"`
-	Status DBaaSInstanceStatus `json:"status,omitempty"`
+	Spec   DBaaSProviderInstanceSpec `json:"spec,omitempty"`
+	Status DBaaSInstanceStatus       `json:"status,omitempty"`
+}
+
+// Defines the desired state of a DBaaSDatabase object.
+type DBaaSDatabaseSpec struct {
+	// A reference to the DBaaSInstance hosting the logical databases.
+	DatabaseServiceRef NamespacedName `json:"databaseServiceRef"`
+
+	// The logical databases to create in the instance.
+	// +kubebuilder:validation:MinItems=1
+	Databases []LogicalDatabase `json:"databases"`
+
+	// The roles to create in the instance, with their grants on the logical databases.
+	Roles []DatabaseRole `json:"roles,omitempty"`
+}
+
+// Defines a logical database inside an instance.
+type LogicalDatabase struct {
+	// The name of the database.
+	Name string `json:"name"`
+
+	// The schemas to create in the database.
+	Schemas []string `json:"schemas,omitempty"`
+
+	// The role owning the database, one of the roles of the DBaaSDatabase.
+	Owner string `json:"owner,omitempty"`
+}
+
+// Defines a role, or user, inside an instance.
+type DatabaseRole struct {
+	// The name of the role.
+	Name string `json:"name"`
+
+	// The privileges granted to the role.
+	Grants []DatabaseGrant `json:"grants,omitempty"`
+}
+
+// Defines privileges granted to a role on a logical database, or on a schema of a logical database.
+type DatabaseGrant struct {
+	// The name of the database, one of the databases of the DBaaSDatabase.
+	Database string `json:"database"`
+
+	// The schema of the database, the privileges apply to the whole database if not set.
+	Schema string `json:"schema,omitempty"`
+
+	// The privileges, in the terms of the database engine, for example SELECT, INSERT or ALL.
+	// +kubebuilder:validation:MinItems=1
+	Privileges []string `json:"privileges"`
+}
+
+// Defines the database specification of a provider database object, with the instance resolved by the DBaaS operator.
+type DBaaSProviderDatabaseSpec struct {
+	DBaaSDatabaseSpec `json:",inline"`
+
+	// A reference to the DBaaSInventory of the instance.
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the instance in the database service, as seen in the status of the DBaaSInstance.
+	DatabaseServiceID string `json:"databaseServiceID"`
+}
+
+// Defines the observed state of a DBaaSDatabase object.
+type DBaaSDatabaseStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The credentials of the roles, created by the provider.
+	Roles []DatabaseRoleStatus `json:"roles,omitempty"`
+}
+
+// Defines the observed state of a role.
+type DatabaseRoleStatus struct {
+	// The name of the role.
+	Name string `json:"name"`
+
+	// The secret holding the credentials of the role, in the namespace of the DBaaSDatabase.
+	CredentialsRef *corev1.LocalObjectReference `json:"credentialsRef,omitempty"`
+}
+
+// The schema for a provider database object.
+type DBaaSProviderDatabase struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSProviderDatabaseSpec `json:"spec,omitempty"`
+	Status DBaaSDatabaseStatus       `json:"status,omitempty"`
+}
+
+// Defines the phase of a backup or of a restore.
+type DBaaSBackupPhase string
+
+// Constants for the backup and restore phases.
+const (
+	BackupPhaseUnknown    DBaaSBackupPhase = "Unknown"
+	BackupPhasePending    DBaaSBackupPhase = "Pending"
+	BackupPhaseInProgress DBaaSBackupPhase = "InProgress"
+	BackupPhaseCompleted  DBaaSBackupPhase = "Completed"
+	BackupPhaseFailed     DBaaSBackupPhase = "Failed"
+)
+
+// Defines the desired state of a DBaaSBackup object.
+type DBaaSBackupSpec struct {
+	// A reference to the DBaaSInstance to back up, in the namespace of the backup if the namespace is not set.
+	InstanceRef NamespacedName `json:"instanceRef"`
+
+	// How long the provider keeps the backup, for example 720h. The retention of the provider applies if not set.
+	Retention *metav1.Duration `json:"retention,omitempty"`
+}
+
+// Defines the backup specification of a provider backup object, with the instance resolved by the DBaaS operator.
+type DBaaSProviderBackupSpec struct {
+	DBaaSBackupSpec `json:",inline"`
+
+	// A reference to the DBaaSInventory of the instance.
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the instance in the database service, as seen in the status of the DBaaSInstance.
+	DatabaseServiceID string `json:"databaseServiceID"`
+}
+
+// Defines the observed state of a DBaaSBackup object.
+type DBaaSBackupStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The ID of the backup in the database service, used to restore it.
+	BackupID string `json:"backupID,omitempty"`
+
+	// +kubebuilder:validation:Enum=Unknown;Pending;InProgress;Completed;Failed
+	// The phase of the backup.
+	Phase DBaaSBackupPhase `json:"phase,omitempty"`
+
+	// The time the backup started.
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// The time the backup completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// The schema for a provider backup object.
+type DBaaSProviderBackup struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSProviderBackupSpec `json:"spec,omitempty"`
+	Status DBaaSBackupStatus       `json:"status,omitempty"`
+}
+
+// Defines the desired state of a DBaaSBackupSchedule object.
+type DBaaSBackupScheduleSpec struct {
+	// The specification of the DBaaSBackups created by the schedule.
+	BackupTemplate DBaaSBackupSpec `json:"backupTemplate"`
+
+	// The time between two backups, for example 24h.
+	Interval metav1.Duration `json:"interval"`
+
+	// The number of DBaaSBackups created by the schedule to keep, the oldest ones are deleted. All the backups are kept if not set.
+	// +kubebuilder:validation:Minimum=1
+	BackupsHistoryLimit *int32 `json:"backupsHistoryLimit,omitempty"`
+
+	// Suspends the creation of backups.
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// Defines the observed state of a DBaaSBackupSchedule object.
+type DBaaSBackupScheduleStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// The last time a DBaaSBackup was created by the schedule.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// The last DBaaSBackup created by the schedule.
+	LastBackupRef *corev1.LocalObjectReference `json:"lastBackupRef,omitempty"`
+}
+
+// Defines the desired state of a DBaaSRestore object.
+type DBaaSRestoreSpec struct {
+	// A reference to the DBaaSBackup to restore, in the namespace of the restore if the namespace is not set.
+	BackupRef NamespacedName `json:"backupRef"`
+
+	// A reference to the DBaaSInstance to restore the backup to, which must be in the namespace of the restore.
+	// The backup is restored to the instance it was taken from if neither targetInstanceRef nor newInstance is set,
+	// only if that instance is in the namespace of the restore.
+	TargetInstanceRef *NamespacedName `json:"targetInstanceRef,omitempty"`
+
+	// A DBaaSInstance to create, in the namespace of the restore, with the inventory of the backed up instance, to restore the backup to.
+	// The instance is created by the DBaaS operator, so the user creating the restore must be allowed to create DBaaSInstances in the namespace.
+	NewInstance *DBaaSRestoreInstance `json:"newInstance,omitempty"`
+}
+
+// Defines the DBaaSInstance created by a restore.
+type DBaaSRestoreInstance struct {
+	// The name of the DBaaSInstance.
+	Name string `json:"name"`
+
+	// Parameters with values used for provisioning the instance.
+	ProvisioningParameters map[ProvisioningParameterType]string `json:"provisioningParameters,omitempty"`
+}
+
+// Defines the restore specification of a provider restore object, with the backup and the instances resolved by the DBaaS operator.
+type DBaaSProviderRestoreSpec struct {
+	// A reference to the DBaaSInventory of the instances.
+	InventoryRef NamespacedName `json:"inventoryRef"`
+
+	// The ID of the backup in the database service, as seen in the status of the DBaaSBackup.
+	BackupID string `json:"backupID"`
+
+	// The ID of the backed up instance in the database service.
+	SourceDatabaseServiceID string `json:"sourceDatabaseServiceID"`
+
+	// The ID of the instance to restore the backup to in the database service.
+	DatabaseServiceID string `json:"databaseServiceID"`
+}
+
+// Defines the observed state of a DBaaSRestore object.
+type DBaaSRestoreStatus struct {
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// +kubebuilder:validation:Enum=Unknown;Pending;InProgress;Completed;Failed
+	// The phase of the restore.
+	Phase DBaaSBackupPhase `json:"phase,omitempty"`
+
+	// The DBaaSInstance the backup is restored to: the target instance, the new instance created for the restore, or else the backed up instance.
+	TargetInstanceRef *NamespacedName `json:"targetInstanceRef,omitempty"`
+
+	// The time the restore completed.
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+}
+
+// The schema for a provider restore object.
+type DBaaSProviderRestore struct {
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSProviderRestoreSpec `json:"spec,omitempty"`
+	Status DBaaSRestoreStatus       `json:"status,omitempty"`
 }
 
 // Defines the value and display value for an option in a dropdown menu, radio button, or checkbox.
@@ -364,6 +809,13 @@ type ProvisioningParameter struct {
 
 	// Lists of additional data containing the options or default values for the field.
 	ConditionalData []ConditionalProvisioningParameterData `json:"conditionalData,omitempty"`
+
+	// +kubebuilder:validation:Enum=Immutable;Updatable;RequiresRestart
+	// Determines whether the value can be changed once the instance is created. Defaults to Immutable.
+	// Immutable: The value cannot be changed.
+	// Updatable: The value can be changed, and the provider updates the database instance in place.
+	// RequiresRestart: The value can be changed, and the provider restarts the database instance to apply it.
+	Mutability ParameterMutability `json:"mutability,omitempty"`
 }
 
 // A list of available options with default values for a dropdown menu, or a list of default values entered by the user within the user interface (UI) based on the dependencies.
@@ -21,6 +21,7 @@ const (
 	MongoDBAtlasRegistration     = "mongodb-atlas-registration"
 	CrunchyBridgeRegistration    = "crunchy-bridge-registration"
 	RdsRegistration              = "rds-registration"
+	LocalRegistration            = "local-registration"
 )
 
 // Hub marks this type as a conversion hub.
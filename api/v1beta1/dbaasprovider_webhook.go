@@ -18,6 +18,7 @@ package v1beta1
 
 import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 )
@@ -46,4 +47,68 @@ func (r *DBaaSProvider) GetDBaaSAPIGroupVersion() schema.GroupVersion {
 	}
 }
 
+// GetCapabilities returns the capabilities of the provider, with the defaults applied for the capabilities it does not declare.
+// Backups require a backupKind,
+// connections support at least the ReadWrite access mode, and the providers implementing the v1alpha1 API
+// do not support the operations only defined by the v1beta1 API. All the optional capabilities of the result are set.
+func (r *DBaaSProvider) GetCapabilities() DBaaSProviderCapabilities {
+	var capabilities DBaaSProviderCapabilities
+	if r.Spec.Capabilities != nil {
+		r.Spec.Capabilities.DeepCopyInto(&capabilities)
+	}
+	if len(capabilities.AccessModes) == 0 {
+		capabilities.AccessModes = []ConnectionAccessMode{AccessModeReadWrite}
+	}
+	backups := capabilities.SupportsBackups() && len(r.Spec.BackupKind) > 0
+	cloning := capabilities.SupportsCloning()
+	if r.GetDBaaSAPIGroupVersion().Version == "v1alpha1" {
+		backups = false
+		cloning = false
+		capabilities.AccessModes = []ConnectionAccessMode{AccessModeReadWrite}
+	}
+	capabilities.Provisioning = pointer.Bool(capabilities.SupportsProvisioning())
+	capabilities.Updates = pointer.Bool(capabilities.SupportsUpdates())
+	capabilities.Deletion = pointer.Bool(capabilities.SupportsDeletion())
+	capabilities.Backups = pointer.Bool(backups)
+	capabilities.Cloning = pointer.Bool(cloning)
+	capabilities.TLS = pointer.Bool(capabilities.SupportsTLS())
+	capabilities.PrivateNetworking = pointer.Bool(capabilities.SupportsPrivateNetworking())
+	return capabilities
+}
+
+// SupportsProvisioning returns true if the provider provisions instances, the default
+func (c DBaaSProviderCapabilities) SupportsProvisioning() bool {
+	return c.Provisioning == nil || *c.Provisioning
+}
+
+// SupportsUpdates returns true if the provider applies the changes of the provisioning parameters, the default
+func (c DBaaSProviderCapabilities) SupportsUpdates() bool {
+	return c.Updates == nil || *c.Updates
+}
+
+// SupportsDeletion returns true if the provider deletes the database instances, the default
+func (c DBaaSProviderCapabilities) SupportsDeletion() bool {
+	return c.Deletion == nil || *c.Deletion
+}
+
+// SupportsBackups returns true if the provider supports backups, the default, it does not check the backupKind of the provider
+func (c DBaaSProviderCapabilities) SupportsBackups() bool {
+	return c.Backups == nil || *c.Backups
+}
+
+// SupportsCloning returns true if the provider supports cloning, false if not set
+func (c DBaaSProviderCapabilities) SupportsCloning() bool {
+	return c.Cloning != nil && *c.Cloning
+}
+
+// SupportsTLS returns true if the connections use TLS, false if not set
+func (c DBaaSProviderCapabilities) SupportsTLS() bool {
+	return c.TLS != nil && *c.TLS
+}
+
+// SupportsPrivateNetworking returns true if the provider connects over private networking, false if not set
+func (c DBaaSProviderCapabilities) SupportsPrivateNetworking() bool {
+	return c.PrivateNetworking != nil && *c.PrivateNetworking
+}
+
 // TODO(user): EDIT THIS FILE!  THIS IS SCAFFOLDING FOR YOU TO OWN!
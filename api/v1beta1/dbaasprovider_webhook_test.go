@@ -0,0 +1,102 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"k8s.io/utils/pointer"
+)
+
+var _ = Describe("DBaaSProvider capabilities", func() {
+	newProvider := func(groupVersion string, capabilities *DBaaSProviderCapabilities) *DBaaSProvider {
+		return &DBaaSProvider{
+			Spec: DBaaSProviderSpec{
+				GroupVersion: groupVersion,
+				BackupKind:   "TestBackup",
+				Capabilities: capabilities,
+			},
+		}
+	}
+
+	It("should default the capabilities of a provider not declaring them", func() {
+		provider := newProvider(GroupVersion.String(), nil)
+		Expect(provider.GetCapabilities()).Should(Equal(DBaaSProviderCapabilities{
+			Provisioning:      pointer.Bool(true),
+			Updates:           pointer.Bool(true),
+			Deletion:          pointer.Bool(true),
+			Backups:           pointer.Bool(true),
+			Cloning:           pointer.Bool(false),
+			AccessModes:       []ConnectionAccessMode{AccessModeReadWrite},
+			TLS:               pointer.Bool(false),
+			PrivateNetworking: pointer.Bool(false),
+		}))
+
+		provider.Spec.BackupKind = ""
+		Expect(provider.GetCapabilities().SupportsBackups()).Should(BeFalse())
+	})
+	It("should default the capabilities a provider does not declare", func() {
+		provider := newProvider(GroupVersion.String(), &DBaaSProviderCapabilities{
+			Updates: pointer.Bool(false),
+			TLS:     pointer.Bool(true),
+		})
+		capabilities := provider.GetCapabilities()
+		Expect(capabilities.SupportsProvisioning()).Should(BeTrue())
+		Expect(capabilities.SupportsUpdates()).Should(BeFalse())
+		Expect(capabilities.SupportsDeletion()).Should(BeTrue())
+		Expect(capabilities.SupportsBackups()).Should(BeTrue())
+		Expect(capabilities.SupportsCloning()).Should(BeFalse())
+		Expect(capabilities.SupportsTLS()).Should(BeTrue())
+		Expect(capabilities.SupportsPrivateNetworking()).Should(BeFalse())
+	})
+	It("should return the declared capabilities", func() {
+		declared := &DBaaSProviderCapabilities{
+			Provisioning:         pointer.Bool(true),
+			Updates:              pointer.Bool(false),
+			Deletion:             pointer.Bool(false),
+			Backups:              pointer.Bool(true),
+			Cloning:              pointer.Bool(true),
+			AccessModes:          []ConnectionAccessMode{AccessModeReadWrite, AccessModeReadOnly},
+			TLS:                  pointer.Bool(true),
+			PrivateNetworking:    pointer.Bool(false),
+			DatabaseServiceTypes: []DatabaseServiceType{"instance"},
+		}
+		provider := newProvider(GroupVersion.String(), declared)
+		Expect(provider.GetCapabilities()).Should(Equal(*declared))
+
+		By("not modifying the declared capabilities")
+		capabilities := provider.GetCapabilities()
+		capabilities.AccessModes[0] = AccessModeAdmin
+		Expect(provider.Spec.Capabilities.AccessModes[0]).Should(Equal(AccessModeReadWrite))
+	})
+	It("should only support ReadWrite connections if no access mode is declared", func() {
+		provider := newProvider(GroupVersion.String(), &DBaaSProviderCapabilities{})
+		Expect(provider.GetCapabilities().AccessModes).Should(Equal([]ConnectionAccessMode{AccessModeReadWrite}))
+	})
+	It("should not support the v1beta1 operations for a v1alpha1 provider", func() {
+		provider := newProvider("", &DBaaSProviderCapabilities{
+			Backups:     pointer.Bool(true),
+			Cloning:     pointer.Bool(true),
+			AccessModes: []ConnectionAccessMode{AccessModeReadOnly},
+		})
+		capabilities := provider.GetCapabilities()
+		Expect(capabilities.SupportsProvisioning()).Should(BeTrue())
+		Expect(capabilities.SupportsBackups()).Should(BeFalse())
+		Expect(capabilities.SupportsCloning()).Should(BeFalse())
+		Expect(capabilities.AccessModes).Should(Equal([]ConnectionAccessMode{AccessModeReadWrite}))
+	})
+})
@@ -0,0 +1,51 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="Backup",type=string,JSONPath=`.spec.backupRef.name`
+//+kubebuilder:printcolumn:name="Target",type=string,JSONPath=`.status.targetInstanceRef.name`
+//+kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
+
+// The schema for the DBaaSRestore API.
+// Restores a DBaaSBackup to the backed up DBaaSInstance, to another instance of the same inventory, or to a new instance.
+// +operator-sdk:csv:customresourcedefinitions:displayName="DBaaSRestore"
+type DBaaSRestore struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSRestoreSpec   `json:"spec,omitempty"`
+	Status DBaaSRestoreStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of DBaaSRestores.
+type DBaaSRestoreList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []DBaaSRestore `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&DBaaSRestore{}, &DBaaSRestoreList{})
+}
@@ -0,0 +1,159 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"reflect"
+
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+// log is for logging in this package.
+var dbaasrestorelog = logf.Log.WithName("dbaasrestore-resource")
+
+// SetupWebhookWithManager sets up the webhook with the Manager.
+func (r *DBaaSRestore) SetupWebhookWithManager(mgr ctrl.Manager) error {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(r).
+		WithValidator(&restoreValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-dbaas-redhat-com-v1beta1-dbaasrestore,mutating=false,failurePolicy=fail,sideEffects=None,groups=dbaas.redhat.com,resources=dbaasrestores,verbs=create;update,versions=v1beta1,name=vdbaasrestore.kb.io,admissionReviewVersions=v1beta1
+
+// restoreValidator validates a DBaaSRestore, with the user creating it for a restore to a new instance
+// +kubebuilder:object:generate=false
+type restoreValidator struct{}
+
+var _ admission.CustomValidator = &restoreValidator{}
+
+// ValidateCreate implements admission.CustomValidator so a webhook will be registered for the type
+func (v *restoreValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	restore := obj.(*DBaaSRestore)
+	dbaasrestorelog.Info("validate create", "name", restore.Name)
+	if err := validateRestore(restore, nil); err != nil {
+		return err
+	}
+	if restore.Spec.NewInstance != nil {
+		return validateNewInstanceAccess(ctx, restore)
+	}
+	return nil
+}
+
+// ValidateUpdate implements admission.CustomValidator so a webhook will be registered for the type
+func (v *restoreValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	restore := newObj.(*DBaaSRestore)
+	dbaasrestorelog.Info("validate update", "name", restore.Name)
+	return validateRestore(restore, oldObj.(*DBaaSRestore))
+}
+
+// ValidateDelete implements admission.CustomValidator so a webhook will be registered for the type
+func (v *restoreValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	dbaasrestorelog.Info("validate delete", "name", obj.(*DBaaSRestore).Name)
+	return nil
+}
+
+// validateNewInstanceAccess checks the user creating a restore to a new instance is allowed to create DBaaSInstances
+// in the namespace of the restore, as the instance is created by the operator on behalf of the user
+func validateNewInstanceAccess(ctx context.Context, restore *DBaaSRestore) error {
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	extra := make(map[string]authorizationv1.ExtraValue, len(req.UserInfo.Extra))
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	review := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			Extra:  extra,
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: restore.Namespace,
+				Verb:      "create",
+				Group:     GroupVersion.Group,
+				Resource:  "dbaasinstances",
+			},
+		},
+	}
+	if err := WebhookAPIClient.Create(ctx, review); err != nil {
+		return err
+	}
+	if !review.Status.Allowed {
+		return errors.NewForbidden(GroupVersion.WithResource("dbaasrestores").GroupResource(), restore.Name,
+			field.Forbidden(field.NewPath("spec", "newInstance"), "the user is not allowed to create DBaaSInstances in the namespace of the restore"))
+	}
+	return nil
+}
+
+// validateRestore checks the backup and the target of a restore, which cannot be changed once requested
+func validateRestore(restore *DBaaSRestore, old *DBaaSRestore) error {
+	var errs field.ErrorList
+	specPath := field.NewPath("spec")
+	if old != nil && !reflect.DeepEqual(restore.Spec, old.Spec) {
+		errs = append(errs, field.Forbidden(specPath, "the spec of a restore is immutable"))
+	}
+	if len(restore.Spec.BackupRef.Name) == 0 {
+		errs = append(errs, field.Required(specPath.Child("backupRef", "name"), "the backup must be specified"))
+	}
+	if restore.Spec.TargetInstanceRef != nil && restore.Spec.NewInstance != nil {
+		errs = append(errs, field.Invalid(specPath.Child("newInstance"), restore.Spec.NewInstance.Name, "both targetInstanceRef and newInstance are specified"))
+	}
+	if restore.Spec.TargetInstanceRef != nil && len(restore.Spec.TargetInstanceRef.Name) == 0 {
+		errs = append(errs, field.Required(specPath.Child("targetInstanceRef", "name"), "the target instance must be specified"))
+	}
+	if restore.Spec.TargetInstanceRef != nil && len(restore.Spec.TargetInstanceRef.Namespace) > 0 && restore.Spec.TargetInstanceRef.Namespace != restore.Namespace {
+		errs = append(errs, field.Invalid(specPath.Child("targetInstanceRef", "namespace"), restore.Spec.TargetInstanceRef.Namespace, "the target instance must be in the namespace of the restore"))
+	}
+	if restore.Spec.NewInstance != nil && len(restore.Spec.NewInstance.Name) == 0 {
+		errs = append(errs, field.Required(specPath.Child("newInstance", "name"), "the name of the new instance must be specified"))
+	}
+	if old == nil && len(errs) == 0 {
+		backup := &DBaaSBackup{}
+		if err := WebhookAPIClient.Get(context.TODO(), namespacedKey(restore.Spec.BackupRef, restore.Namespace), backup); err != nil {
+			if !errors.IsNotFound(err) {
+				return err
+			}
+		} else {
+			supportErrs, err := validateBackupsSupported(backup.Spec.InstanceRef, backup.Namespace, specPath.Child("backupRef"))
+			if err != nil {
+				return err
+			}
+			errs = append(errs, supportErrs...)
+			if restore.Spec.TargetInstanceRef == nil && restore.Spec.NewInstance == nil &&
+				namespacedKey(backup.Spec.InstanceRef, backup.Namespace).Namespace != restore.Namespace {
+				errs = append(errs, field.Required(specPath.Child("targetInstanceRef"), "the backed up instance is not in the namespace of the restore, a target instance or a new instance must be specified"))
+			}
+		}
+	}
+	if len(errs) > 0 {
+		return errors.NewInvalid(GroupVersion.WithKind("DBaaSRestore").GroupKind(), restore.Name, errs)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var testDBaaSRestore = &DBaaSRestore{
+	ObjectMeta: metav1.ObjectMeta{
+		Name:      "test-restore",
+		Namespace: testNamespace,
+	},
+	Spec: DBaaSRestoreSpec{
+		BackupRef:   NamespacedName{Name: "test-backup"},
+		NewInstance: &DBaaSRestoreInstance{Name: "test-instance-restored"},
+	},
+}
+
+var _ = Describe("DBaaSRestore Webhook", func() {
+	Context("after creating DBaaSRestore into a new instance", func() {
+		BeforeEach(assertResourceCreation(testDBaaSRestore))
+		AfterEach(assertResourceDeletion(testDBaaSRestore))
+
+		It("should not allow updating the spec", func() {
+			updatedDBaaSRestore := &DBaaSRestore{}
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(testDBaaSRestore), updatedDBaaSRestore)).Should(Succeed())
+			updatedDBaaSRestore.Spec.BackupRef.Name = "other-backup"
+			err := k8sClient.Update(ctx, updatedDBaaSRestore)
+			Expect(err).Should(MatchError(ContainSubstring("the spec of a restore is immutable")))
+		})
+	})
+
+	Context("after trying to create an invalid DBaaSRestore", func() {
+		DescribeTable("checking invalid DBaaSRestore specs",
+			func(specFn func(*DBaaSRestoreSpec), expectedErr string) {
+				restore := testDBaaSRestore.DeepCopy()
+				restore.SetResourceVersion("")
+				specFn(&restore.Spec)
+				err := k8sClient.Create(ctx, restore)
+				Expect(err).Should(MatchError(ContainSubstring(expectedErr)))
+			},
+			Entry("not allow creating the DBaaSRestore without the backup",
+				func(spec *DBaaSRestoreSpec) {
+					spec.BackupRef.Name = ""
+				},
+				"the backup must be specified"),
+			Entry("not allow creating the DBaaSRestore with both a target and a new instance",
+				func(spec *DBaaSRestoreSpec) {
+					spec.TargetInstanceRef = &NamespacedName{Name: "test-instance"}
+				},
+				"both targetInstanceRef and newInstance are specified"),
+			Entry("not allow creating the DBaaSRestore with a target in another namespace",
+				func(spec *DBaaSRestoreSpec) {
+					spec.NewInstance = nil
+					spec.TargetInstanceRef = &NamespacedName{Name: "test-instance", Namespace: "other-namespace"}
+				},
+				"the target instance must be in the namespace of the restore"),
+		)
+	})
+})
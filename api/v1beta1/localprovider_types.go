@@ -0,0 +1,100 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Kinds implemented by the built-in local provider.
+const (
+	LocalInventoryKind  = "LocalInventory"
+	LocalConnectionKind = "LocalConnection"
+	LocalInstanceKind   = "LocalInstance"
+)
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// The schema for the inventory of the built-in local provider, backed by in-cluster PostgreSQL.
+// +operator-sdk:csv:customresourcedefinitions:displayName="LocalInventory"
+type LocalInventory struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInventorySpec   `json:"spec,omitempty"`
+	Status DBaaSInventoryStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of LocalInventories.
+type LocalInventoryList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LocalInventory `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// The schema for a connection of the built-in local provider.
+// +operator-sdk:csv:customresourcedefinitions:displayName="LocalConnection"
+type LocalConnection struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSConnectionSpec   `json:"spec,omitempty"`
+	Status DBaaSConnectionStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of LocalConnections.
+type LocalConnectionList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LocalConnection `json:"items"`
+}
+
+//+kubebuilder:object:root=true
+//+kubebuilder:subresource:status
+
+// The schema for an instance of the built-in local provider, provisioned as an in-cluster PostgreSQL StatefulSet.
+// +operator-sdk:csv:customresourcedefinitions:displayName="LocalInstance"
+type LocalInstance struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   DBaaSInstanceSpec   `json:"spec,omitempty"`
+	Status DBaaSInstanceStatus `json:"status,omitempty"`
+}
+
+//+kubebuilder:object:root=true
+
+// Contains a list of LocalInstances.
+type LocalInstanceList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []LocalInstance `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&LocalInventory{}, &LocalInventoryList{},
+		&LocalConnection{}, &LocalConnectionList{},
+		&LocalInstance{}, &LocalInstanceList{})
+}
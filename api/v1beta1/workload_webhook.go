@@ -0,0 +1,289 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	// ConnectionInjectionLabel selects the Deployments handled by the injection webhook. Its value names the DBaaSConnection,
+	// in the same namespace, injected into the pods of the Deployment.
+	ConnectionInjectionLabel = "dbaas.redhat.com/connection"
+
+	// InjectedConnectionAnnotation is set on the pod template of a Deployment to the name of the connection injected into it.
+	InjectedConnectionAnnotation = "dbaas.redhat.com/injected-connection"
+
+	// ConnectionInjectionModeAnnotation selects how the connection is injected, either as environment variables (env, the default),
+	// or as files in a volume mounted under the service binding root (volume).
+	ConnectionInjectionModeAnnotation = "dbaas.redhat.com/connection-mode"
+
+	// ConnectionEnvPrefixAnnotation sets a prefix for the names of the injected environment variables.
+	ConnectionEnvPrefixAnnotation = "dbaas.redhat.com/connection-env-prefix"
+
+	// ConnectionInjectionModeEnv injects the connection as environment variables.
+	ConnectionInjectionModeEnv = "env"
+	// ConnectionInjectionModeVolume injects the connection as files in a volume.
+	ConnectionInjectionModeVolume = "volume"
+
+	// ServiceBindingRoot is the directory where the connection volumes are mounted, as defined by the Service Binding specification.
+	ServiceBindingRoot = "/bindings"
+
+	serviceBindingRootEnvVar = "SERVICE_BINDING_ROOT"
+	injectedVolumePrefix     = "dbaas-binding-"
+	workloadWebhookPath      = "/mutate-apps-v1-deployment"
+
+	// injectedEnvSourcesAnnotation records, on the pod template, the config maps and secrets added as environment sources
+	injectedEnvSourcesAnnotation = "dbaas.redhat.com/injected-env-sources"
+	// injectedBindingRootAnnotation records, on the pod template, the containers SERVICE_BINDING_ROOT was added to
+	injectedBindingRootAnnotation = "dbaas.redhat.com/injected-binding-root"
+)
+
+// log is for logging in this package.
+var workloadlog = logf.Log.WithName("workload-resource")
+
+//+kubebuilder:webhook:path=/mutate-apps-v1-deployment,mutating=true,failurePolicy=ignore,sideEffects=None,groups=apps,resources=deployments,verbs=create;update,versions=v1,name=mdeployment.dbaas.redhat.com,admissionReviewVersions=v1beta1
+
+// WorkloadInjector injects the credentials and connection information of a DBaaSConnection
+// into the pods of the Deployments labeled with ConnectionInjectionLabel.
+// +kubebuilder:object:generate=false
+type WorkloadInjector struct {
+	decoder *admission.Decoder
+}
+
+var _ admission.Handler = &WorkloadInjector{}
+var _ admission.DecoderInjector = &WorkloadInjector{}
+
+// SetupWorkloadWebhookWithManager registers the workload injection webhook with the Manager.
+func SetupWorkloadWebhookWithManager(mgr ctrl.Manager) {
+	if WebhookAPIClient == nil {
+		WebhookAPIClient = mgr.GetClient()
+	}
+	mgr.GetWebhookServer().Register(workloadWebhookPath, &webhook.Admission{Handler: &WorkloadInjector{}})
+}
+
+// InjectDecoder implements admission.DecoderInjector.
+func (w *WorkloadInjector) InjectDecoder(d *admission.Decoder) error {
+	w.decoder = d
+	return nil
+}
+
+// Handle injects the labeled connection into the pod template of a Deployment, and removes the injected connection
+// from a Deployment no longer labeled, which is still sent to the webhook as the previous version of the Deployment matches.
+func (w *WorkloadInjector) Handle(ctx context.Context, req admission.Request) admission.Response {
+	deployment := &appsv1.Deployment{}
+	if err := w.decoder.Decode(req, deployment); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	connectionName := deployment.Labels[ConnectionInjectionLabel]
+	if len(connectionName) == 0 {
+		if _, ok := deployment.Spec.Template.Annotations[InjectedConnectionAnnotation]; !ok {
+			return admission.Allowed("no connection to inject")
+		}
+		workloadlog.Info("remove injected connection", "name", deployment.Name, "namespace", req.Namespace)
+		removeInjected(&deployment.Spec.Template)
+		return patchResponse(req, deployment)
+	}
+	workloadlog.Info("inject connection", "name", deployment.Name, "namespace", req.Namespace, "connection", connectionName)
+
+	mode := deployment.Annotations[ConnectionInjectionModeAnnotation]
+	if mode != "" && mode != ConnectionInjectionModeEnv && mode != ConnectionInjectionModeVolume {
+		return admission.Denied(fmt.Sprintf("invalid value %q for the %s annotation, expected %s or %s",
+			mode, ConnectionInjectionModeAnnotation, ConnectionInjectionModeEnv, ConnectionInjectionModeVolume))
+	}
+
+	namespace := deployment.Namespace
+	if len(namespace) == 0 {
+		namespace = req.Namespace
+	}
+	connection := &DBaaSConnection{}
+	if err := WebhookAPIClient.Get(ctx, types.NamespacedName{Name: connectionName, Namespace: namespace}, connection); err != nil {
+		if !errors.IsNotFound(err) {
+			return admission.Errored(http.StatusInternalServerError, err)
+		}
+		resp := admission.Allowed("connection not found")
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("DBaaSConnection %s not found, it is injected once it is ready", connectionName))
+		return resp
+	}
+	if connection.Status.CredentialsRef == nil || connection.Status.ConnectionInfoRef == nil {
+		resp := admission.Allowed("connection not ready")
+		resp.Warnings = append(resp.Warnings, fmt.Sprintf("DBaaSConnection %s is not ready for binding, it is injected once it is ready", connectionName))
+		return resp
+	}
+
+	template := &deployment.Spec.Template
+	removeInjected(template)
+	if mode == ConnectionInjectionModeVolume {
+		injectVolume(template, connection)
+	} else {
+		injectEnv(template, connection, deployment.Annotations[ConnectionEnvPrefixAnnotation])
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[InjectedConnectionAnnotation] = connection.Name
+	return patchResponse(req, deployment)
+}
+
+// patchResponse admits the Deployment with the changes made by the webhook
+func patchResponse(req admission.Request, deployment *appsv1.Deployment) admission.Response {
+	marshaled, err := json.Marshal(deployment)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, marshaled)
+}
+
+// injectEnv adds the connection secret and config map as environment sources of all containers, and records them in the pod template
+func injectEnv(template *corev1.PodTemplateSpec, connection *DBaaSConnection, prefix string) {
+	podSpec := &template.Spec
+	sources := []corev1.EnvFromSource{
+		{
+			Prefix:       prefix,
+			ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: *connection.Status.ConnectionInfoRef},
+		},
+		{
+			Prefix:    prefix,
+			SecretRef: &corev1.SecretEnvSource{LocalObjectReference: *connection.Status.CredentialsRef},
+		},
+	}
+	for i := range podSpec.Containers {
+		podSpec.Containers[i].EnvFrom = append(podSpec.Containers[i].EnvFrom, sources...)
+	}
+	if template.Annotations == nil {
+		template.Annotations = map[string]string{}
+	}
+	template.Annotations[injectedEnvSourcesAnnotation] = connection.Status.ConnectionInfoRef.Name + "," + connection.Status.CredentialsRef.Name
+}
+
+// injectVolume mounts the connection secret and config map in all containers, under the service binding root,
+// and records the containers it set the service binding root of in the pod template
+func injectVolume(template *corev1.PodTemplateSpec, connection *DBaaSConnection) {
+	podSpec := &template.Spec
+	volumeName := injectedVolumePrefix + connection.Name
+	podSpec.Volumes = append(podSpec.Volumes, corev1.Volume{
+		Name: volumeName,
+		VolumeSource: corev1.VolumeSource{
+			Projected: &corev1.ProjectedVolumeSource{
+				Sources: []corev1.VolumeProjection{
+					{ConfigMap: &corev1.ConfigMapProjection{LocalObjectReference: *connection.Status.ConnectionInfoRef}},
+					{Secret: &corev1.SecretProjection{LocalObjectReference: *connection.Status.CredentialsRef}},
+				},
+			},
+		},
+	})
+	var bindingRootContainers []string
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      volumeName,
+			MountPath: path.Join(ServiceBindingRoot, connection.Name),
+			ReadOnly:  true,
+		})
+		found := false
+		for _, env := range container.Env {
+			if env.Name == serviceBindingRootEnvVar {
+				found = true
+				break
+			}
+		}
+		if !found {
+			container.Env = append(container.Env, corev1.EnvVar{Name: serviceBindingRootEnvVar, Value: ServiceBindingRoot})
+			bindingRootContainers = append(bindingRootContainers, container.Name)
+		}
+	}
+	if len(bindingRootContainers) > 0 {
+		if template.Annotations == nil {
+			template.Annotations = map[string]string{}
+		}
+		template.Annotations[injectedBindingRootAnnotation] = strings.Join(bindingRootContainers, ",")
+	}
+}
+
+// removeInjected removes what previous injections added, for any connection, so an update does not inject a connection twice
+// and a changed connection or mode does not leave stale sources behind
+func removeInjected(template *corev1.PodTemplateSpec) {
+	podSpec := &template.Spec
+	volumes := podSpec.Volumes[:0]
+	for _, volume := range podSpec.Volumes {
+		if !strings.HasPrefix(volume.Name, injectedVolumePrefix) {
+			volumes = append(volumes, volume)
+		}
+	}
+	podSpec.Volumes = volumes
+
+	injectedSources := recordedNames(template, injectedEnvSourcesAnnotation)
+	bindingRootContainers := recordedNames(template, injectedBindingRootAnnotation)
+	for i := range podSpec.Containers {
+		container := &podSpec.Containers[i]
+		mounts := container.VolumeMounts[:0]
+		for _, mount := range container.VolumeMounts {
+			if !strings.HasPrefix(mount.Name, injectedVolumePrefix) {
+				mounts = append(mounts, mount)
+			}
+		}
+		container.VolumeMounts = mounts
+
+		sources := container.EnvFrom[:0]
+		for _, source := range container.EnvFrom {
+			if (source.SecretRef != nil && injectedSources[source.SecretRef.Name]) ||
+				(source.ConfigMapRef != nil && injectedSources[source.ConfigMapRef.Name]) {
+				continue
+			}
+			sources = append(sources, source)
+		}
+		container.EnvFrom = sources
+
+		if bindingRootContainers[container.Name] {
+			env := container.Env[:0]
+			for _, envVar := range container.Env {
+				if envVar.Name != serviceBindingRootEnvVar {
+					env = append(env, envVar)
+				}
+			}
+			container.Env = env
+		}
+	}
+	delete(template.Annotations, injectedEnvSourcesAnnotation)
+	delete(template.Annotations, injectedBindingRootAnnotation)
+	delete(template.Annotations, InjectedConnectionAnnotation)
+}
+
+// recordedNames returns the comma separated names recorded in an annotation of the pod template
+func recordedNames(template *corev1.PodTemplateSpec, annotation string) map[string]bool {
+	names := map[string]bool{}
+	if recorded := template.Annotations[annotation]; len(recorded) > 0 {
+		for _, name := range strings.Split(recorded, ",") {
+			names[name] = true
+		}
+	}
+	return names
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var _ = Describe("Workload injection webhook", func() {
+	injectedConnection := &DBaaSConnection{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-injected-connection",
+			Namespace: testNamespace,
+		},
+		Spec: DBaaSConnectionSpec{
+			InventoryRef: NamespacedName{
+				Name:      inventoryName,
+				Namespace: testNamespace,
+			},
+			DatabaseServiceID: databaseServiceID,
+		},
+	}
+	newDeployment := func(connectionName string, annotations map[string]string) *appsv1.Deployment {
+		var labels map[string]string
+		if len(connectionName) > 0 {
+			labels = map[string]string{ConnectionInjectionLabel: connectionName}
+		}
+		return &appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "test-workload",
+				Namespace:   testNamespace,
+				Labels:      labels,
+				Annotations: annotations,
+			},
+			Spec: appsv1.DeploymentSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "test-workload"}},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "test-workload"}},
+					Spec: corev1.PodSpec{
+						Containers: []corev1.Container{{Name: "app", Image: "test-image"}},
+					},
+				},
+			},
+		}
+	}
+
+	BeforeEach(assertResourceCreation(injectedConnection))
+	BeforeEach(func() {
+		By("publishing the connection status")
+		injectedConnection.Status.CredentialsRef = &corev1.LocalObjectReference{Name: "test-credentials"}
+		injectedConnection.Status.ConnectionInfoRef = &corev1.LocalObjectReference{Name: "test-configs"}
+		Expect(k8sClient.Status().Update(ctx, injectedConnection)).Should(Succeed())
+	})
+	AfterEach(assertResourceDeletion(injectedConnection))
+
+	Context("with a Deployment without label", func() {
+		deployment := newDeployment("", nil)
+		BeforeEach(assertResourceCreation(deployment))
+		AfterEach(assertResourceDeletion(deployment))
+		It("should not modify the Deployment", func() {
+			Expect(deployment.Spec.Template.Spec.Containers[0].EnvFrom).Should(BeEmpty())
+			Expect(deployment.Spec.Template.Spec.Volumes).Should(BeEmpty())
+		})
+	})
+
+	Context("with a Deployment labeled for env injection", func() {
+		deployment := newDeployment(injectedConnection.Name, map[string]string{
+			ConnectionEnvPrefixAnnotation: "DB_",
+		})
+		BeforeEach(assertResourceCreation(deployment))
+		AfterEach(assertResourceDeletion(deployment))
+		It("should inject the connection as environment variables", func() {
+			expected := []corev1.EnvFromSource{
+				{Prefix: "DB_", ConfigMapRef: &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-configs"}}},
+				{Prefix: "DB_", SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "test-credentials"}}},
+			}
+			Expect(deployment.Spec.Template.Spec.Containers[0].EnvFrom).Should(Equal(expected))
+			Expect(deployment.Spec.Template.Annotations).Should(HaveKeyWithValue(InjectedConnectionAnnotation, injectedConnection.Name))
+
+			By("updating the Deployment")
+			deployment.Spec.Template.Labels["updated"] = "true"
+			Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].EnvFrom).Should(Equal(expected))
+
+			By("switching the Deployment to volume injection")
+			deployment.Annotations[ConnectionInjectionModeAnnotation] = ConnectionInjectionModeVolume
+			Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+			Expect(deployment.Spec.Template.Spec.Containers[0].EnvFrom).Should(BeEmpty())
+			Expect(deployment.Spec.Template.Spec.Volumes).Should(HaveLen(1))
+		})
+	})
+
+	Context("with a Deployment labeled for volume injection", func() {
+		deployment := newDeployment(injectedConnection.Name, map[string]string{
+			ConnectionInjectionModeAnnotation: ConnectionInjectionModeVolume,
+		})
+		BeforeEach(assertResourceCreation(deployment))
+		AfterEach(assertResourceDeletion(deployment))
+		It("should mount the connection under the service binding root", func() {
+			podSpec := deployment.Spec.Template.Spec
+			Expect(podSpec.Volumes).Should(HaveLen(1))
+			Expect(podSpec.Volumes[0].Name).Should(Equal("dbaas-binding-" + injectedConnection.Name))
+			Expect(podSpec.Volumes[0].Projected).ShouldNot(BeNil())
+			Expect(podSpec.Volumes[0].Projected.Sources).Should(HaveLen(2))
+			Expect(podSpec.Containers[0].VolumeMounts).Should(ConsistOf(corev1.VolumeMount{
+				Name:      "dbaas-binding-" + injectedConnection.Name,
+				MountPath: "/bindings/" + injectedConnection.Name,
+				ReadOnly:  true,
+			}))
+			Expect(podSpec.Containers[0].Env).Should(ContainElement(corev1.EnvVar{Name: "SERVICE_BINDING_ROOT", Value: "/bindings"}))
+
+			By("removing the label of the Deployment")
+			delete(deployment.Labels, ConnectionInjectionLabel)
+			Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+			podSpec = deployment.Spec.Template.Spec
+			Expect(podSpec.Volumes).Should(BeEmpty())
+			Expect(podSpec.Containers[0].VolumeMounts).Should(BeEmpty())
+			Expect(podSpec.Containers[0].Env).Should(BeEmpty())
+			Expect(deployment.Spec.Template.Annotations).ShouldNot(HaveKey(InjectedConnectionAnnotation))
+		})
+	})
+
+	Context("with a Deployment setting its service binding root", func() {
+		deployment := newDeployment(injectedConnection.Name, map[string]string{
+			ConnectionInjectionModeAnnotation: ConnectionInjectionModeVolume,
+		})
+		deployment.Spec.Template.Spec.Containers[0].Env = []corev1.EnvVar{{Name: "SERVICE_BINDING_ROOT", Value: "/custom"}}
+		BeforeEach(assertResourceCreation(deployment))
+		AfterEach(assertResourceDeletion(deployment))
+		It("should keep its service binding root", func() {
+			Expect(deployment.Spec.Template.Spec.Containers[0].Env).Should(Equal([]corev1.EnvVar{{Name: "SERVICE_BINDING_ROOT", Value: "/custom"}}))
+
+			By("switching the Deployment to env injection")
+			deployment.Annotations[ConnectionInjectionModeAnnotation] = ConnectionInjectionModeEnv
+			Expect(k8sClient.Update(ctx, deployment)).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(deployment), deployment)).Should(Succeed())
+			Expect(deployment.Spec.Template.Spec.Volumes).Should(BeEmpty())
+			Expect(deployment.Spec.Template.Spec.Containers[0].Env).Should(Equal([]corev1.EnvVar{{Name: "SERVICE_BINDING_ROOT", Value: "/custom"}}))
+		})
+	})
+
+	Context("with a Deployment labeled with an unknown connection", func() {
+		deployment := newDeployment("unknown-connection", nil)
+		BeforeEach(assertResourceCreation(deployment))
+		AfterEach(assertResourceDeletion(deployment))
+		It("should admit the Deployment without injecting it", func() {
+			Expect(deployment.Spec.Template.Spec.Containers[0].EnvFrom).Should(BeEmpty())
+			Expect(deployment.Spec.Template.Annotations).ShouldNot(HaveKey(InjectedConnectionAnnotation))
+		})
+	})
+})
@@ -22,8 +22,8 @@ limitations under the License.
 package v1beta1
 
 import (
-	v1 "k8s.io/api/core/v1"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -68,7 +68,37 @@ func (in *CredentialField) DeepCopy() *CredentialField {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
+func (in *DBaaSAccessModeRule) DeepCopyInto(out *DBaaSAccessModeRule) {
+	*out = *in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.NsSelector != nil {
+		in, out := &in.NsSelector, &out.NsSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AllowedAccessModes != nil {
+		in, out := &in.AllowedAccessModes, &out.AllowedAccessModes
+		*out = make([]ConnectionAccessMode, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSAccessModeRule.
+func (in *DBaaSAccessModeRule) DeepCopy() *DBaaSAccessModeRule {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSAccessModeRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackup) DeepCopyInto(out *DBaaSBackup) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -76,18 +106,18 @@ func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnection.
-func (in *DBaaSConnection) DeepCopy() *DBaaSConnection {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackup.
+func (in *DBaaSBackup) DeepCopy() *DBaaSBackup {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnection)
+	out := new(DBaaSBackup)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSConnection) DeepCopyObject() runtime.Object {
+func (in *DBaaSBackup) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -95,31 +125,31 @@ func (in *DBaaSConnection) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionList) DeepCopyInto(out *DBaaSConnectionList) {
+func (in *DBaaSBackupList) DeepCopyInto(out *DBaaSBackupList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSConnection, len(*in))
+		*out = make([]DBaaSBackup, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionList.
-func (in *DBaaSConnectionList) DeepCopy() *DBaaSConnectionList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupList.
+func (in *DBaaSBackupList) DeepCopy() *DBaaSBackupList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionList)
+	out := new(DBaaSBackupList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSConnectionList) DeepCopyObject() runtime.Object {
+func (in *DBaaSBackupList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -127,94 +157,170 @@ func (in *DBaaSConnectionList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionPolicy) DeepCopyInto(out *DBaaSConnectionPolicy) {
+func (in *DBaaSBackupSchedule) DeepCopyInto(out *DBaaSBackupSchedule) {
 	*out = *in
-	if in.Namespaces != nil {
-		in, out := &in.Namespaces, &out.Namespaces
-		*out = new([]string)
-		if **in != nil {
-			in, out := *in, *out
-			*out = make([]string, len(*in))
-			copy(*out, *in)
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupSchedule.
+func (in *DBaaSBackupSchedule) DeepCopy() *DBaaSBackupSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSBackupSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSBackupSchedule) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackupScheduleList) DeepCopyInto(out *DBaaSBackupScheduleList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSBackupSchedule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.NsSelector != nil {
-		in, out := &in.NsSelector, &out.NsSelector
-		*out = new(metav1.LabelSelector)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupScheduleList.
+func (in *DBaaSBackupScheduleList) DeepCopy() *DBaaSBackupScheduleList {
+	if in == nil {
+		return nil
 	}
+	out := new(DBaaSBackupScheduleList)
+	in.DeepCopyInto(out)
+	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionPolicy.
-func (in *DBaaSConnectionPolicy) DeepCopy() *DBaaSConnectionPolicy {
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSBackupScheduleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackupScheduleSpec) DeepCopyInto(out *DBaaSBackupScheduleSpec) {
+	*out = *in
+	in.BackupTemplate.DeepCopyInto(&out.BackupTemplate)
+	out.Interval = in.Interval
+	if in.BackupsHistoryLimit != nil {
+		in, out := &in.BackupsHistoryLimit, &out.BackupsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupScheduleSpec.
+func (in *DBaaSBackupScheduleSpec) DeepCopy() *DBaaSBackupScheduleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionPolicy)
+	out := new(DBaaSBackupScheduleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionSpec) DeepCopyInto(out *DBaaSConnectionSpec) {
+func (in *DBaaSBackupScheduleStatus) DeepCopyInto(out *DBaaSBackupScheduleStatus) {
 	*out = *in
-	out.InventoryRef = in.InventoryRef
-	if in.DatabaseServiceRef != nil {
-		in, out := &in.DatabaseServiceRef, &out.DatabaseServiceRef
-		*out = new(NamespacedName)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastBackupRef != nil {
+		in, out := &in.LastBackupRef, &out.LastBackupRef
+		*out = new(corev1.LocalObjectReference)
 		**out = **in
 	}
-	if in.DatabaseServiceType != nil {
-		in, out := &in.DatabaseServiceType, &out.DatabaseServiceType
-		*out = new(DatabaseServiceType)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupScheduleStatus.
+func (in *DBaaSBackupScheduleStatus) DeepCopy() *DBaaSBackupScheduleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSBackupScheduleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSBackupSpec) DeepCopyInto(out *DBaaSBackupSpec) {
+	*out = *in
+	out.InstanceRef = in.InstanceRef
+	if in.Retention != nil {
+		in, out := &in.Retention, &out.Retention
+		*out = new(v1.Duration)
 		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionSpec.
-func (in *DBaaSConnectionSpec) DeepCopy() *DBaaSConnectionSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupSpec.
+func (in *DBaaSBackupSpec) DeepCopy() *DBaaSBackupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionSpec)
+	out := new(DBaaSBackupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSConnectionStatus) DeepCopyInto(out *DBaaSConnectionStatus) {
+func (in *DBaaSBackupStatus) DeepCopyInto(out *DBaaSBackupStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
 	}
-	if in.ConnectionInfoRef != nil {
-		in, out := &in.ConnectionInfoRef, &out.ConnectionInfoRef
-		*out = new(v1.LocalObjectReference)
-		**out = **in
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionStatus.
-func (in *DBaaSConnectionStatus) DeepCopy() *DBaaSConnectionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSBackupStatus.
+func (in *DBaaSBackupStatus) DeepCopy() *DBaaSBackupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSConnectionStatus)
+	out := new(DBaaSBackupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstance) DeepCopyInto(out *DBaaSInstance) {
+func (in *DBaaSClusterPolicy) DeepCopyInto(out *DBaaSClusterPolicy) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -222,18 +328,18 @@ func (in *DBaaSInstance) DeepCopyInto(out *DBaaSInstance) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstance.
-func (in *DBaaSInstance) DeepCopy() *DBaaSInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSClusterPolicy.
+func (in *DBaaSClusterPolicy) DeepCopy() *DBaaSClusterPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstance)
+	out := new(DBaaSClusterPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
+func (in *DBaaSClusterPolicy) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -241,31 +347,31 @@ func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstanceList) DeepCopyInto(out *DBaaSInstanceList) {
+func (in *DBaaSClusterPolicyList) DeepCopyInto(out *DBaaSClusterPolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSInstance, len(*in))
+		*out = make([]DBaaSClusterPolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceList.
-func (in *DBaaSInstanceList) DeepCopy() *DBaaSInstanceList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSClusterPolicyList.
+func (in *DBaaSClusterPolicyList) DeepCopy() *DBaaSClusterPolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstanceList)
+	out := new(DBaaSClusterPolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInstanceList) DeepCopyObject() runtime.Object {
+func (in *DBaaSClusterPolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -273,59 +379,29 @@ func (in *DBaaSInstanceList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstanceSpec) DeepCopyInto(out *DBaaSInstanceSpec) {
-	*out = *in
-	out.InventoryRef = in.InventoryRef
-	if in.ProvisioningParameters != nil {
-		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
-		*out = make(map[ProvisioningParameterType]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceSpec.
-func (in *DBaaSInstanceSpec) DeepCopy() *DBaaSInstanceSpec {
-	if in == nil {
-		return nil
-	}
-	out := new(DBaaSInstanceSpec)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInstanceStatus) DeepCopyInto(out *DBaaSInstanceStatus) {
+func (in *DBaaSClusterPolicyStatus) DeepCopyInto(out *DBaaSClusterPolicyStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.InstanceInfo != nil {
-		in, out := &in.InstanceInfo, &out.InstanceInfo
-		*out = make(map[string]string, len(*in))
-		for key, val := range *in {
-			(*out)[key] = val
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceStatus.
-func (in *DBaaSInstanceStatus) DeepCopy() *DBaaSInstanceStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSClusterPolicyStatus.
+func (in *DBaaSClusterPolicyStatus) DeepCopy() *DBaaSClusterPolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInstanceStatus)
+	out := new(DBaaSClusterPolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventory) DeepCopyInto(out *DBaaSInventory) {
+func (in *DBaaSConnection) DeepCopyInto(out *DBaaSConnection) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -333,18 +409,18 @@ func (in *DBaaSInventory) DeepCopyInto(out *DBaaSInventory) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventory.
-func (in *DBaaSInventory) DeepCopy() *DBaaSInventory {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnection.
+func (in *DBaaSConnection) DeepCopy() *DBaaSConnection {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInventory)
+	out := new(DBaaSConnection)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInventory) DeepCopyObject() runtime.Object {
+func (in *DBaaSConnection) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -352,31 +428,31 @@ func (in *DBaaSInventory) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventoryList) DeepCopyInto(out *DBaaSInventoryList) {
+func (in *DBaaSConnectionList) DeepCopyInto(out *DBaaSConnectionList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSInventory, len(*in))
+		*out = make([]DBaaSConnection, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryList.
-func (in *DBaaSInventoryList) DeepCopy() *DBaaSInventoryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionList.
+func (in *DBaaSConnectionList) DeepCopy() *DBaaSConnectionList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInventoryList)
+	out := new(DBaaSConnectionList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSInventoryList) DeepCopyObject() runtime.Object {
+func (in *DBaaSConnectionList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -384,99 +460,115 @@ func (in *DBaaSInventoryList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventoryPolicy) DeepCopyInto(out *DBaaSInventoryPolicy) {
+func (in *DBaaSConnectionPolicy) DeepCopyInto(out *DBaaSConnectionPolicy) {
 	*out = *in
-	if in.DisableProvisions != nil {
-		in, out := &in.DisableProvisions, &out.DisableProvisions
-		*out = new(bool)
-		**out = **in
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = new([]string)
+		if **in != nil {
+			in, out := *in, *out
+			*out = make([]string, len(*in))
+			copy(*out, *in)
+		}
+	}
+	if in.NsSelector != nil {
+		in, out := &in.NsSelector, &out.NsSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]DBaaSAccessModeRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
-	in.Connections.DeepCopyInto(&out.Connections)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryPolicy.
-func (in *DBaaSInventoryPolicy) DeepCopy() *DBaaSInventoryPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionPolicy.
+func (in *DBaaSConnectionPolicy) DeepCopy() *DBaaSConnectionPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInventoryPolicy)
+	out := new(DBaaSConnectionPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventorySpec) DeepCopyInto(out *DBaaSInventorySpec) {
+func (in *DBaaSConnectionSpec) DeepCopyInto(out *DBaaSConnectionSpec) {
 	*out = *in
-	if in.CredentialsRef != nil {
-		in, out := &in.CredentialsRef, &out.CredentialsRef
-		*out = new(LocalObjectReference)
+	out.InventoryRef = in.InventoryRef
+	if in.DatabaseServiceRef != nil {
+		in, out := &in.DatabaseServiceRef, &out.DatabaseServiceRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.DatabaseServiceType != nil {
+		in, out := &in.DatabaseServiceType, &out.DatabaseServiceType
+		*out = new(DatabaseServiceType)
+		**out = **in
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+	if in.TTL != nil {
+		in, out := &in.TTL, &out.TTL
+		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventorySpec.
-func (in *DBaaSInventorySpec) DeepCopy() *DBaaSInventorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionSpec.
+func (in *DBaaSConnectionSpec) DeepCopy() *DBaaSConnectionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSInventorySpec)
+	out := new(DBaaSConnectionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSInventoryStatus) DeepCopyInto(out *DBaaSInventoryStatus) {
+func (in *DBaaSConnectionStatus) DeepCopyInto(out *DBaaSConnectionStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.DatabaseServices != nil {
-		in, out := &in.DatabaseServices, &out.DatabaseServices
-		*out = make([]DatabaseService, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryStatus.
-func (in *DBaaSInventoryStatus) DeepCopy() *DBaaSInventoryStatus {
-	if in == nil {
-		return nil
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
-	out := new(DBaaSInventoryStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSOperatorInventorySpec) DeepCopyInto(out *DBaaSOperatorInventorySpec) {
-	*out = *in
-	out.ProviderRef = in.ProviderRef
-	in.DBaaSInventorySpec.DeepCopyInto(&out.DBaaSInventorySpec)
-	if in.Policy != nil {
-		in, out := &in.Policy, &out.Policy
-		*out = new(DBaaSInventoryPolicy)
-		(*in).DeepCopyInto(*out)
+	if in.ConnectionInfoRef != nil {
+		in, out := &in.ConnectionInfoRef, &out.ConnectionInfoRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorInventorySpec.
-func (in *DBaaSOperatorInventorySpec) DeepCopy() *DBaaSOperatorInventorySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSConnectionStatus.
+func (in *DBaaSConnectionStatus) DeepCopy() *DBaaSConnectionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSOperatorInventorySpec)
+	out := new(DBaaSConnectionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPlatform) DeepCopyInto(out *DBaaSPlatform) {
+func (in *DBaaSDatabase) DeepCopyInto(out *DBaaSDatabase) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -484,18 +576,18 @@ func (in *DBaaSPlatform) DeepCopyInto(out *DBaaSPlatform) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatform.
-func (in *DBaaSPlatform) DeepCopy() *DBaaSPlatform {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDatabase.
+func (in *DBaaSDatabase) DeepCopy() *DBaaSDatabase {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPlatform)
+	out := new(DBaaSDatabase)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSPlatform) DeepCopyObject() runtime.Object {
+func (in *DBaaSDatabase) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -503,31 +595,31 @@ func (in *DBaaSPlatform) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPlatformList) DeepCopyInto(out *DBaaSPlatformList) {
+func (in *DBaaSDatabaseList) DeepCopyInto(out *DBaaSDatabaseList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSPlatform, len(*in))
+		*out = make([]DBaaSDatabase, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformList.
-func (in *DBaaSPlatformList) DeepCopy() *DBaaSPlatformList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDatabaseList.
+func (in *DBaaSDatabaseList) DeepCopy() *DBaaSDatabaseList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPlatformList)
+	out := new(DBaaSDatabaseList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSPlatformList) DeepCopyObject() runtime.Object {
+func (in *DBaaSDatabaseList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -535,54 +627,66 @@ func (in *DBaaSPlatformList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPlatformSpec) DeepCopyInto(out *DBaaSPlatformSpec) {
+func (in *DBaaSDatabaseSpec) DeepCopyInto(out *DBaaSDatabaseSpec) {
 	*out = *in
-	if in.SyncPeriod != nil {
-		in, out := &in.SyncPeriod, &out.SyncPeriod
-		*out = new(int)
-		**out = **in
+	out.DatabaseServiceRef = in.DatabaseServiceRef
+	if in.Databases != nil {
+		in, out := &in.Databases, &out.Databases
+		*out = make([]LogicalDatabase, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]DatabaseRole, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformSpec.
-func (in *DBaaSPlatformSpec) DeepCopy() *DBaaSPlatformSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDatabaseSpec.
+func (in *DBaaSDatabaseSpec) DeepCopy() *DBaaSDatabaseSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPlatformSpec)
+	out := new(DBaaSDatabaseSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPlatformStatus) DeepCopyInto(out *DBaaSPlatformStatus) {
+func (in *DBaaSDatabaseStatus) DeepCopyInto(out *DBaaSDatabaseStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.PlatformsStatus != nil {
-		in, out := &in.PlatformsStatus, &out.PlatformsStatus
-		*out = make([]PlatformStatus, len(*in))
-		copy(*out, *in)
+	if in.Roles != nil {
+		in, out := &in.Roles, &out.Roles
+		*out = make([]DatabaseRoleStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformStatus.
-func (in *DBaaSPlatformStatus) DeepCopy() *DBaaSPlatformStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSDatabaseStatus.
+func (in *DBaaSDatabaseStatus) DeepCopy() *DBaaSDatabaseStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPlatformStatus)
+	out := new(DBaaSDatabaseStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPolicy) DeepCopyInto(out *DBaaSPolicy) {
+func (in *DBaaSInstance) DeepCopyInto(out *DBaaSInstance) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -590,18 +694,18 @@ func (in *DBaaSPolicy) DeepCopyInto(out *DBaaSPolicy) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicy.
-func (in *DBaaSPolicy) DeepCopy() *DBaaSPolicy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstance.
+func (in *DBaaSInstance) DeepCopy() *DBaaSInstance {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPolicy)
+	out := new(DBaaSInstance)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSPolicy) DeepCopyObject() runtime.Object {
+func (in *DBaaSInstance) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -609,31 +713,31 @@ func (in *DBaaSPolicy) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPolicyList) DeepCopyInto(out *DBaaSPolicyList) {
+func (in *DBaaSInstanceList) DeepCopyInto(out *DBaaSInstanceList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSPolicy, len(*in))
+		*out = make([]DBaaSInstance, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyList.
-func (in *DBaaSPolicyList) DeepCopy() *DBaaSPolicyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceList.
+func (in *DBaaSInstanceList) DeepCopy() *DBaaSInstanceList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPolicyList)
+	out := new(DBaaSInstanceList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSPolicyList) DeepCopyObject() runtime.Object {
+func (in *DBaaSInstanceList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -641,45 +745,93 @@ func (in *DBaaSPolicyList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPolicySpec) DeepCopyInto(out *DBaaSPolicySpec) {
+func (in *DBaaSInstanceSource) DeepCopyInto(out *DBaaSInstanceSource) {
 	*out = *in
-	in.DBaaSInventoryPolicy.DeepCopyInto(&out.DBaaSInventoryPolicy)
+	if in.InstanceRef != nil {
+		in, out := &in.InstanceRef, &out.InstanceRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.BackupRef != nil {
+		in, out := &in.BackupRef, &out.BackupRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.PointInTime != nil {
+		in, out := &in.PointInTime, &out.PointInTime
+		*out = (*in).DeepCopy()
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicySpec.
-func (in *DBaaSPolicySpec) DeepCopy() *DBaaSPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceSource.
+func (in *DBaaSInstanceSource) DeepCopy() *DBaaSInstanceSource {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPolicySpec)
+	out := new(DBaaSInstanceSource)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSPolicyStatus) DeepCopyInto(out *DBaaSPolicyStatus) {
+func (in *DBaaSInstanceSpec) DeepCopyInto(out *DBaaSInstanceSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+	if in.ProvisioningParameters != nil {
+		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
+		*out = make(map[ProvisioningParameterType]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(DBaaSInstanceSource)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceSpec.
+func (in *DBaaSInstanceSpec) DeepCopy() *DBaaSInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInstanceStatus) DeepCopyInto(out *DBaaSInstanceStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.InstanceInfo != nil {
+		in, out := &in.InstanceInfo, &out.InstanceInfo
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyStatus.
-func (in *DBaaSPolicyStatus) DeepCopy() *DBaaSPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInstanceStatus.
+func (in *DBaaSInstanceStatus) DeepCopy() *DBaaSInstanceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSPolicyStatus)
+	out := new(DBaaSInstanceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProvider) DeepCopyInto(out *DBaaSProvider) {
+func (in *DBaaSInventory) DeepCopyInto(out *DBaaSInventory) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
@@ -687,18 +839,18 @@ func (in *DBaaSProvider) DeepCopyInto(out *DBaaSProvider) {
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProvider.
-func (in *DBaaSProvider) DeepCopy() *DBaaSProvider {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventory.
+func (in *DBaaSInventory) DeepCopy() *DBaaSInventory {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProvider)
+	out := new(DBaaSInventory)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSProvider) DeepCopyObject() runtime.Object {
+func (in *DBaaSInventory) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -706,85 +858,58 @@ func (in *DBaaSProvider) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderConnection) DeepCopyInto(out *DBaaSProviderConnection) {
-	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderConnection.
-func (in *DBaaSProviderConnection) DeepCopy() *DBaaSProviderConnection {
-	if in == nil {
-		return nil
-	}
-	out := new(DBaaSProviderConnection)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderInstance) DeepCopyInto(out *DBaaSProviderInstance) {
+func (in *DBaaSInventoryGrant) DeepCopyInto(out *DBaaSInventoryGrant) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInstance.
-func (in *DBaaSProviderInstance) DeepCopy() *DBaaSProviderInstance {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryGrant.
+func (in *DBaaSInventoryGrant) DeepCopy() *DBaaSInventoryGrant {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderInstance)
+	out := new(DBaaSInventoryGrant)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderInventory) DeepCopyInto(out *DBaaSProviderInventory) {
-	*out = *in
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInventory.
-func (in *DBaaSProviderInventory) DeepCopy() *DBaaSProviderInventory {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryGrant) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(DBaaSProviderInventory)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderList) DeepCopyInto(out *DBaaSProviderList) {
+func (in *DBaaSInventoryGrantList) DeepCopyInto(out *DBaaSInventoryGrantList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]DBaaSProvider, len(*in))
+		*out = make([]DBaaSInventoryGrant, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderList.
-func (in *DBaaSProviderList) DeepCopy() *DBaaSProviderList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryGrantList.
+func (in *DBaaSInventoryGrantList) DeepCopy() *DBaaSInventoryGrantList {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderList)
+	out := new(DBaaSInventoryGrantList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
+func (in *DBaaSInventoryGrantList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -792,77 +917,1133 @@ func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
+func (in *DBaaSInventoryGrantSpec) DeepCopyInto(out *DBaaSInventoryGrantSpec) {
 	*out = *in
-	out.Provider = in.Provider
-	if in.CredentialFields != nil {
-		in, out := &in.CredentialFields, &out.CredentialFields
-		*out = make([]CredentialField, len(*in))
+	out.InventoryRef = in.InventoryRef
+	if in.ServiceIDs != nil {
+		in, out := &in.ServiceIDs, &out.ServiceIDs
+		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
-	if in.ProvisioningParameters != nil {
-		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
-		*out = make(map[ProvisioningParameterType]ProvisioningParameter, len(*in))
-		for key, val := range *in {
-			(*out)[key] = *val.DeepCopy()
-		}
+	if in.Namespaces != nil {
+		in, out := &in.Namespaces, &out.Namespaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ServiceAccounts != nil {
+		in, out := &in.ServiceAccounts, &out.ServiceAccounts
+		*out = make([]NamespacedName, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderSpec.
-func (in *DBaaSProviderSpec) DeepCopy() *DBaaSProviderSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryGrantSpec.
+func (in *DBaaSInventoryGrantSpec) DeepCopy() *DBaaSInventoryGrantSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderSpec)
+	out := new(DBaaSInventoryGrantSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DBaaSProviderStatus) DeepCopyInto(out *DBaaSProviderStatus) {
+func (in *DBaaSInventoryGrantStatus) DeepCopyInto(out *DBaaSInventoryGrantStatus) {
 	*out = *in
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
-		*out = make([]metav1.Condition, len(*in))
+		*out = make([]v1.Condition, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderStatus.
-func (in *DBaaSProviderStatus) DeepCopy() *DBaaSProviderStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryGrantStatus.
+func (in *DBaaSInventoryGrantStatus) DeepCopy() *DBaaSInventoryGrantStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(DBaaSProviderStatus)
+	out := new(DBaaSInventoryGrantStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatabaseProviderInfo) DeepCopyInto(out *DatabaseProviderInfo) {
+func (in *DBaaSInventoryList) DeepCopyInto(out *DBaaSInventoryList) {
 	*out = *in
-	out.Icon = in.Icon
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseProviderInfo.
-func (in *DatabaseProviderInfo) DeepCopy() *DatabaseProviderInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryList.
+func (in *DBaaSInventoryList) DeepCopy() *DBaaSInventoryList {
 	if in == nil {
 		return nil
 	}
-	out := new(DatabaseProviderInfo)
+	out := new(DBaaSInventoryList)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *DatabaseService) DeepCopyInto(out *DatabaseService) {
-	*out = *in
-	if in.ServiceType != nil {
-		in, out := &in.ServiceType, &out.ServiceType
-		*out = new(DatabaseServiceType)
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryPolicy) DeepCopyInto(out *DBaaSInventoryPolicy) {
+	*out = *in
+	if in.DisableProvisions != nil {
+		in, out := &in.DisableProvisions, &out.DisableProvisions
+		*out = new(bool)
+		**out = **in
+	}
+	in.Connections.DeepCopyInto(&out.Connections)
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(DBaaSProvisioningPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryPolicy.
+func (in *DBaaSInventoryPolicy) DeepCopy() *DBaaSInventoryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventorySpec) DeepCopyInto(out *DBaaSInventorySpec) {
+	*out = *in
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventorySpec.
+func (in *DBaaSInventorySpec) DeepCopy() *DBaaSInventorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSInventoryStatus) DeepCopyInto(out *DBaaSInventoryStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DatabaseServices != nil {
+		in, out := &in.DatabaseServices, &out.DatabaseServices
+		*out = make([]DatabaseService, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSInventoryStatus.
+func (in *DBaaSInventoryStatus) DeepCopy() *DBaaSInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSOperatorConnectionStatus) DeepCopyInto(out *DBaaSOperatorConnectionStatus) {
+	*out = *in
+	in.DBaaSConnectionStatus.DeepCopyInto(&out.DBaaSConnectionStatus)
+	if in.Binding != nil {
+		in, out := &in.Binding, &out.Binding
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.LastRolloutRestart != nil {
+		in, out := &in.LastRolloutRestart, &out.LastRolloutRestart
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorConnectionStatus.
+func (in *DBaaSOperatorConnectionStatus) DeepCopy() *DBaaSOperatorConnectionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSOperatorConnectionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSOperatorInventorySpec) DeepCopyInto(out *DBaaSOperatorInventorySpec) {
+	*out = *in
+	out.ProviderRef = in.ProviderRef
+	in.DBaaSInventorySpec.DeepCopyInto(&out.DBaaSInventorySpec)
+	if in.Policy != nil {
+		in, out := &in.Policy, &out.Policy
+		*out = new(DBaaSInventoryPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorInventorySpec.
+func (in *DBaaSOperatorInventorySpec) DeepCopy() *DBaaSOperatorInventorySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSOperatorInventorySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSOperatorInventoryStatus) DeepCopyInto(out *DBaaSOperatorInventoryStatus) {
+	*out = *in
+	in.DBaaSInventoryStatus.DeepCopyInto(&out.DBaaSInventoryStatus)
+	if in.CredentialsLastRotated != nil {
+		in, out := &in.CredentialsLastRotated, &out.CredentialsLastRotated
+		*out = (*in).DeepCopy()
+	}
+	if in.EffectivePolicyRef != nil {
+		in, out := &in.EffectivePolicyRef, &out.EffectivePolicyRef
+		*out = new(LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSOperatorInventoryStatus.
+func (in *DBaaSOperatorInventoryStatus) DeepCopy() *DBaaSOperatorInventoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSOperatorInventoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatform) DeepCopyInto(out *DBaaSPlatform) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatform.
+func (in *DBaaSPlatform) DeepCopy() *DBaaSPlatform {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatform)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSPlatform) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformList) DeepCopyInto(out *DBaaSPlatformList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSPlatform, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformList.
+func (in *DBaaSPlatformList) DeepCopy() *DBaaSPlatformList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSPlatformList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformSpec) DeepCopyInto(out *DBaaSPlatformSpec) {
+	*out = *in
+	if in.SyncPeriod != nil {
+		in, out := &in.SyncPeriod, &out.SyncPeriod
+		*out = new(int)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformSpec.
+func (in *DBaaSPlatformSpec) DeepCopy() *DBaaSPlatformSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPlatformStatus) DeepCopyInto(out *DBaaSPlatformStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PlatformsStatus != nil {
+		in, out := &in.PlatformsStatus, &out.PlatformsStatus
+		*out = make([]PlatformStatus, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPlatformStatus.
+func (in *DBaaSPlatformStatus) DeepCopy() *DBaaSPlatformStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPlatformStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicy) DeepCopyInto(out *DBaaSPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicy.
+func (in *DBaaSPolicy) DeepCopy() *DBaaSPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicyList) DeepCopyInto(out *DBaaSPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyList.
+func (in *DBaaSPolicyList) DeepCopy() *DBaaSPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicySpec) DeepCopyInto(out *DBaaSPolicySpec) {
+	*out = *in
+	in.DBaaSInventoryPolicy.DeepCopyInto(&out.DBaaSInventoryPolicy)
+	if in.Quotas != nil {
+		in, out := &in.Quotas, &out.Quotas
+		*out = new(DBaaSQuotaPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicySpec.
+func (in *DBaaSPolicySpec) DeepCopy() *DBaaSPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSPolicyStatus) DeepCopyInto(out *DBaaSPolicyStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.QuotaUsage != nil {
+		in, out := &in.QuotaUsage, &out.QuotaUsage
+		*out = make([]DBaaSQuotaUsage, len(*in))
+		copy(*out, *in)
+	}
+	if in.EffectivePolicy != nil {
+		in, out := &in.EffectivePolicy, &out.EffectivePolicy
+		*out = new(DBaaSPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSPolicyStatus.
+func (in *DBaaSPolicyStatus) DeepCopy() *DBaaSPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProvider) DeepCopyInto(out *DBaaSProvider) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProvider.
+func (in *DBaaSProvider) DeepCopy() *DBaaSProvider {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProvider)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSProvider) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderBackup) DeepCopyInto(out *DBaaSProviderBackup) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderBackup.
+func (in *DBaaSProviderBackup) DeepCopy() *DBaaSProviderBackup {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderBackup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderBackupSpec) DeepCopyInto(out *DBaaSProviderBackupSpec) {
+	*out = *in
+	in.DBaaSBackupSpec.DeepCopyInto(&out.DBaaSBackupSpec)
+	out.InventoryRef = in.InventoryRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderBackupSpec.
+func (in *DBaaSProviderBackupSpec) DeepCopy() *DBaaSProviderBackupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderBackupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderCapabilities) DeepCopyInto(out *DBaaSProviderCapabilities) {
+	*out = *in
+	if in.Provisioning != nil {
+		in, out := &in.Provisioning, &out.Provisioning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Updates != nil {
+		in, out := &in.Updates, &out.Updates
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Deletion != nil {
+		in, out := &in.Deletion, &out.Deletion
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Backups != nil {
+		in, out := &in.Backups, &out.Backups
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Cloning != nil {
+		in, out := &in.Cloning, &out.Cloning
+		*out = new(bool)
+		**out = **in
+	}
+	if in.AccessModes != nil {
+		in, out := &in.AccessModes, &out.AccessModes
+		*out = make([]ConnectionAccessMode, len(*in))
+		copy(*out, *in)
+	}
+	if in.TLS != nil {
+		in, out := &in.TLS, &out.TLS
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PrivateNetworking != nil {
+		in, out := &in.PrivateNetworking, &out.PrivateNetworking
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DatabaseServiceTypes != nil {
+		in, out := &in.DatabaseServiceTypes, &out.DatabaseServiceTypes
+		*out = make([]DatabaseServiceType, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderCapabilities.
+func (in *DBaaSProviderCapabilities) DeepCopy() *DBaaSProviderCapabilities {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderCapabilities)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderConnection) DeepCopyInto(out *DBaaSProviderConnection) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderConnection.
+func (in *DBaaSProviderConnection) DeepCopy() *DBaaSProviderConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderDatabase) DeepCopyInto(out *DBaaSProviderDatabase) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderDatabase.
+func (in *DBaaSProviderDatabase) DeepCopy() *DBaaSProviderDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderDatabaseSpec) DeepCopyInto(out *DBaaSProviderDatabaseSpec) {
+	*out = *in
+	in.DBaaSDatabaseSpec.DeepCopyInto(&out.DBaaSDatabaseSpec)
+	out.InventoryRef = in.InventoryRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderDatabaseSpec.
+func (in *DBaaSProviderDatabaseSpec) DeepCopy() *DBaaSProviderDatabaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderDatabaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderInstance) DeepCopyInto(out *DBaaSProviderInstance) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInstance.
+func (in *DBaaSProviderInstance) DeepCopy() *DBaaSProviderInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderInstanceSpec) DeepCopyInto(out *DBaaSProviderInstanceSpec) {
+	*out = *in
+	in.DBaaSInstanceSpec.DeepCopyInto(&out.DBaaSInstanceSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInstanceSpec.
+func (in *DBaaSProviderInstanceSpec) DeepCopy() *DBaaSProviderInstanceSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderInstanceSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderInventory) DeepCopyInto(out *DBaaSProviderInventory) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderInventory.
+func (in *DBaaSProviderInventory) DeepCopy() *DBaaSProviderInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderList) DeepCopyInto(out *DBaaSProviderList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSProvider, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderList.
+func (in *DBaaSProviderList) DeepCopy() *DBaaSProviderList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSProviderList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderRestore) DeepCopyInto(out *DBaaSProviderRestore) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderRestore.
+func (in *DBaaSProviderRestore) DeepCopy() *DBaaSProviderRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderRestoreSpec) DeepCopyInto(out *DBaaSProviderRestoreSpec) {
+	*out = *in
+	out.InventoryRef = in.InventoryRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderRestoreSpec.
+func (in *DBaaSProviderRestoreSpec) DeepCopy() *DBaaSProviderRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderSpec) DeepCopyInto(out *DBaaSProviderSpec) {
+	*out = *in
+	out.Provider = in.Provider
+	if in.CredentialFields != nil {
+		in, out := &in.CredentialFields, &out.CredentialFields
+		*out = make([]CredentialField, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProvisioningParameters != nil {
+		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
+		*out = make(map[ProvisioningParameterType]ProvisioningParameter, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(DBaaSProviderCapabilities)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderSpec.
+func (in *DBaaSProviderSpec) DeepCopy() *DBaaSProviderSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProviderStatus) DeepCopyInto(out *DBaaSProviderStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = new(DBaaSProviderCapabilities)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProviderStatus.
+func (in *DBaaSProviderStatus) DeepCopy() *DBaaSProviderStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProviderStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSProvisioningPolicy) DeepCopyInto(out *DBaaSProvisioningPolicy) {
+	*out = *in
+	if in.AllowedPlans != nil {
+		in, out := &in.AllowedPlans, &out.AllowedPlans
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedCloudProviders != nil {
+		in, out := &in.AllowedCloudProviders, &out.AllowedCloudProviders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.AllowedRegions != nil {
+		in, out := &in.AllowedRegions, &out.AllowedRegions
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MaxNodes != nil {
+		in, out := &in.MaxNodes, &out.MaxNodes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxStorageGib != nil {
+		in, out := &in.MaxStorageGib, &out.MaxStorageGib
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxSpendLimit != nil {
+		in, out := &in.MaxSpendLimit, &out.MaxSpendLimit
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSProvisioningPolicy.
+func (in *DBaaSProvisioningPolicy) DeepCopy() *DBaaSProvisioningPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSProvisioningPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSQuotaPolicy) DeepCopyInto(out *DBaaSQuotaPolicy) {
+	*out = *in
+	if in.MaxInstancesPerNamespace != nil {
+		in, out := &in.MaxInstancesPerNamespace, &out.MaxInstancesPerNamespace
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxInstancesPerInventory != nil {
+		in, out := &in.MaxInstancesPerInventory, &out.MaxInstancesPerInventory
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxInstancesPerProvider != nil {
+		in, out := &in.MaxInstancesPerProvider, &out.MaxInstancesPerProvider
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxConnectionsPerInstance != nil {
+		in, out := &in.MaxConnectionsPerInstance, &out.MaxConnectionsPerInstance
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSQuotaPolicy.
+func (in *DBaaSQuotaPolicy) DeepCopy() *DBaaSQuotaPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSQuotaPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSQuotaUsage) DeepCopyInto(out *DBaaSQuotaUsage) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSQuotaUsage.
+func (in *DBaaSQuotaUsage) DeepCopy() *DBaaSQuotaUsage {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSQuotaUsage)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestore) DeepCopyInto(out *DBaaSRestore) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestore.
+func (in *DBaaSRestore) DeepCopy() *DBaaSRestore {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestore)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSRestore) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreInstance) DeepCopyInto(out *DBaaSRestoreInstance) {
+	*out = *in
+	if in.ProvisioningParameters != nil {
+		in, out := &in.ProvisioningParameters, &out.ProvisioningParameters
+		*out = make(map[ProvisioningParameterType]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreInstance.
+func (in *DBaaSRestoreInstance) DeepCopy() *DBaaSRestoreInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreList) DeepCopyInto(out *DBaaSRestoreList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]DBaaSRestore, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreList.
+func (in *DBaaSRestoreList) DeepCopy() *DBaaSRestoreList {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *DBaaSRestoreList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreSpec) DeepCopyInto(out *DBaaSRestoreSpec) {
+	*out = *in
+	out.BackupRef = in.BackupRef
+	if in.TargetInstanceRef != nil {
+		in, out := &in.TargetInstanceRef, &out.TargetInstanceRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.NewInstance != nil {
+		in, out := &in.NewInstance, &out.NewInstance
+		*out = new(DBaaSRestoreInstance)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreSpec.
+func (in *DBaaSRestoreSpec) DeepCopy() *DBaaSRestoreSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DBaaSRestoreStatus) DeepCopyInto(out *DBaaSRestoreStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.TargetInstanceRef != nil {
+		in, out := &in.TargetInstanceRef, &out.TargetInstanceRef
+		*out = new(NamespacedName)
+		**out = **in
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DBaaSRestoreStatus.
+func (in *DBaaSRestoreStatus) DeepCopy() *DBaaSRestoreStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DBaaSRestoreStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseGrant) DeepCopyInto(out *DatabaseGrant) {
+	*out = *in
+	if in.Privileges != nil {
+		in, out := &in.Privileges, &out.Privileges
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseGrant.
+func (in *DatabaseGrant) DeepCopy() *DatabaseGrant {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseGrant)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseProviderInfo) DeepCopyInto(out *DatabaseProviderInfo) {
+	*out = *in
+	out.Icon = in.Icon
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseProviderInfo.
+func (in *DatabaseProviderInfo) DeepCopy() *DatabaseProviderInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseProviderInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRole) DeepCopyInto(out *DatabaseRole) {
+	*out = *in
+	if in.Grants != nil {
+		in, out := &in.Grants, &out.Grants
+		*out = make([]DatabaseGrant, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRole.
+func (in *DatabaseRole) DeepCopy() *DatabaseRole {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRole)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseRoleStatus) DeepCopyInto(out *DatabaseRoleStatus) {
+	*out = *in
+	if in.CredentialsRef != nil {
+		in, out := &in.CredentialsRef, &out.CredentialsRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DatabaseRoleStatus.
+func (in *DatabaseRoleStatus) DeepCopy() *DatabaseRoleStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(DatabaseRoleStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DatabaseService) DeepCopyInto(out *DatabaseService) {
+	*out = *in
+	if in.ServiceType != nil {
+		in, out := &in.ServiceType, &out.ServiceType
+		*out = new(DatabaseServiceType)
 		**out = **in
 	}
 	if in.ServiceInfo != nil {
@@ -921,6 +2102,183 @@ func (in *Instance) DeepCopy() *Instance {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConnection) DeepCopyInto(out *LocalConnection) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConnection.
+func (in *LocalConnection) DeepCopy() *LocalConnection {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConnection)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalConnection) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalConnectionList) DeepCopyInto(out *LocalConnectionList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalConnection, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalConnectionList.
+func (in *LocalConnectionList) DeepCopy() *LocalConnectionList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalConnectionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalConnectionList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInstance) DeepCopyInto(out *LocalInstance) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInstance.
+func (in *LocalInstance) DeepCopy() *LocalInstance {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInstance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInstance) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInstanceList) DeepCopyInto(out *LocalInstanceList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalInstance, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInstanceList.
+func (in *LocalInstanceList) DeepCopy() *LocalInstanceList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInstanceList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInstanceList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInventory) DeepCopyInto(out *LocalInventory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInventory.
+func (in *LocalInventory) DeepCopy() *LocalInventory {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInventory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInventory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LocalInventoryList) DeepCopyInto(out *LocalInventoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LocalInventory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LocalInventoryList.
+func (in *LocalInventoryList) DeepCopy() *LocalInventoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(LocalInventoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LocalInventoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LocalObjectReference) DeepCopyInto(out *LocalObjectReference) {
 	*out = *in
@@ -936,6 +2294,26 @@ func (in *LocalObjectReference) DeepCopy() *LocalObjectReference {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogicalDatabase) DeepCopyInto(out *LogicalDatabase) {
+	*out = *in
+	if in.Schemas != nil {
+		in, out := &in.Schemas, &out.Schemas
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogicalDatabase.
+func (in *LogicalDatabase) DeepCopy() *LogicalDatabase {
+	if in == nil {
+		return nil
+	}
+	out := new(LogicalDatabase)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NamespacedName) DeepCopyInto(out *NamespacedName) {
 	*out = *in
@@ -986,7 +2364,7 @@ func (in *PlatformConfig) DeepCopyInto(out *PlatformConfig) {
 	*out = *in
 	if in.Envs != nil {
 		in, out := &in.Envs, &out.Envs
-		*out = make([]v1.EnvVar, len(*in))
+		*out = make([]corev1.EnvVar, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
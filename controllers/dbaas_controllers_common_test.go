@@ -43,6 +43,7 @@ import (
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/pkg/providertest/contract"
 )
 
 const (
@@ -269,7 +270,7 @@ func assertProviderResourceCreated(object client.Object, groupVersion schema.Gro
 				providerInstance := &v1beta1.DBaaSProviderInstance{}
 				err := json.Unmarshal(bytes, providerInstance)
 				Expect(err).NotTo(HaveOccurred())
-				Expect(&providerInstance.Spec).Should(Equal(DBaaSResourceSpec))
+				Expect(&providerInstance.Spec.DBaaSInstanceSpec).Should(Equal(DBaaSResourceSpec))
 				Expect(len(providerInstance.GetOwnerReferences())).Should(Equal(1))
 				Expect(providerInstance.GetOwnerReferences()[0].Name).Should(Equal(object.GetName()))
 			default:
@@ -294,16 +295,31 @@ func assertDBaaSResourceStatusUpdated(object client.Object, status metav1.Condit
 			}
 			switch v := object.(type) {
 			case *v1beta1.DBaaSInventory:
-				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSInventoryReadyType)
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSInventoryReadyType)
 				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
 			case *v1beta1.DBaaSConnection:
-				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSConnectionReadyType)
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSConnectionReadyType)
 				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
 			case *v1beta1.DBaaSInstance:
-				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSInstanceReadyType)
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSInstanceReadyType)
 				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
 			case *v1beta1.DBaaSPolicy:
-				dbaasConds, _ := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSPolicyReadyType)
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSPolicyReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSInventoryGrant:
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSInventoryGrantReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSDatabase:
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSDatabaseReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSBackup:
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSBackupReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSBackupSchedule:
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSBackupScheduleReadyType)
+				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
+			case *v1beta1.DBaaSRestore:
+				dbaasConds, _ := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSRestoreReadyType)
 				return len(dbaasConds) > 0 && dbaasConds[0].Status == status && dbaasConds[0].Reason == reason, nil
 			default:
 				Fail("invalid test object")
@@ -360,15 +376,15 @@ func assertDBaaSResourceProviderStatusUpdated(object client.Object, groupVersion
 			}
 			switch v := object.(type) {
 			case *v1beta1.DBaaSInventory:
-				_, conds := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSInventoryReadyType)
+				_, conds := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSInventoryReadyType)
 				return len(conds), nil
 			case *v1beta1.DBaaSConnection:
 				assertInventoryDBaaSStatus(v.Spec.InventoryRef.Name, v.Spec.InventoryRef.Namespace, resourceDBaaSStatus)()
-				_, conds := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSConnectionReadyType)
+				_, conds := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSConnectionReadyType)
 				return len(conds), nil
 			case *v1beta1.DBaaSInstance:
 				assertInventoryDBaaSStatus(v.Spec.InventoryRef.Name, v.Spec.InventoryRef.Namespace, resourceDBaaSStatus)()
-				_, conds := splitStatusConditions(v.Status.Conditions, v1beta1.DBaaSInstanceReadyType)
+				_, conds := contract.SplitConditions(v.Status.Conditions, v1beta1.DBaaSInstanceReadyType)
 				return len(conds), nil
 			default:
 				Fail("invalid test object")
@@ -388,17 +404,6 @@ func assertDBaaSResourceProviderStatusUpdated(object client.Object, groupVersion
 	}
 }
 
-func splitStatusConditions(conds []metav1.Condition, condType string) (dbaasCond []metav1.Condition, providerCond []metav1.Condition) {
-	for _, v := range conds {
-		if v.Type != condType { //skip the DBaaS operator specific condition
-			providerCond = append(providerCond, v)
-		} else {
-			dbaasCond = append(dbaasCond, v)
-		}
-	}
-	return
-}
-
 func assertInventoryDBaaSStatus(name, namespace string, dbaasStatus metav1.ConditionStatus) func() {
 	return func() {
 		updatedInv := &v1beta1.DBaaSInventory{}
@@ -452,11 +457,12 @@ func assertInstanceDBaaSStatus(name, namespace string, dbaasStatus metav1.Condit
 
 func assertInventoryStatus(inv *v1beta1.DBaaSInventory, condType string, dbaasStatus metav1.ConditionStatus, providerResourceStatus interface{}) func() {
 	return func() {
-		status := inv.Status.DeepCopy()
-		dbaasConds, providerConds := splitStatusConditions(status.Conditions, condType)
+		status := inv.Status.DBaaSInventoryStatus.DeepCopy()
+		dbaasConds, providerConds := contract.SplitConditions(status.Conditions, condType)
 		Expect(len(dbaasConds)).Should(Equal(1))
 		Expect(dbaasConds[0].Type).Should(Equal(condType))
 		Expect(dbaasConds[0].Status).Should(Equal(dbaasStatus))
+		_, providerConds = contract.SplitConditions(providerConds, v1beta1.DBaaSInventoryCredentialsType)
 		status.Conditions = providerConds
 		var providerStatus interface{}
 		switch providerResourceStatus.(type) {
@@ -476,10 +482,11 @@ func assertInventoryStatus(inv *v1beta1.DBaaSInventory, condType string, dbaasSt
 func assertInventoryStatusV1alpha1(inv *v1alpha1.DBaaSInventory, condType string, dbaasStatus metav1.ConditionStatus, providerResourceStatus interface{}) func() {
 	return func() {
 		status := inv.Status.DeepCopy()
-		dbaasConds, providerConds := splitStatusConditions(status.Conditions, condType)
+		dbaasConds, providerConds := contract.SplitConditions(status.Conditions, condType)
 		Expect(len(dbaasConds)).Should(Equal(1))
 		Expect(dbaasConds[0].Type).Should(Equal(condType))
 		Expect(dbaasConds[0].Status).Should(Equal(dbaasStatus))
+		_, providerConds = contract.SplitConditions(providerConds, v1beta1.DBaaSInventoryCredentialsType)
 		status.Conditions = providerConds
 		Expect(status).Should(Equal(providerResourceStatus))
 	}
@@ -487,8 +494,8 @@ func assertInventoryStatusV1alpha1(inv *v1alpha1.DBaaSInventory, condType string
 func assertConnectionStatus(conn *v1beta1.DBaaSConnection, condType string, providerResourceStatus interface{}) func() {
 	return func() {
 		assertConnectionDBaaSStatus(conn.Name, conn.Namespace, metav1.ConditionTrue)()
-		status := conn.Status.DeepCopy()
-		_, providerConds := splitStatusConditions(status.Conditions, condType)
+		status := conn.Status.DBaaSConnectionStatus.DeepCopy()
+		_, providerConds := contract.SplitConditions(status.Conditions, condType)
 		status.Conditions = providerConds
 		var providerStatus interface{}
 		switch providerResourceStatus.(type) {
@@ -509,7 +516,7 @@ func assertInstanceStatus(conn *v1beta1.DBaaSInstance, condType string, provider
 	return func() {
 		assertInstanceDBaaSStatus(conn.Name, conn.Namespace, metav1.ConditionTrue)()
 		status := conn.Status.DeepCopy()
-		_, providerConds := splitStatusConditions(status.Conditions, condType)
+		_, providerConds := contract.SplitConditions(status.Conditions, condType)
 		status.Conditions = providerConds
 		var providerStatus interface{}
 		switch providerResourceStatus.(type) {
@@ -530,7 +537,7 @@ func assertInstanceStatusV1alpha1(conn *v1alpha1.DBaaSInstance, condType string,
 	return func() {
 		assertInstanceDBaaSStatus(conn.Name, conn.Namespace, metav1.ConditionTrue)()
 		status := conn.Status.DeepCopy()
-		_, providerConds := splitStatusConditions(status.Conditions, condType)
+		_, providerConds := contract.SplitConditions(status.Conditions, condType)
 		status.Conditions = providerConds
 		Expect(status).Should(Equal(providerResourceStatus))
 	}
@@ -594,7 +601,7 @@ func assertProviderResourceSpecUpdated(object client.Object, groupVersion schema
 					providerInstance := &v1beta1.DBaaSProviderInstance{}
 					err := json.Unmarshal(bytes, providerInstance)
 					Expect(err).NotTo(HaveOccurred())
-					return reflect.DeepEqual(&providerInstance.Spec, DBaaSResourceSpec)
+					return reflect.DeepEqual(&providerInstance.Spec.DBaaSInstanceSpec, DBaaSResourceSpec)
 				}
 				providerInstance := &v1alpha1.DBaaSProviderInstance{}
 				err := json.Unmarshal(bytes, providerInstance)
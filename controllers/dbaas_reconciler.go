@@ -2,11 +2,15 @@ package controllers
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
@@ -22,10 +26,13 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
@@ -73,6 +80,32 @@ func (r *DBaaSReconciler) watchDBaaSProviderObject(ctrl controller.Controller, o
 	return nil
 }
 
+// isCredentialsSecret returns true for a secret labeled as inventory credentials by checkCredsRefLabel
+func isCredentialsSecret(obj client.Object) bool {
+	return obj.GetLabels()[v1beta1.TypeLabelKey] == v1beta1.TypeLabelValue ||
+		obj.GetLabels()[v1beta1.TypeLabelKeyMongo] == v1beta1.TypeLabelValue
+}
+
+// dataHash returns a fingerprint of the data of secrets or config maps, which only changes when a key or a value changes.
+func dataHash(data ...map[string][]byte) string {
+	hash := sha256.New()
+	for _, d := range data {
+		keys := make([]string, 0, len(d))
+		for key := range d {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			hash.Write([]byte(key))
+			hash.Write([]byte{0})
+			hash.Write(d[key])
+			hash.Write([]byte{0})
+		}
+		hash.Write([]byte{1})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
 func (r *DBaaSReconciler) createProviderObject(object client.Object, groupVersion schema.GroupVersion, providerObjectKind string) *unstructured.Unstructured {
 	var providerObject unstructured.Unstructured
 	providerObject.SetGroupVersionKind(schema.GroupVersionKind{
@@ -88,6 +121,15 @@ func (r *DBaaSReconciler) createProviderObject(object client.Object, groupVersio
 func (r *DBaaSReconciler) providerObjectMutateFn(object client.Object, providerObject *unstructured.Unstructured, spec interface{}) controllerutil.MutateFn {
 	return func() error {
 		providerObject.UnstructuredContent()["spec"] = spec
+		if inventory, ok := object.(*v1beta1.DBaaSInventory); ok && len(inventory.Status.CredentialsHash) > 0 {
+			// a credentials change triggers a re-sync of the provider object
+			annotations := providerObject.GetAnnotations()
+			if annotations == nil {
+				annotations = map[string]string{}
+			}
+			annotations[v1beta1.CredentialsHashAnnotation] = inventory.Status.CredentialsHash
+			providerObject.SetAnnotations(annotations)
+		}
 		providerObject.SetOwnerReferences(nil)
 		return ctrl.SetControllerReference(object, providerObject, r.Scheme)
 	}
@@ -113,39 +155,98 @@ func (r *DBaaSReconciler) policyListByNS(ctx context.Context, namespace string)
 	return policyListByNS, nil
 }
 
-// check if namespace is a valid connection namespace
-func (r *DBaaSReconciler) isValidConnectionNS(ctx context.Context, namespace string, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) (bool, error) {
+// getClusterPolicy returns the cluster policy, nil if there is none
+func (r *DBaaSReconciler) getClusterPolicy(ctx context.Context) (*v1beta1.DBaaSClusterPolicy, error) {
+	clusterPolicy := &v1beta1.DBaaSClusterPolicy{}
+	if err := r.Get(ctx, types.NamespacedName{Name: v1beta1.ClusterPolicyName}, clusterPolicy); err != nil {
+		if errors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return clusterPolicy, nil
+}
+
+// check if namespace is a valid connection namespace, for both the policy and the cluster policy.
+// A connection can also be allowed by a grant of the inventory, the cluster policy still applies to it.
+func (r *DBaaSReconciler) isValidConnectionNS(ctx context.Context, namespace string, inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy,
+	clusterPolicy *v1beta1.DBaaSClusterPolicy, connection *v1beta1.DBaaSConnection) (bool, error) {
 	// valid if in same namespace as inventory
 	if namespace == inventory.Namespace {
 		return true, nil
 	}
-	var validNamespaces []string
-	var validNsSelector *metav1.LabelSelector
+	var connections v1beta1.DBaaSConnectionPolicy
 	if inventory.Spec.Policy != nil {
-		if inventory.Spec.Policy.Connections.Namespaces != nil {
-			validNamespaces = *inventory.Spec.Policy.Connections.Namespaces
+		connections = inventory.Spec.Policy.Connections
+	} else if activePolicy != nil {
+		connections = activePolicy.Spec.Connections
+	}
+	valid, err := r.isAllowedConnectionNS(ctx, namespace, connections)
+	if err != nil {
+		return false, err
+	}
+	if !valid && connection != nil {
+		if valid, err = r.isGrantedConnection(ctx, connection, inventory); err != nil {
+			return false, err
 		}
-		if inventory.Spec.Policy.Connections.NsSelector != nil {
-			validNsSelector = inventory.Spec.Policy.Connections.NsSelector
+	}
+	if !valid {
+		return false, nil
+	}
+	// the cluster policy only narrows the namespaces when it sets some
+	if clusterPolicy == nil || (clusterPolicy.Spec.Connections.Namespaces == nil && clusterPolicy.Spec.Connections.NsSelector == nil) {
+		return true, nil
+	}
+	return r.isAllowedConnectionNS(ctx, namespace, clusterPolicy.Spec.Connections)
+}
+
+// check if a connection is allowed by a grant of the inventory for its database service
+func (r *DBaaSReconciler) isGrantedConnection(ctx context.Context, connection *v1beta1.DBaaSConnection, inventory *v1beta1.DBaaSInventory) (bool, error) {
+	serviceID := connection.Spec.DatabaseServiceID
+	if ref := connection.Spec.DatabaseServiceRef; ref != nil && len(ref.Name) > 0 {
+		ns := ref.Namespace
+		if len(ns) == 0 {
+			ns = connection.Namespace
 		}
-	} else {
-		if activePolicy != nil {
-			if activePolicy.Spec.Connections.Namespaces != nil {
-				validNamespaces = *activePolicy.Spec.Connections.Namespaces
-			}
-			if activePolicy.Spec.Connections.NsSelector != nil {
-				validNsSelector = activePolicy.Spec.Connections.NsSelector
+		instance := &v1beta1.DBaaSInstance{}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: ns, Name: ref.Name}, instance); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
 			}
+			return false, err
+		}
+		serviceID = instance.Status.InstanceID
+	}
+	if len(serviceID) == 0 {
+		return false, nil
+	}
+	var grantList v1beta1.DBaaSInventoryGrantList
+	if err := r.List(ctx, &grantList, client.InNamespace(inventory.Namespace)); err != nil {
+		return false, err
+	}
+	now := time.Now()
+	for i := range grantList.Items {
+		if grantList.Items[i].Allows(connection, serviceID, now) {
+			return true, nil
 		}
 	}
+	return false, nil
+}
+
+// check if namespace is allowed by the namespaces or the namespace selector of a connection policy
+func (r *DBaaSReconciler) isAllowedConnectionNS(ctx context.Context, namespace string, connections v1beta1.DBaaSConnectionPolicy) (bool, error) {
+	var validNamespaces []string
+	if connections.Namespaces != nil {
+		validNamespaces = *connections.Namespaces
+	}
 
 	// valid if all namespaces are supported via wildcard
 	if contains(validNamespaces, "*") || contains(validNamespaces, namespace) {
 		return true, nil
 	}
 
-	if validNsSelector != nil {
-		selector, err := metav1.LabelSelectorAsSelector(validNsSelector)
+	if connections.NsSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(connections.NsSelector)
 		if err != nil {
 			return false, err
 		}
@@ -160,22 +261,34 @@ func (r *DBaaSReconciler) isValidConnectionNS(ctx context.Context, namespace str
 	return contains(validNamespaces, namespace), nil
 }
 
-// check if provisioning is allowed against an inventory. inventory takes precedence over dbaaspolicy.
-func canProvision(inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy) bool {
+// check if provisioning is allowed against an inventory. inventory takes precedence over dbaaspolicy, the cluster policy can disable it for all.
+// The provisioning parameters must also comply with the provisioning guardrails, the returned message explains a refusal.
+func canProvision(inventory *v1beta1.DBaaSInventory, activePolicy *v1beta1.DBaaSPolicy, clusterPolicy *v1beta1.DBaaSClusterPolicy,
+	params map[v1beta1.ProvisioningParameterType]string) (bool, string) {
 	if activePolicy == nil {
 		// not an active namespace
-		return false
+		return false, v1beta1.MsgInventoryNotProvisionable
 	}
+	disabled := false
 	if inventory.Spec.Policy != nil {
 		if inventory.Spec.Policy.DisableProvisions != nil {
-			return !*inventory.Spec.Policy.DisableProvisions
+			disabled = *inventory.Spec.Policy.DisableProvisions
 		}
 	} else {
 		if activePolicy.Spec.DisableProvisions != nil {
-			return !*activePolicy.Spec.DisableProvisions
+			disabled = *activePolicy.Spec.DisableProvisions
 		}
 	}
-	return true
+	if clusterPolicy != nil && clusterPolicy.Spec.DisableProvisions != nil && *clusterPolicy.Spec.DisableProvisions {
+		disabled = true
+	}
+	if disabled {
+		return false, v1beta1.MsgInventoryNotProvisionable
+	}
+	if errs := v1beta1.EffectiveProvisioningPolicy(inventory, activePolicy, clusterPolicy).ValidateProvisioningParameters(params); len(errs) > 0 {
+		return false, fmt.Sprintf("%s: %s", v1beta1.MsgInventoryNotProvisionable, errs.ToAggregate())
+	}
+	return true, ""
 }
 
 func (r *DBaaSReconciler) reconcileProviderResource(ctx context.Context, providerName string, DBaaSObject client.Object,
@@ -279,9 +392,18 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1bet
 		return
 	}
 	activePolicy := getActivePolicy(policyList)
-	provision = canProvision(inventory, activePolicy)
+	clusterPolicy, err := r.getClusterPolicy(ctx)
+	if err != nil {
+		return
+	}
+	var params map[v1beta1.ProvisioningParameterType]string
+	if instance, ok := DBaaSObject.(*v1beta1.DBaaSInstance); ok {
+		params = instance.Spec.ProvisioningParameters
+	}
+	provision, provisionMsg := canProvision(inventory, activePolicy, clusterPolicy, params)
 
-	validNS, err = r.isValidConnectionNS(ctx, DBaaSObject.GetNamespace(), inventory, activePolicy)
+	connection, _ := DBaaSObject.(*v1beta1.DBaaSConnection)
+	validNS, err = r.isValidConnectionNS(ctx, DBaaSObject.GetNamespace(), inventory, activePolicy, clusterPolicy, connection)
 	if err != nil {
 		return
 	}
@@ -294,9 +416,9 @@ func (r *DBaaSReconciler) checkInventory(ctx context.Context, inventoryRef v1bet
 			statusErrorFn(v1beta1.DBaaSInventoryNotReady, v1beta1.MsgInventoryNotReady)
 		} else if !provision &&
 			reflect.TypeOf(DBaaSObject) == reflect.TypeOf(&v1beta1.DBaaSInstance{}) {
-			err = fmt.Errorf("inventory %v provisioning is disabled", inventoryRef)
-			logger.Error(err, "Inventory provisioning is disabled", "Inventory", inventory.Name, "Namespace", inventory.Namespace)
-			statusErrorFn(v1beta1.DBaaSInventoryNotProvisionable, v1beta1.MsgInventoryNotProvisionable)
+			err = fmt.Errorf("inventory %v provisioning is not allowed", inventoryRef)
+			logger.Error(err, "Inventory provisioning is not allowed", "Inventory", inventory.Name, "Namespace", inventory.Namespace, "Reason", provisionMsg)
+			statusErrorFn(v1beta1.DBaaSInventoryNotProvisionable, provisionMsg)
 		} else {
 			return
 		}
@@ -330,7 +452,8 @@ func (r *DBaaSReconciler) checkCredsRefLabel(ctx context.Context, inventory v1be
 			Name:      inventory.Spec.CredentialsRef.Name,
 			Namespace: inventory.Namespace,
 		}, &secret); err != nil {
-			return err
+			// a missing secret is reported by the credentials check
+			return client.IgnoreNotFound(err)
 		}
 
 		secretPatch := corev1.Secret{ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{}}}
@@ -380,6 +503,50 @@ func GetInstallNamespace() (string, error) {
 	return ns, nil
 }
 
+// refKey returns the key of an object reference, in the given namespace if the reference does not set one
+func refKey(ref v1beta1.NamespacedName, namespace string) types.NamespacedName {
+	if len(ref.Namespace) == 0 {
+		return types.NamespacedName{Namespace: namespace, Name: ref.Name}
+	}
+	return types.NamespacedName{Namespace: ref.Namespace, Name: ref.Name}
+}
+
+// getInstance returns the instance of an object reference, in the given namespace if the reference does not set one
+func (r *DBaaSReconciler) getInstance(ctx context.Context, ref v1beta1.NamespacedName, namespace string) (*v1beta1.DBaaSInstance, error) {
+	instance := &v1beta1.DBaaSInstance{}
+	if err := r.Get(ctx, refKey(ref, namespace), instance); err != nil {
+		return nil, err
+	}
+	return instance, nil
+}
+
+// instanceInventories maps the instances to the inventories they reference
+func (r *DBaaSReconciler) instanceInventories(ctx context.Context) (map[types.NamespacedName]v1beta1.NamespacedName, error) {
+	var instanceList v1beta1.DBaaSInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, err
+	}
+	inventories := make(map[types.NamespacedName]v1beta1.NamespacedName, len(instanceList.Items))
+	for i := range instanceList.Items {
+		inventories[client.ObjectKeyFromObject(&instanceList.Items[i])] = instanceList.Items[i].Spec.InventoryRef
+	}
+	return inventories, nil
+}
+
+// updateStatusCondition sets a condition of a DBaaS object and updates its status.
+// A conflict is not an error, the modified object is reconciled again.
+func (r *DBaaSReconciler) updateStatusCondition(ctx context.Context, DBaaSObject client.Object, conditions *[]metav1.Condition, condition metav1.Condition) error {
+	apimeta.SetStatusCondition(conditions, condition)
+	if err := r.Client.Status().Update(ctx, DBaaSObject); err != nil {
+		if errors.IsConflict(err) {
+			ctrl.LoggerFrom(ctx).V(1).Info("DBaaS object modified, retry syncing status", "DBaaS Object", client.ObjectKeyFromObject(DBaaSObject))
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
 // checks if a string is present in a slice
 func contains(s []string, str string) bool {
 	for _, v := range s {
@@ -389,3 +556,57 @@ func contains(s []string, str string) bool {
 	}
 	return false
 }
+
+// inventoryUser is a DBaaS object referencing an inventory, whose namespace must be a valid connection namespace for the inventory
+type inventoryUser struct {
+	key       types.NamespacedName
+	inventory v1beta1.NamespacedName
+}
+
+// inventoryUsersFn lists the DBaaS objects of a kind with the inventories they reference
+type inventoryUsersFn func(ctx context.Context) ([]inventoryUser, error)
+
+// watchConnectionNamespaces re-evaluates the namespace of the DBaaS objects referencing an inventory whenever it may have become,
+// or may no longer be, a valid connection namespace: when the labels of a namespace, a policy, the cluster policy, an inventory,
+// or a grant of an inventory change. The status of a grant changes when it expires.
+func (r *DBaaSReconciler) watchConnectionNamespaces(bldr *builder.Builder, usersFn inventoryUsersFn) *builder.Builder {
+	return bldr.
+		Watches(&source.Kind{Type: &corev1.Namespace{}}, handler.EnqueueRequestsFromMapFunc(inventoryUsersMapFn(usersFn,
+			func(namespace client.Object, user inventoryUser) bool {
+				return user.key.Namespace == namespace.GetName()
+			})), builder.WithPredicates(predicate.LabelChangedPredicate{}), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(inventoryUsersMapFn(usersFn,
+			func(policy client.Object, user inventoryUser) bool {
+				return user.inventory.Namespace == policy.GetNamespace()
+			}))).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSClusterPolicy{}}, handler.EnqueueRequestsFromMapFunc(inventoryUsersMapFn(usersFn,
+			func(_ client.Object, _ inventoryUser) bool {
+				return true
+			}))).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInventory{}}, handler.EnqueueRequestsFromMapFunc(inventoryUsersMapFn(usersFn,
+			func(inventory client.Object, user inventoryUser) bool {
+				return user.inventory.Name == inventory.GetName() && user.inventory.Namespace == inventory.GetNamespace()
+			})), builder.WithPredicates(predicate.GenerationChangedPredicate{})).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInventoryGrant{}}, handler.EnqueueRequestsFromMapFunc(inventoryUsersMapFn(usersFn,
+			func(grant client.Object, user inventoryUser) bool {
+				return user.inventory.Name == grant.(*v1beta1.DBaaSInventoryGrant).Spec.InventoryRef.Name && user.inventory.Namespace == grant.GetNamespace()
+			})))
+}
+
+// inventoryUsersMapFn maps an object to the DBaaS objects it affects
+func inventoryUsersMapFn(usersFn inventoryUsersFn, affects func(client.Object, inventoryUser) bool) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		users, err := usersFn(context.Background())
+		if err != nil {
+			ctrl.Log.WithName("DBaaSReconciler").Error(err, "Error listing the DBaaS objects referencing an inventory", "Object", objectKeyFromObject(obj))
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, user := range users {
+			if affects(obj, user) {
+				requests = append(requests, reconcile.Request{NamespacedName: user.key})
+			}
+		}
+		return requests
+	}
+}
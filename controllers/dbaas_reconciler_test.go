@@ -17,6 +17,7 @@ limitations under the License.
 package controllers
 
 import (
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -33,8 +34,10 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 )
 
 var testSecret = corev1.Secret{
@@ -245,6 +248,28 @@ var _ = Describe("Get Provider Spec Status Version", func() {
 	)
 })
 
+var _ = Describe("Map changes to the DBaaS objects referencing an inventory", func() {
+	users := []inventoryUser{
+		{key: types.NamespacedName{Name: "connection-1", Namespace: "tenant-1"}, inventory: v1beta1.NamespacedName{Name: "inventory-a", Namespace: testNamespace}},
+		{key: types.NamespacedName{Name: "connection-2", Namespace: "tenant-2"}, inventory: v1beta1.NamespacedName{Name: "inventory-b", Namespace: testNamespace}},
+		{key: types.NamespacedName{Name: "connection-3", Namespace: "tenant-1"}, inventory: v1beta1.NamespacedName{Name: "inventory-c", Namespace: "other"}},
+	}
+	usersFn := func(_ context.Context) ([]inventoryUser, error) {
+		return users, nil
+	}
+
+	It("should only enqueue the affected objects", func() {
+		mapFn := inventoryUsersMapFn(usersFn, func(namespace client.Object, user inventoryUser) bool {
+			return user.key.Namespace == namespace.GetName()
+		})
+		Expect(mapFn(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-1"}})).Should(ConsistOf(
+			reconcile.Request{NamespacedName: users[0].key},
+			reconcile.Request{NamespacedName: users[2].key},
+		))
+		Expect(mapFn(&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "tenant-3"}})).Should(BeEmpty())
+	})
+})
+
 var _ = Describe("list policies by inventory namespace", func() {
 	ns := &corev1.Namespace{
 		ObjectMeta: metav1.ObjectMeta{
@@ -259,28 +284,15 @@ var _ = Describe("list policies by inventory namespace", func() {
 	policy2.Name = "test-policy-2"
 	isTrue := true
 	policy2.Spec.DisableProvisions = &isTrue
-	policy3 := getDefaultPolicy(ns.Name)
-	policy3.Name = "test-policy-3"
 	BeforeEach(assertResourceCreationIfNotExists(&policy1))
 	BeforeEach(assertDBaaSResourceStatusUpdated(&policy1, metav1.ConditionTrue, v1beta1.Ready))
-	BeforeEach(assertResourceCreationIfNotExists(&policy2))
-	BeforeEach(assertDBaaSResourceStatusUpdated(&policy2, metav1.ConditionFalse, v1beta1.DBaaSPolicyNotReady))
-	BeforeEach(assertResourceCreationIfNotExists(&policy3))
-	BeforeEach(assertDBaaSResourceStatusUpdated(&policy3, metav1.ConditionFalse, v1beta1.DBaaSPolicyNotReady))
 
 	Context("after creating DBaaSPolicies", func() {
-		It("should return all the created policies", func() {
+		It("should return the created policy", func() {
 			policyList, err := dRec.policyListByNS(ctx, ns.Name)
 			Expect(err).NotTo(HaveOccurred())
-			Expect(policyList.Items).Should(HaveLen(3))
-
-			for i := range policyList.Items {
-				if policyList.Items[i].Name == policy1.Name {
-					Expect(apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1beta1.DBaaSPolicyReadyType)).Should(BeTrue())
-				} else {
-					Expect(apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1beta1.DBaaSPolicyReadyType)).Should(BeFalse())
-				}
-			}
+			Expect(policyList.Items).Should(HaveLen(1))
+			Expect(apimeta.IsStatusConditionTrue(policyList.Items[0].Status.Conditions, v1beta1.DBaaSPolicyReadyType)).Should(BeTrue())
 
 			activePolicy := getActivePolicy(policyList)
 			Expect(activePolicy).Should(Not(BeNil()))
@@ -296,22 +308,99 @@ var _ = Describe("list policies by inventory namespace", func() {
 			Expect(isOwner(&policy1, &rqList.Items[0], dRec.Scheme)).Should(BeTrue())
 
 			inventory := &v1beta1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name}}
-			Expect(canProvision(inventory, activePolicy)).Should(BeTrue())
+			provision, _ := canProvision(inventory, activePolicy, nil, nil)
+			Expect(provision).Should(BeTrue())
 			activePolicy.Spec.DisableProvisions = &isTrue
-			Expect(canProvision(inventory, activePolicy)).Should(BeFalse())
+			provision, _ = canProvision(inventory, activePolicy, nil, nil)
+			Expect(provision).Should(BeFalse())
 
 			// override policy setting
 			isFalse := false
 			inventory.Spec.Policy = &v1beta1.DBaaSInventoryPolicy{DisableProvisions: &isFalse}
-			Expect(canProvision(inventory, activePolicy)).Should(BeTrue())
+			provision, _ = canProvision(inventory, activePolicy, nil, nil)
+			Expect(provision).Should(BeTrue())
 
 			// check nil policy
-			Expect(canProvision(inventory, nil)).Should(BeFalse())
+			provision, _ = canProvision(inventory, nil, nil, nil)
+			Expect(provision).Should(BeFalse())
+
+			// check provisioning guardrails
+			maxNodes := int32(3)
+			activePolicy.Spec.Provisioning = &v1beta1.DBaaSProvisioningPolicy{
+				AllowedPlans:   []string{v1beta1.ProvisioningPlanServerless},
+				AllowedRegions: []string{"us-east-1"},
+				MaxNodes:       &maxNodes,
+			}
+			provision, _ = canProvision(inventory, activePolicy, nil, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan:    v1beta1.ProvisioningPlanServerless,
+				v1beta1.ProvisioningRegions: "us-east-1",
+				v1beta1.ProvisioningNodes:   "3",
+			})
+			Expect(provision).Should(BeTrue())
+			provision, msg := canProvision(inventory, activePolicy, nil, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan:    v1beta1.ProvisioningPlanDedicated,
+				v1beta1.ProvisioningRegions: "eu-west-1",
+				v1beta1.ProvisioningNodes:   "5",
+			})
+			Expect(provision).Should(BeFalse())
+			Expect(msg).Should(ContainSubstring(`plan "DEDICATED" is not allowed by the policy`))
+			Expect(msg).Should(ContainSubstring(`regions "eu-west-1" is not allowed by the policy`))
+			Expect(msg).Should(ContainSubstring("nodes 5 exceeds the maximum of 3 allowed by the policy"))
+
+			// inventory guardrails narrow the policy guardrails, not override them
+			inventory.Spec.Policy.Provisioning = &v1beta1.DBaaSProvisioningPolicy{
+				AllowedPlans: []string{v1beta1.ProvisioningPlanDedicated, v1beta1.ProvisioningPlanServerless},
+			}
+			provision, _ = canProvision(inventory, activePolicy, nil, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan: v1beta1.ProvisioningPlanDedicated,
+			})
+			Expect(provision).Should(BeFalse())
+			provision, _ = canProvision(inventory, activePolicy, nil, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan: v1beta1.ProvisioningPlanServerless,
+			})
+			Expect(provision).Should(BeTrue())
+			activePolicy.Spec.Provisioning = nil
+			inventory.Spec.Policy.Provisioning = &v1beta1.DBaaSProvisioningPolicy{
+				AllowedPlans: []string{v1beta1.ProvisioningPlanDedicated, v1beta1.ProvisioningPlanFreeTrial},
+			}
+			provision, _ = canProvision(inventory, activePolicy, nil, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan: v1beta1.ProvisioningPlanDedicated,
+			})
+			Expect(provision).Should(BeTrue())
+
+			// cluster policy guardrails are narrowed, not overridden
+			clusterPolicy := &v1beta1.DBaaSClusterPolicy{
+				ObjectMeta: metav1.ObjectMeta{Name: v1beta1.ClusterPolicyName},
+				Spec: v1beta1.DBaaSPolicySpec{
+					DBaaSInventoryPolicy: v1beta1.DBaaSInventoryPolicy{
+						Provisioning: &v1beta1.DBaaSProvisioningPolicy{
+							AllowedPlans: []string{v1beta1.ProvisioningPlanFreeTrial},
+						},
+					},
+				},
+			}
+			provision, _ = canProvision(inventory, activePolicy, clusterPolicy, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan: v1beta1.ProvisioningPlanDedicated,
+			})
+			Expect(provision).Should(BeFalse())
+			provision, _ = canProvision(inventory, activePolicy, clusterPolicy, map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningPlan: v1beta1.ProvisioningPlanFreeTrial,
+			})
+			Expect(provision).Should(BeTrue())
+
+			// provisioning disabled cluster-wide
+			clusterPolicy.Spec.DisableProvisions = &isTrue
+			provision, _ = canProvision(inventory, activePolicy, clusterPolicy, nil)
+			Expect(provision).Should(BeFalse())
 		})
 
-		It("should, upon deletion, make another policy active", func() {
+		It("should reject a second policy", func() {
+			policy := policy2.DeepCopy()
+			Expect(dRec.Create(ctx, policy)).Should(MatchError(ContainSubstring("already has the policy " + policy1.Name)))
+		})
+
+		It("should, upon deletion, allow another policy to be made active", func() {
 			Expect(dRec.Delete(ctx, &policy1)).Should(Succeed())
-			Expect(dRec.Delete(ctx, &policy3)).Should(Succeed())
 			By("checking the resources deleted")
 			Eventually(func() bool {
 				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy1), &policy1)
@@ -320,13 +409,11 @@ var _ = Describe("list policies by inventory namespace", func() {
 				}
 				return false
 			}, timeout).Should(BeTrue())
-			Eventually(func() bool {
-				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy3), &policy3)
-				if err != nil && errors.IsNotFound(err) {
-					return true
-				}
-				return false
-			}, timeout).Should(BeTrue())
+
+			By("creating another policy")
+			Eventually(func() error {
+				return dRec.Create(ctx, policy2.DeepCopy())
+			}, timeout).Should(Succeed())
 
 			By("checking the DBaaS resource status")
 			Eventually(func() (bool, error) {
@@ -346,7 +433,8 @@ var _ = Describe("list policies by inventory namespace", func() {
 			Expect(activePolicy.Name).Should(Equal(policy2.Name))
 
 			inventory := &v1beta1.DBaaSInventory{ObjectMeta: metav1.ObjectMeta{Namespace: ns.Name}}
-			Expect(canProvision(inventory, activePolicy)).Should(BeFalse())
+			provision, _ := canProvision(inventory, activePolicy, nil, nil)
+			Expect(provision).Should(BeFalse())
 		})
 	})
 })
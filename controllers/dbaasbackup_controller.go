@@ -0,0 +1,202 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// DBaaSBackupReconciler reconciles a DBaaSBackup object
+type DBaaSBackupReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile creates the provider backup object of a DBaaSBackup, for the provider of the referenced instance,
+// and syncs its status. The namespace of the backup must be a valid connection namespace for the inventory of the instance.
+func (r *DBaaSBackupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var backup v1beta1.DBaaSBackup
+	if err := r.Get(ctx, req.NamespacedName, &backup); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Backup resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Backup for reconcile")
+		return ctrl.Result{}, err
+	}
+	if backup.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	instance, err := r.getInstance(ctx, backup.Spec.InstanceRef, backup.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "DBaaS Instance resource not found for DBaaS Backup", "DBaaS Backup", backup.Name)
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &backup, &backup.Status.Conditions, backupNotReady(v1beta1.DBaaSServiceNotAvailable, err.Error()))
+		}
+		logger.Error(err, "Error fetching the DBaaS Instance of the DBaaS Backup", "DBaaS Backup", backup.Name)
+		return ctrl.Result{}, err
+	}
+
+	inventory, validNS, _, err := r.checkInventory(ctx, instance.Spec.InventoryRef, &backup, func(reason string, message string) {
+		apimeta.SetStatusCondition(&backup.Status.Conditions, backupNotReady(reason, message))
+	}, logger)
+	if err != nil || !validNS {
+		return ctrl.Result{}, err
+	}
+	if len(instance.Status.InstanceID) == 0 {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &backup, &backup.Status.Conditions, backupNotReady(v1beta1.DBaaSServiceNotAvailable, "instance ID is not available"))
+	}
+
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !provider.GetCapabilities().SupportsBackups() {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &backup, &backup.Status.Conditions, backupNotReady(v1beta1.DBaaSBackupNotSupported, v1beta1.MsgBackupNotSupported))
+	}
+
+	return r.reconcileProviderResource(ctx,
+		inventory.Spec.ProviderRef.Name,
+		&backup,
+		func(provider *v1beta1.DBaaSProvider) string {
+			return provider.Spec.BackupKind
+		},
+		func() interface{} {
+			return &v1beta1.DBaaSProviderBackupSpec{
+				DBaaSBackupSpec:   *backup.Spec.DeepCopy(),
+				InventoryRef:      instance.Spec.InventoryRef,
+				DatabaseServiceID: instance.Status.InstanceID,
+			}
+		},
+		func() interface{} {
+			return &v1beta1.DBaaSProviderBackup{}
+		},
+		func(i interface{}) metav1.Condition {
+			return mergeBackupStatus(&backup, i.(*v1beta1.DBaaSProviderBackup))
+		},
+		func() *[]metav1.Condition {
+			return &backup.Status.Conditions
+		},
+		v1beta1.DBaaSBackupReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSBackupReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSBackup{}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instanceToBackups))
+	return r.watchConnectionNamespaces(bldr, r.backupInventoryUsers).
+		Build(r)
+}
+
+// instanceToBackups maps an instance to the backups referencing it
+func (r *DBaaSBackupReconciler) instanceToBackups(obj client.Object) []reconcile.Request {
+	var backupList v1beta1.DBaaSBackupList
+	if err := r.List(context.Background(), &backupList); err != nil {
+		ctrl.Log.WithName("DBaaSBackupReconciler").Error(err, "Error listing the DBaaS Backups referencing an instance", "DBaaS Instance", objectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range backupList.Items {
+		if refKey(backupList.Items[i].Spec.InstanceRef, backupList.Items[i].Namespace) == client.ObjectKeyFromObject(obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&backupList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// backupInventoryUsers lists the backups with the inventories of the instances they reference
+func (r *DBaaSBackupReconciler) backupInventoryUsers(ctx context.Context) ([]inventoryUser, error) {
+	var backupList v1beta1.DBaaSBackupList
+	if err := r.List(ctx, &backupList); err != nil {
+		return nil, err
+	}
+	inventories, err := r.instanceInventories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var users []inventoryUser
+	for i := range backupList.Items {
+		if inventory, ok := inventories[refKey(backupList.Items[i].Spec.InstanceRef, backupList.Items[i].Namespace)]; ok {
+			users = append(users, inventoryUser{key: client.ObjectKeyFromObject(&backupList.Items[i]), inventory: inventory})
+		}
+	}
+	return users, nil
+}
+
+func backupNotReady(reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSBackupReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// mergeBackupStatus: merge the status from DBaaSProviderBackup into the current DBaaSBackup status
+func mergeBackupStatus(backup *v1beta1.DBaaSBackup, providerBackup *v1beta1.DBaaSProviderBackup) metav1.Condition {
+	providerBackup.Status.DeepCopyInto(&backup.Status)
+	if len(backup.Status.Phase) == 0 {
+		backup.Status.Phase = v1beta1.BackupPhaseUnknown
+	}
+	// Update backup status condition (type: DBaaSBackupReadyType) based on the provider status
+	specSync := apimeta.FindStatusCondition(providerBackup.Status.Conditions, v1beta1.DBaaSBackupProviderSyncType)
+	if specSync != nil && specSync.Status == metav1.ConditionTrue {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSBackupReadyType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.Ready,
+			Message: v1beta1.MsgProviderCRStatusSyncDone,
+		}
+	}
+	if specSync != nil {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSBackupReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.ProviderReconcileError,
+			Message: fmt.Sprintf("%s: %s", specSync.Reason, specSync.Message),
+		}
+	}
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSBackupReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.ProviderReconcileInprogress,
+		Message: v1beta1.MsgProviderCRReconcileInProgress,
+	}
+}
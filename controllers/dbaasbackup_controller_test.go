@@ -0,0 +1,66 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("DBaaSBackup controller with errors", func() {
+	Context("after creating DBaaSBackup without instance", func() {
+		createdDBaaSBackup := &v1beta1.DBaaSBackup{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup-no-instance",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSBackupSpec{
+				InstanceRef: v1beta1.NamespacedName{Name: "test-instance-no-exist-ref"},
+			},
+		}
+
+		BeforeEach(assertResourceCreation(createdDBaaSBackup))
+		AfterEach(assertResourceDeletion(createdDBaaSBackup))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSBackup, metav1.ConditionFalse, v1beta1.DBaaSServiceNotAvailable))
+	})
+})
+
+var _ = Describe("DBaaSBackupSchedule controller", func() {
+	Context("after creating a suspended DBaaSBackupSchedule", func() {
+		createdDBaaSBackupSchedule := &v1beta1.DBaaSBackupSchedule{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-backup-schedule-suspended",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSBackupScheduleSpec{
+				BackupTemplate: v1beta1.DBaaSBackupSpec{
+					InstanceRef: v1beta1.NamespacedName{Name: "test-instance-no-exist-ref"},
+				},
+				Interval: metav1.Duration{Duration: time.Hour},
+				Suspend:  true,
+			},
+		}
+
+		BeforeEach(assertResourceCreation(createdDBaaSBackupSchedule))
+		AfterEach(assertResourceDeletion(createdDBaaSBackupSchedule))
+		It("reports the schedule as suspended", assertDBaaSResourceStatusUpdated(createdDBaaSBackupSchedule, metav1.ConditionFalse, v1beta1.DBaaSBackupScheduleSuspended))
+	})
+})
@@ -0,0 +1,169 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// DBaaSBackupScheduleReconciler reconciles a DBaaSBackupSchedule object
+type DBaaSBackupScheduleReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile creates a DBaaSBackup from the template of a schedule when its interval has elapsed since the last backup,
+// deletes the oldest backups of the schedule beyond its history limit, and requeues the schedule for its next backup.
+// The first backup is created with the schedule.
+func (r *DBaaSBackupScheduleReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var schedule v1beta1.DBaaSBackupSchedule
+	if err := r.Get(ctx, req.NamespacedName, &schedule); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Backup Schedule for reconcile")
+		return ctrl.Result{}, err
+	}
+	if schedule.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	if schedule.Spec.Suspend {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &schedule, &schedule.Status.Conditions, metav1.Condition{
+			Type:    v1beta1.DBaaSBackupScheduleReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.DBaaSBackupScheduleSuspended,
+			Message: v1beta1.MsgBackupScheduleSuspended,
+		})
+	}
+
+	now := time.Now()
+	if next := nextBackupTime(&schedule); !now.Before(next) {
+		backup, err := r.createScheduledBackup(ctx, &schedule, next)
+		if err != nil {
+			logger.Error(err, "Error creating the DBaaS Backup of the DBaaS Backup Schedule", "DBaaS Backup Schedule", schedule.Name)
+			return ctrl.Result{}, err
+		}
+		logger.Info("DBaaS Backup created", "DBaaS Backup Schedule", schedule.Name, "DBaaS Backup", backup.Name)
+		schedule.Status.LastScheduleTime = &metav1.Time{Time: now}
+		schedule.Status.LastBackupRef = &corev1.LocalObjectReference{Name: backup.Name}
+	}
+	if err := r.pruneScheduledBackups(ctx, &schedule); err != nil {
+		logger.Error(err, "Error deleting the old DBaaS Backups of the DBaaS Backup Schedule", "DBaaS Backup Schedule", schedule.Name)
+		return ctrl.Result{}, err
+	}
+
+	next := nextBackupTime(&schedule)
+	apimeta.SetStatusCondition(&schedule.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSBackupScheduleReadyType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.DBaaSBackupScheduled,
+		Message: fmt.Sprintf("Next backup at %s", next.UTC().Format(time.RFC3339)),
+	})
+	if err := r.Client.Status().Update(ctx, &schedule); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Backup Schedule resource modified, retry syncing status", "DBaaS Backup Schedule", schedule.Name)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Backup Schedule resource status", "DBaaS Backup Schedule", schedule.Name)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: next.Sub(now)}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSBackupScheduleReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSBackupSchedule{}).
+		Owns(&v1beta1.DBaaSBackup{}).
+		Complete(r)
+}
+
+// nextBackupTime returns the time of the next backup of a schedule, its creation time before its first backup
+func nextBackupTime(schedule *v1beta1.DBaaSBackupSchedule) time.Time {
+	if schedule.Status.LastScheduleTime == nil {
+		return schedule.CreationTimestamp.Time
+	}
+	return schedule.Status.LastScheduleTime.Add(schedule.Spec.Interval.Duration)
+}
+
+// createScheduledBackup creates a backup from the template of a schedule, named after the schedule and the scheduled time of the backup,
+// so that a backup is not created twice when the status of the schedule fails to be updated
+func (r *DBaaSBackupScheduleReconciler) createScheduledBackup(ctx context.Context, schedule *v1beta1.DBaaSBackupSchedule, scheduled time.Time) (*v1beta1.DBaaSBackup, error) {
+	backup := &v1beta1.DBaaSBackup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%d", schedule.Name, scheduled.Unix()),
+			Namespace: schedule.Namespace,
+			Labels:    map[string]string{v1beta1.BackupScheduleLabel: schedule.Name},
+		},
+		Spec: *schedule.Spec.BackupTemplate.DeepCopy(),
+	}
+	if err := controllerutil.SetControllerReference(schedule, backup, r.Scheme); err != nil {
+		return nil, err
+	}
+	if err := r.Create(ctx, backup); err != nil && !errors.IsAlreadyExists(err) {
+		return nil, err
+	}
+	return backup, nil
+}
+
+// pruneScheduledBackups deletes the oldest backups of a schedule beyond its history limit
+func (r *DBaaSBackupScheduleReconciler) pruneScheduledBackups(ctx context.Context, schedule *v1beta1.DBaaSBackupSchedule) error {
+	if schedule.Spec.BackupsHistoryLimit == nil {
+		return nil
+	}
+	var backupList v1beta1.DBaaSBackupList
+	if err := r.List(ctx, &backupList, client.InNamespace(schedule.Namespace), client.MatchingLabels{v1beta1.BackupScheduleLabel: schedule.Name}); err != nil {
+		return err
+	}
+	var backups []*v1beta1.DBaaSBackup
+	for i := range backupList.Items {
+		if backupList.Items[i].DeletionTimestamp == nil && metav1.IsControlledBy(&backupList.Items[i], schedule) {
+			backups = append(backups, &backupList.Items[i])
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool {
+		return backups[i].CreationTimestamp.Before(&backups[j].CreationTimestamp) ||
+			(backups[i].CreationTimestamp.Equal(&backups[j].CreationTimestamp) && backups[i].Name < backups[j].Name)
+	})
+	for i := 0; i < len(backups)-int(*schedule.Spec.BackupsHistoryLimit); i++ {
+		if err := r.Delete(ctx, backups[i]); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		ctrl.LoggerFrom(ctx).Info("DBaaS Backup deleted", "DBaaS Backup Schedule", schedule.Name, "DBaaS Backup", backups[i].Name)
+	}
+	return nil
+}
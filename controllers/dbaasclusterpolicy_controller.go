@@ -0,0 +1,80 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DBaaSClusterPolicyReconciler reconciles a DBaaSClusterPolicy object
+type DBaaSClusterPolicyReconciler struct {
+	*DBaaSReconciler
+}
+
+// Reconcile reports whether a cluster policy is applied.
+// The effective policies of the namespaces are updated by the DBaaSPolicy reconciler.
+func (r *DBaaSClusterPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var clusterPolicy v1beta1.DBaaSClusterPolicy
+	if err := r.Get(ctx, req.NamespacedName, &clusterPolicy); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Cluster Policy for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	cond := metav1.Condition{
+		Type:    v1beta1.DBaaSPolicyReadyType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.Ready,
+		Message: v1beta1.MsgPolicyReady,
+	}
+	if clusterPolicy.Name != v1beta1.ClusterPolicyName {
+		cond = metav1.Condition{
+			Type:    v1beta1.DBaaSPolicyReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.DBaaSPolicyNotReady,
+			Message: v1beta1.MsgClusterPolicyNotApplied,
+		}
+	}
+	apimeta.SetStatusCondition(&clusterPolicy.Status.Conditions, cond)
+	if err := r.Client.Status().Update(ctx, &clusterPolicy); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Cluster Policy resource modified, retry syncing status", "DBaaS Cluster Policy", clusterPolicy)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Cluster Policy resource status", "DBaaS Cluster Policy", clusterPolicy)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSClusterPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSClusterPolicy{}).
+		Complete(r)
+}
@@ -18,19 +18,28 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"net"
+	"net/url"
+	"time"
 
 	appv1 "k8s.io/api/apps/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -38,15 +47,59 @@ import (
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
 )
 
+const (
+	serviceBindingSecretTypePrefix = "servicebinding.io/"
+
+	// entries of a binding secret, as defined by the Service Binding specification
+	bindingTypeKey     = "type"
+	bindingProviderKey = "provider"
+	bindingHostKey     = "host"
+	bindingPortKey     = "port"
+	bindingUsernameKey = "username"
+	bindingPasswordKey = "password"
+	bindingDatabaseKey = "database"
+	bindingURIKey      = "uri"
+
+	// DefaultRolloutRestartInterval is the default minimum interval between two restarts of the workloads bound to a connection
+	DefaultRolloutRestartInterval = time.Minute
+
+	// DefaultProbeTimeout is the default timeout of a probe checking the database of a connection is reachable
+	DefaultProbeTimeout = 5 * time.Second
+
+	// devTopologyCleanupPageSize is the number of connections listed at a time by the cleanup of the topology placeholder Deployments
+	devTopologyCleanupPageSize = 100
+)
+
 // DBaaSConnectionReconciler reconciles a DBaaSConnection object
 type DBaaSConnectionReconciler struct {
 	*DBaaSReconciler
+	// DisableDevTopology disables the ConfigMaps representing the connections in the topology view of the console
+	DisableDevTopology bool
+	// DisableInjection disables the restart of the Deployments labeled for the injection of a connection,
+	// when the workload injection webhook is not served
+	DisableInjection bool
+	// RolloutRestartInterval is the minimum interval between two restarts of the workloads bound to a connection,
+	// DefaultRolloutRestartInterval if not set
+	RolloutRestartInterval time.Duration
+	// ProbeInterval is the interval between two probes checking the database of a connection is reachable,
+	// the probes are disabled if not set
+	ProbeInterval time.Duration
+	// ProbeTimeout is the timeout of a probe, DefaultProbeTimeout if not set
+	ProbeTimeout time.Duration
+	// RevokeInvalidConnections deletes the provider connection, and with it the credentials, of the connections
+	// whose namespace is no longer a valid connection namespace for their inventory
+	RevokeInvalidConnections bool
+
+	prober *connectionProber
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
-//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=get;list;watch;create;update
+//+kubebuilder:rbac:groups=apps,resources=deployments,verbs=list;watch;patch;delete
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=list;watch;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;create;update;delete
+//+kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;delete
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -81,15 +134,30 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 	res, err := r.reconcileDevTopologyResource(ctx, &connection)
 	if err != nil {
 		if errors.IsConflict(err) {
-			logger.V(1).Info("Deployment for Developer Topology view modified, retry reconciling")
+			logger.V(1).Info("ConfigMap for Developer Topology view modified, retry reconciling")
 			metricLabelErrCdValue = metrics.LabelErrorCdValueDevTopologyModified
 			return ctrl.Result{Requeue: true}, nil
 		}
-		logger.Error(err, "Error reconciling Deployment for Developer Topology view")
+		logger.Error(err, "Error reconciling ConfigMap for Developer Topology view")
 		metricLabelErrCdValue = metrics.LabelErrorCdValueErrReconcilingWithDevTopology
 		return ctrl.Result{}, err
 	}
-	logger.Info("Deployment for Developer Topology view reconciled", "result", res)
+	logger.Info("ConfigMap for Developer Topology view reconciled", "result", res)
+
+	expired, expiryResult, err := r.reconcileExpiry(ctx, &connection)
+	if err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Connection resource modified, retry expiring the connection")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error expiring the DBaaS Connection")
+		metricLabelErrCdValue = metrics.LabelErrorCdValueErrExpiringConnection
+		return ctrl.Result{}, err
+	}
+	if expired {
+		logger.Info("DBaaS Connection expired", "expiresAt", connection.Status.ExpiresAt)
+		return ctrl.Result{}, nil
+	}
 
 	if inventory, validNS, _, err := r.checkInventory(ctx, connection.Spec.InventoryRef, &connection, func(reason string, message string) {
 		cond := metav1.Condition{
@@ -104,7 +172,18 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		return ctrl.Result{}, err
 	} else if !validNS {
 		metricLabelErrCdValue = metrics.LabelErrorCdValueInvalidNameSpace
-		return ctrl.Result{}, nil
+		if r.RevokeInvalidConnections {
+			if err := r.revokeConnection(ctx, &connection, inventory); err != nil {
+				if errors.IsConflict(err) {
+					logger.V(1).Info("DBaaS Connection resource modified, retry revoking the connection")
+					return ctrl.Result{Requeue: true}, nil
+				}
+				logger.Error(err, "Error revoking the DBaaS Connection from an invalid namespace")
+				metricLabelErrCdValue = metrics.LabelErrorCdValueErrRevokingConnection
+				return ctrl.Result{}, err
+			}
+		}
+		return expiryResult, nil
 	} else {
 		spec, err := r.getConnectionSpec(ctx, connection.Spec.DeepCopy())
 		if err != nil {
@@ -162,75 +241,665 @@ func (r *DBaaSConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Requ
 		defer func() {
 			metrics.SetConnectionMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, connection, execution, event, metricLabelErrCdValue)
 		}()
-		return result, err
+		if err != nil || result.Requeue {
+			return result, err
+		}
+
+		if err := r.reconcileServiceBinding(ctx, &connection, provider); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("Service binding Secret modified, retry reconciling")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error reconciling the service binding Secret")
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrReconcilingServiceBinding
+			return ctrl.Result{}, err
+		}
+
+		rolloutResult, err := r.reconcileRolloutRestart(ctx, &connection)
+		if err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Connection resource modified, retry restarting the bound workloads")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error restarting the workloads bound to the DBaaS Connection")
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrRestartingWorkloads
+			return ctrl.Result{}, err
+		}
+
+		if err := r.reconcileInjection(ctx, &connection); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("Deployment modified, retry injecting the DBaaS Connection")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error injecting the DBaaS Connection into the annotated Deployments")
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrInjectingWorkloads
+			return ctrl.Result{}, err
+		}
+
+		if err := r.reconcileReachability(ctx, &connection); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Connection resource modified, retry probing the database")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error probing the database of the DBaaS Connection")
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrProbingConnection
+			return ctrl.Result{}, err
+		}
+		return shortestRequeue(result, rolloutResult, expiryResult), nil
 	}
 }
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSConnectionReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		r.cleanupDevTopologyDeployments(ctx, mgr.GetAPIReader())
+		return nil
+	})); err != nil {
+		return nil, err
+	}
+	if r.ProbeInterval > 0 {
+		r.prober = newConnectionProber(r.Client, r.ProbeInterval, r.ProbeTimeout)
+		if err := mgr.Add(r.prober); err != nil {
+			return nil, err
+		}
+	}
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSConnection{}).
 		Watches(&source.Kind{Type: &v1beta1.DBaaSConnection{}}, &EventHandlerWithDelete{Controller: r}).
+		Owns(&v1.Secret{}, builder.OnlyMetadata).
+		Owns(&v1.ConfigMap{}, builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &v1.Secret{}}, handler.EnqueueRequestsFromMapFunc(r.bindingSourceToConnections), builder.OnlyMetadata).
+		Watches(&source.Kind{Type: &v1.ConfigMap{}}, handler.EnqueueRequestsFromMapFunc(r.bindingSourceToConnections), builder.OnlyMetadata)
+	return r.watchConnectionNamespaces(bldr, r.connectionInventoryUsers).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
+// connectionInventoryUsers lists the connections with the inventories they reference
+func (r *DBaaSConnectionReconciler) connectionInventoryUsers(ctx context.Context) ([]inventoryUser, error) {
+	var connectionList v1beta1.DBaaSConnectionList
+	if err := r.List(ctx, &connectionList); err != nil {
+		return nil, err
+	}
+	users := make([]inventoryUser, len(connectionList.Items))
+	for i := range connectionList.Items {
+		users[i] = inventoryUser{key: client.ObjectKeyFromObject(&connectionList.Items[i]), inventory: connectionList.Items[i].Spec.InventoryRef}
+	}
+	return users, nil
+}
+
+// revokeConnection deletes the provider connection and the service binding of a connection whose namespace is no longer valid,
+// so that the credentials are no longer published in the namespace. They are published again once the namespace is valid.
+func (r *DBaaSConnectionReconciler) revokeConnection(ctx context.Context, connection *v1beta1.DBaaSConnection, inventory *v1beta1.DBaaSInventory) error {
+	if err := r.deleteProviderConnection(ctx, connection, inventory); err != nil {
+		return err
+	}
+	if connection.Status.CredentialsRef == nil && connection.Status.ConnectionInfoRef == nil && connection.Status.Binding == nil {
+		return nil
+	}
+	clearConnectionCredentials(connection)
+	apimeta.SetStatusCondition(&connection.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSConnectionReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.DBaaSInvalidNamespace,
+		Message: v1beta1.MsgConnectionRevoked,
+	})
+	return r.Client.Status().Update(ctx, connection)
+}
+
+// deleteProviderConnection deletes the provider connection, revoking the database user created by the provider, and the service binding of a connection
+func (r *DBaaSConnectionReconciler) deleteProviderConnection(ctx context.Context, connection *v1beta1.DBaaSConnection, inventory *v1beta1.DBaaSInventory) error {
+	logger := ctrl.LoggerFrom(ctx)
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return err
+	}
+	providerObject := r.createProviderObject(connection, provider.GetDBaaSAPIGroupVersion(), provider.Spec.ConnectionKind)
+	if err := r.Client.Delete(ctx, providerObject); err != nil {
+		if !errors.IsNotFound(err) {
+			return err
+		}
+	} else {
+		logger.Info("Provider connection deleted", "Provider Object", providerObject)
+	}
+	secret := &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      serviceBindingSecretName(connection.Name),
+			Namespace: connection.Namespace,
+		},
+	}
+	if err := r.Client.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+		return err
+	}
+	return nil
+}
+
+// clearConnectionCredentials removes the references to the credentials of a deleted provider connection
+func clearConnectionCredentials(connection *v1beta1.DBaaSConnection) {
+	connection.Status.CredentialsRef = nil
+	connection.Status.ConnectionInfoRef = nil
+	connection.Status.Binding = nil
+	connection.Status.CredentialsHash = ""
+}
+
+// reconcileExpiry reports the expiry time of a connection with an expiresAt or a ttl, and requeues the connection when it expires.
+// Once expired, the provider connection and the service binding are deleted, and the connection is no longer reconciled until its expiry is extended.
+func (r *DBaaSConnectionReconciler) reconcileExpiry(ctx context.Context, connection *v1beta1.DBaaSConnection) (bool, ctrl.Result, error) {
+	expiresAt := connection.ExpiryTime()
+	if expiresAt == nil {
+		if connection.Status.ExpiresAt != nil {
+			connection.Status.ExpiresAt = nil
+			apimeta.RemoveStatusCondition(&connection.Status.Conditions, v1beta1.DBaaSConnectionExpiredType)
+			metrics.DeleteConnectionExpiryMetrics(*connection)
+		}
+		return false, ctrl.Result{}, nil
+	}
+	connection.Status.ExpiresAt = expiresAt
+
+	inventory := &v1beta1.DBaaSInventory{}
+	if err := r.Get(ctx, types.NamespacedName{Namespace: connection.Spec.InventoryRef.Namespace, Name: connection.Spec.InventoryRef.Name}, inventory); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, ctrl.Result{}, err
+		}
+		inventory = nil
+	}
+	providerName := ""
+	if inventory != nil {
+		providerName = inventory.Spec.ProviderRef.Name
+	}
+
+	remaining := time.Until(expiresAt.Time)
+	if remaining > 0 {
+		apimeta.SetStatusCondition(&connection.Status.Conditions, metav1.Condition{
+			Type:    v1beta1.DBaaSConnectionExpiredType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.DBaaSConnectionNotExpired,
+			Message: v1beta1.MsgConnectionNotExpired,
+		})
+		metrics.SetConnectionExpiryMetrics(providerName, *connection, expiresAt.Time, false)
+		return false, ctrl.Result{RequeueAfter: remaining}, nil
+	}
+
+	if inventory != nil {
+		if err := r.deleteProviderConnection(ctx, connection, inventory); err != nil && !errors.IsNotFound(err) {
+			return true, ctrl.Result{}, err
+		}
+	}
+	clearConnectionCredentials(connection)
+	apimeta.SetStatusCondition(&connection.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSConnectionExpiredType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.DBaaSConnectionExpired,
+		Message: v1beta1.MsgConnectionExpired,
+	})
+	apimeta.SetStatusCondition(&connection.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSConnectionReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.DBaaSConnectionExpired,
+		Message: v1beta1.MsgConnectionExpired,
+	})
+	if err := r.Client.Status().Update(ctx, connection); err != nil {
+		return true, ctrl.Result{}, err
+	}
+	metrics.SetConnectionExpiryMetrics(providerName, *connection, expiresAt.Time, true)
+	return true, ctrl.Result{}, nil
+}
+
+// reconcileDevTopologyResource reconciles the ConfigMap representing the connection in the topology view of the console
 func (r *DBaaSConnectionReconciler) reconcileDevTopologyResource(ctx context.Context, connection *v1beta1.DBaaSConnection) (controllerutil.OperationResult, error) {
-	deployment := &appv1.Deployment{
+	configMap := &v1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      connection.Name,
+			Name:      devTopologyName(connection.Name),
 			Namespace: connection.Namespace,
 		},
 	}
-	result, err := controllerutil.CreateOrUpdate(ctx, r.Client, deployment, r.deploymentMutateFn(connection, deployment))
-	return result, err
+	if r.DisableDevTopology {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(configMap), configMap); err != nil {
+			if errors.IsNotFound(err) {
+				return controllerutil.OperationResultNone, nil
+			}
+			return controllerutil.OperationResultNone, err
+		}
+		if !metav1.IsControlledBy(configMap, connection) {
+			return controllerutil.OperationResultNone, nil
+		}
+		if err := r.Client.Delete(ctx, configMap); err != nil && !errors.IsNotFound(err) {
+			return controllerutil.OperationResultNone, err
+		}
+		return controllerutil.OperationResultUpdated, nil
+	}
+	return controllerutil.CreateOrUpdate(ctx, r.Client, configMap, r.devTopologyMutateFn(connection, configMap))
 }
 
-func (r *DBaaSConnectionReconciler) deploymentMutateFn(connection *v1beta1.DBaaSConnection, deployment *appv1.Deployment) controllerutil.MutateFn {
+func (r *DBaaSConnectionReconciler) devTopologyMutateFn(connection *v1beta1.DBaaSConnection, configMap *v1.ConfigMap) controllerutil.MutateFn {
 	return func() error {
-		if deployment.ObjectMeta.Annotations == nil {
-			deployment.ObjectMeta.Annotations = make(map[string]string, 4)
-		}
-		deployment.ObjectMeta.Annotations["managed-by"] = "dbaas-operator"
-		deployment.ObjectMeta.Annotations["owner"] = connection.Name
-		deployment.ObjectMeta.Annotations["owner.kind"] = connection.Kind
-		deployment.ObjectMeta.Annotations["owner.namespace"] = connection.Namespace
-
-		deployment.Spec = appv1.DeploymentSpec{
-			Replicas: pointer.Int32(0),
-			Selector: &metav1.LabelSelector{
-				MatchLabels: map[string]string{
-					"name": "bind-deploy",
-				},
-			},
-			Template: v1.PodTemplateSpec{
-				ObjectMeta: metav1.ObjectMeta{
-					Labels: map[string]string{
-						"name": "bind-deploy",
-					},
-				},
-				Spec: v1.PodSpec{
-					Containers: []v1.Container{
-						{
-							Name:            "bind-deploy",
-							Image:           "quay.io/ecosystem-appeng/busybox",
-							ImagePullPolicy: v1.PullIfNotPresent,
-							Command:         []string{"sh", "-c", "echo The app is running! && sleep 3600"},
-						},
+		if configMap.Labels == nil {
+			configMap.Labels = make(map[string]string, 1)
+		}
+		configMap.Labels[v1beta1.TopologyNodeLabelKey] = "true"
+		if configMap.Annotations == nil {
+			configMap.Annotations = make(map[string]string, 4)
+		}
+		configMap.Annotations["managed-by"] = "dbaas-operator"
+		configMap.Annotations["owner"] = connection.Name
+		configMap.Annotations["owner.kind"] = connection.Kind
+		configMap.Annotations["owner.namespace"] = connection.Namespace
+
+		configMap.Data = map[string]string{
+			"connection":         connection.Name,
+			"inventory":          connection.Spec.InventoryRef.Name,
+			"inventoryNamespace": connection.Spec.InventoryRef.Namespace,
+		}
+		configMap.OwnerReferences = nil
+		return ctrl.SetControllerReference(connection, configMap, r.Scheme)
+	}
+}
+
+// cleanupDevTopologyDeployments deletes the placeholder Deployments created for the topology view by previous versions of the operator.
+// The placeholders have no labels, and are named after their connection, so only the Deployments named after a connection are read,
+// a page of connections at a time.
+func (r *DBaaSConnectionReconciler) cleanupDevTopologyDeployments(ctx context.Context, reader client.Reader) {
+	logger := ctrl.Log.WithName("DBaaSConnectionReconciler")
+	connections := &metav1.PartialObjectMetadataList{}
+	connections.SetGroupVersionKind(v1beta1.GroupVersion.WithKind("DBaaSConnectionList"))
+	for {
+		if err := reader.List(ctx, connections, client.Limit(devTopologyCleanupPageSize), client.Continue(connections.Continue)); err != nil {
+			logger.Error(err, "Error listing DBaaS Connections, the placeholder Deployments for Developer Topology view are not cleaned up")
+			return
+		}
+		for i := range connections.Items {
+			deployment := &metav1.PartialObjectMetadata{}
+			deployment.SetGroupVersionKind(appv1.SchemeGroupVersion.WithKind("Deployment"))
+			if err := reader.Get(ctx, client.ObjectKeyFromObject(&connections.Items[i]), deployment); err != nil {
+				if !errors.IsNotFound(err) {
+					logger.Error(err, "Error reading the placeholder Deployment for Developer Topology view", "DBaaS Connection", client.ObjectKeyFromObject(&connections.Items[i]))
+				}
+				continue
+			}
+			if !isDevTopologyDeployment(deployment) {
+				continue
+			}
+			if err := r.Client.Delete(ctx, deployment, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil && !errors.IsNotFound(err) {
+				logger.Error(err, "Error deleting the placeholder Deployment for Developer Topology view", "Deployment", client.ObjectKeyFromObject(deployment))
+				continue
+			}
+			logger.Info("Placeholder Deployment for Developer Topology view deleted", "Deployment", client.ObjectKeyFromObject(deployment))
+		}
+		if len(connections.Continue) == 0 {
+			return
+		}
+	}
+}
+
+// isDevTopologyDeployment returns true for a placeholder Deployment created for the topology view by previous versions of the operator,
+// controlled by a DBaaSConnection of any version
+func isDevTopologyDeployment(deployment client.Object) bool {
+	annotations := deployment.GetAnnotations()
+	if annotations["managed-by"] != "dbaas-operator" || annotations["owner.kind"] != "DBaaSConnection" {
+		return false
+	}
+	owner := metav1.GetControllerOf(deployment)
+	if owner == nil || owner.Kind != "DBaaSConnection" || owner.Name != annotations["owner"] {
+		return false
+	}
+	gv, err := schema.ParseGroupVersion(owner.APIVersion)
+	return err == nil && gv.Group == v1beta1.GroupVersion.Group
+}
+
+func devTopologyName(connectionName string) string {
+	return connectionName + "-topology"
+}
+
+// reconcileServiceBinding projects the credentials and connection information published by the provider
+// into a single binding secret, following the Service Binding specification (https://servicebinding.io/spec/core/1.0.0/)
+func (r *DBaaSConnectionReconciler) reconcileServiceBinding(ctx context.Context, connection *v1beta1.DBaaSConnection, provider *v1beta1.DBaaSProvider) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if !apimeta.IsStatusConditionTrue(connection.Status.Conditions, v1beta1.DBaaSConnectionReadyType) ||
+		connection.Status.CredentialsRef == nil || connection.Status.ConnectionInfoRef == nil {
+		return nil
+	}
+
+	credentials := &v1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: connection.Status.CredentialsRef.Name, Namespace: connection.Namespace}, credentials); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Credentials Secret of the connection not found, skip the service binding", "Secret", connection.Status.CredentialsRef.Name)
+			return nil
+		}
+		return err
+	}
+	connectionInfo := &v1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: connection.Namespace}, connectionInfo); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Connection info ConfigMap of the connection not found, skip the service binding", "ConfigMap", connection.Status.ConnectionInfoRef.Name)
+			return nil
+		}
+		return err
+	}
+
+	data := serviceBindingData(provider, credentials, connectionInfo)
+	secretType := v1.SecretTypeOpaque
+	if bindingType := data[bindingTypeKey]; len(bindingType) > 0 {
+		secretType = v1.SecretType(serviceBindingSecretTypePrefix + string(bindingType))
+	}
+
+	secret := &v1.Secret{}
+	secretKey := types.NamespacedName{Name: serviceBindingSecretName(connection.Name), Namespace: connection.Namespace}
+	if err := r.Get(ctx, secretKey, secret); err == nil {
+		// the type of a secret cannot be updated
+		if secret.Type != secretType {
+			if err := r.Client.Delete(ctx, secret); err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+	} else if !errors.IsNotFound(err) {
+		return err
+	}
+
+	secret = &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      secretKey.Name,
+			Namespace: secretKey.Namespace,
+		},
+	}
+	res, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		if secret.Labels == nil {
+			secret.Labels = map[string]string{}
+		}
+		secret.Labels["managed-by"] = "dbaas-operator"
+		secret.Type = secretType
+		secret.Data = data
+		secret.OwnerReferences = nil
+		return ctrl.SetControllerReference(connection, secret, r.Scheme)
+	})
+	if err != nil {
+		return err
+	}
+	if res != controllerutil.OperationResultNone {
+		logger.Info("Service binding Secret reconciled", "Secret", secret.Name, "result", res)
+	}
+
+	if connection.Status.Binding == nil || connection.Status.Binding.Name != secret.Name {
+		connection.Status.Binding = &v1.LocalObjectReference{Name: secret.Name}
+		return r.Client.Status().Update(ctx, connection)
+	}
+	return nil
+}
+
+// bindingSourceToConnections maps a secret or a config map to the connections publishing it in their status
+func (r *DBaaSConnectionReconciler) bindingSourceToConnections(obj client.Object) []reconcile.Request {
+	var connectionList v1beta1.DBaaSConnectionList
+	if err := r.List(context.Background(), &connectionList, client.InNamespace(obj.GetNamespace())); err != nil {
+		ctrl.Log.WithName("DBaaSConnectionReconciler").Error(err, "Error listing DBaaS Connections")
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range connectionList.Items {
+		status := connectionList.Items[i].Status
+		if (status.CredentialsRef != nil && status.CredentialsRef.Name == obj.GetName()) ||
+			(status.ConnectionInfoRef != nil && status.ConnectionInfoRef.Name == obj.GetName()) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&connectionList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// serviceBindingData merges the connection information and the credentials published by the provider,
+// and completes the entries required by the Service Binding specification
+func serviceBindingData(provider *v1beta1.DBaaSProvider, credentials *v1.Secret, connectionInfo *v1.ConfigMap) map[string][]byte {
+	data := map[string][]byte{}
+	for k, v := range connectionInfo.Data {
+		data[k] = []byte(v)
+	}
+	for k, v := range connectionInfo.BinaryData {
+		data[k] = v
+	}
+	for k, v := range credentials.Data {
+		data[k] = v
+	}
+	if len(data[bindingProviderKey]) == 0 && len(provider.Spec.Provider.Name) > 0 {
+		data[bindingProviderKey] = []byte(provider.Spec.Provider.Name)
+	}
+	if len(data[bindingURIKey]) == 0 {
+		if uri := serviceBindingURI(data); len(uri) > 0 {
+			data[bindingURIKey] = []byte(uri)
+		}
+	}
+	return data
+}
+
+// serviceBindingURI builds the connection URI of a database from its binding entries
+func serviceBindingURI(data map[string][]byte) string {
+	host := string(data[bindingHostKey])
+	if len(host) == 0 || len(data[bindingTypeKey]) == 0 {
+		return ""
+	}
+	scheme := string(data[bindingTypeKey])
+	if scheme == "mongodb" && string(data["srv"]) == "true" {
+		scheme = "mongodb+srv"
+	} else if port := string(data[bindingPortKey]); len(port) > 0 {
+		host = net.JoinHostPort(host, port)
+	}
+	uri := url.URL{
+		Scheme: scheme,
+		Host:   host,
+	}
+	if username := string(data[bindingUsernameKey]); len(username) > 0 {
+		if password, ok := data[bindingPasswordKey]; ok {
+			uri.User = url.UserPassword(username, string(password))
+		} else {
+			uri.User = url.User(username)
+		}
+	}
+	if database := string(data[bindingDatabaseKey]); len(database) > 0 {
+		uri.Path = "/" + database
+	}
+	return uri.String()
+}
+
+func serviceBindingSecretName(connectionName string) string {
+	return connectionName + "-binding"
+}
+
+// reconcileReachability schedules the probes of the database of a ready connection, run by the connection prober,
+// which reports whether it is reachable from the cluster in the DatabaseReachable condition. The probes are disabled if ProbeInterval is not set.
+func (r *DBaaSConnectionReconciler) reconcileReachability(ctx context.Context, connection *v1beta1.DBaaSConnection) error {
+	if r.prober == nil {
+		if apimeta.FindStatusCondition(connection.Status.Conditions, v1beta1.DBaaSConnectionReachableType) != nil {
+			apimeta.RemoveStatusCondition(&connection.Status.Conditions, v1beta1.DBaaSConnectionReachableType)
+			return r.Client.Status().Update(ctx, connection)
+		}
+		return nil
+	}
+	if apimeta.IsStatusConditionTrue(connection.Status.Conditions, v1beta1.DBaaSConnectionReadyType) &&
+		connection.Status.ConnectionInfoRef != nil {
+		r.prober.track(client.ObjectKeyFromObject(connection))
+	}
+	return nil
+}
+
+// shortestRequeue merges the results of the steps of a reconciliation, keeping the shortest requeue delay
+func shortestRequeue(results ...ctrl.Result) ctrl.Result {
+	var shortest ctrl.Result
+	for _, result := range results {
+		shortest.Requeue = shortest.Requeue || result.Requeue
+		if result.RequeueAfter > 0 && (shortest.RequeueAfter == 0 || result.RequeueAfter < shortest.RequeueAfter) {
+			shortest.RequeueAfter = result.RequeueAfter
+		}
+	}
+	return shortest
+}
+
+// boundWorkloadKinds are the kinds of workloads restarted when the credentials of a connection change
+var boundWorkloadKinds = []schema.GroupVersionKind{
+	appv1.SchemeGroupVersion.WithKind("DeploymentList"),
+	appv1.SchemeGroupVersion.WithKind("StatefulSetList"),
+}
+
+// reconcileRolloutRestart records a fingerprint of the credentials and connection information of the connection,
+// and restarts the workloads bound to the connection when their data changes, if the connection opted in.
+// Restarts are at least RolloutRestartInterval apart, a change within the interval is requeued.
+func (r *DBaaSConnectionReconciler) reconcileRolloutRestart(ctx context.Context, connection *v1beta1.DBaaSConnection) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if !apimeta.IsStatusConditionTrue(connection.Status.Conditions, v1beta1.DBaaSConnectionReadyType) ||
+		connection.Status.CredentialsRef == nil || connection.Status.ConnectionInfoRef == nil {
+		return ctrl.Result{}, nil
+	}
+
+	credentials := &v1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: connection.Status.CredentialsRef.Name, Namespace: connection.Namespace}, credentials); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	connectionInfo := &v1.ConfigMap{}
+	if err := r.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: connection.Namespace}, connectionInfo); err != nil {
+		if errors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	hash := dataHash(credentials.Data, configMapData(connectionInfo))
+	if connection.Status.CredentialsHash == hash {
+		return ctrl.Result{}, nil
+	}
+	// the first observed hash, or a change for a connection that did not opt in, is only recorded
+	if len(connection.Status.CredentialsHash) == 0 || connection.Annotations[v1beta1.RolloutOnCredentialsChangeAnnotation] != "true" {
+		connection.Status.CredentialsHash = hash
+		return ctrl.Result{}, r.Client.Status().Update(ctx, connection)
+	}
+
+	interval := r.RolloutRestartInterval
+	if interval <= 0 {
+		interval = DefaultRolloutRestartInterval
+	}
+	if last := connection.Status.LastRolloutRestart; last != nil {
+		if wait := interval - time.Since(last.Time); wait > 0 {
+			logger.Info("Credentials of the DBaaS Connection changed, delay the restart of the bound workloads", "RequeueAfter", wait)
+			return ctrl.Result{RequeueAfter: wait}, nil
+		}
+	}
+
+	now := metav1.Now()
+	if err := r.restartBoundWorkloads(ctx, connection, now); err != nil {
+		return ctrl.Result{}, err
+	}
+	connection.Status.CredentialsHash = hash
+	connection.Status.LastRolloutRestart = &now
+	return ctrl.Result{}, r.Client.Status().Update(ctx, connection)
+}
+
+// configMapData returns the data and binary data of a config map
+func configMapData(configMap *v1.ConfigMap) map[string][]byte {
+	data := make(map[string][]byte, len(configMap.Data)+len(configMap.BinaryData))
+	for key, value := range configMap.Data {
+		data[key] = []byte(value)
+	}
+	for key, value := range configMap.BinaryData {
+		data[key] = value
+	}
+	return data
+}
+
+// restartPatch returns the patch of a workload annotating its pod template to restart its pods, and the workload with the given annotations
+func restartPatch(restartedAt metav1.Time, annotations map[string]string) ([]byte, error) {
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						v1beta1.RestartedAtAnnotation: restartedAt.UTC().Format(time.RFC3339),
 					},
 				},
 			},
+		},
+	}
+	if len(annotations) > 0 {
+		patch["metadata"] = map[string]interface{}{"annotations": annotations}
+	}
+	return json.Marshal(patch)
+}
+
+// reconcileInjection restarts the Deployments labeled for the injection of a ready connection that were admitted
+// before the connection was ready, so the workload webhook injects the connection into their pods.
+// A Deployment is restarted once per version of the connection, recorded on the Deployment, in case the webhook does not inject it.
+func (r *DBaaSConnectionReconciler) reconcileInjection(ctx context.Context, connection *v1beta1.DBaaSConnection) error {
+	logger := ctrl.LoggerFrom(ctx)
+	if r.DisableInjection || connection.Status.CredentialsRef == nil || connection.Status.ConnectionInfoRef == nil {
+		return nil
+	}
+	version := fmt.Sprintf("%d/%s", connection.Generation, connection.Status.CredentialsHash)
+	var deployments appv1.DeploymentList
+	if err := r.List(ctx, &deployments, client.InNamespace(connection.Namespace), client.MatchingLabels{v1beta1.ConnectionInjectionLabel: connection.Name}); err != nil {
+		return err
+	}
+	var patch []byte
+	for i := range deployments.Items {
+		deployment := &deployments.Items[i]
+		if deployment.Spec.Template.Annotations[v1beta1.InjectedConnectionAnnotation] == connection.Name ||
+			deployment.Annotations[v1beta1.InjectionRestartedForAnnotation] == version {
+			continue
+		}
+		if patch == nil {
+			var err error
+			if patch, err = restartPatch(metav1.Now(), map[string]string{v1beta1.InjectionRestartedForAnnotation: version}); err != nil {
+				return err
+			}
+		}
+		if err := r.Patch(ctx, deployment, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			if errors.IsNotFound(err) {
+				continue
+			}
+			return err
 		}
-		deployment.OwnerReferences = nil
-		return ctrl.SetControllerReference(connection, deployment, r.Scheme)
+		logger.Info("Deployment restarted to inject the DBaaS Connection", "Deployment", deployment.Name)
 	}
+	return nil
+}
+
+// restartBoundWorkloads triggers a rollout of the workloads labeled with the connection, by annotating their pod template
+func (r *DBaaSConnectionReconciler) restartBoundWorkloads(ctx context.Context, connection *v1beta1.DBaaSConnection, restartedAt metav1.Time) error {
+	logger := ctrl.LoggerFrom(ctx)
+	patch, err := restartPatch(restartedAt, nil)
+	if err != nil {
+		return err
+	}
+	for _, gvk := range boundWorkloadKinds {
+		workloads := &metav1.PartialObjectMetadataList{}
+		workloads.SetGroupVersionKind(gvk)
+		if err := r.List(ctx, workloads, client.InNamespace(connection.Namespace), client.MatchingLabels{v1beta1.ConnectionInjectionLabel: connection.Name}); err != nil {
+			return err
+		}
+		for i := range workloads.Items {
+			workload := &workloads.Items[i]
+			if err := r.Patch(ctx, workload, client.RawPatch(types.MergePatchType, patch)); err != nil {
+				if errors.IsNotFound(err) {
+					continue
+				}
+				return err
+			}
+			logger.Info("Workload bound to the DBaaS Connection restarted", "Kind", workload.Kind, "Name", workload.Name)
+		}
+	}
+	return nil
 }
 
 // mergeConnectionStatus: merge the status from DBaaSProviderConnection into the current DBaaSConnection status
 func mergeConnectionStatus(conn *v1beta1.DBaaSConnection, providerConn *v1beta1.DBaaSProviderConnection) metav1.Condition {
-	providerConn.Status.DeepCopyInto(&conn.Status)
+	var operatorConds []metav1.Condition
+	for _, condType := range []string{v1beta1.DBaaSConnectionReachableType, v1beta1.DBaaSConnectionExpiredType} {
+		if cond := apimeta.FindStatusCondition(conn.Status.Conditions, condType); cond != nil {
+			operatorConds = append(operatorConds, *cond.DeepCopy())
+		}
+	}
+	providerConn.Status.DeepCopyInto(&conn.Status.DBaaSConnectionStatus)
+	// the reachability and expiry conditions are set by the DBaaS operator
+	for _, cond := range operatorConds {
+		apimeta.SetStatusCondition(&conn.Status.Conditions, cond)
+	}
 	// Update connection status condition (type: DBaaSConnectionReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerConn.Status.Conditions, v1beta1.DBaaSConnectionProviderSyncType)
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
@@ -269,6 +938,8 @@ func (r *DBaaSConnectionReconciler) Delete(e event.DeleteEvent) error {
 
 	log.Info("Calling metrics for deleting of DBaaSConnection")
 	metrics.SetConnectionMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, *connectionObj, execution, metrics.LabelEventValueDelete, metricLabelErrCdValue)
+	metrics.DeleteConnectionProbeMetrics(*connectionObj)
+	metrics.DeleteConnectionExpiryMetrics(*connectionObj)
 
 	return nil
 
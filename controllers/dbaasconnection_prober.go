@@ -0,0 +1,181 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/probe"
+)
+
+// connectionProbeWorkers is the number of connections probed concurrently
+const connectionProbeWorkers = 4
+
+// connectionProber probes the databases of the ready connections on its own workers, every interval, and reports whether
+// they are reachable in the DatabaseReachable condition, so a slow or unreachable database does not hold the workers of the
+// connection controller. A connection is probed until it is deleted or no longer ready, and tracked again by the controller.
+type connectionProber struct {
+	client.Client
+	interval time.Duration
+	timeout  time.Duration
+	queue    workqueue.DelayingInterface
+
+	mu      sync.Mutex
+	tracked map[types.NamespacedName]bool
+}
+
+var _ manager.Runnable = &connectionProber{}
+
+func newConnectionProber(c client.Client, interval, timeout time.Duration) *connectionProber {
+	if timeout <= 0 {
+		timeout = DefaultProbeTimeout
+	}
+	return &connectionProber{
+		Client:   c,
+		interval: interval,
+		timeout:  timeout,
+		queue:    workqueue.NewNamedDelayingQueue("connection-prober"),
+		tracked:  map[types.NamespacedName]bool{},
+	}
+}
+
+// track schedules the probes of a connection, if they are not scheduled yet
+func (p *connectionProber) track(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.tracked[key] {
+		p.tracked[key] = true
+		p.queue.Add(key)
+	}
+}
+
+func (p *connectionProber) untrack(key types.NamespacedName) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tracked, key)
+}
+
+// Start runs the workers of the prober until the context is done
+func (p *connectionProber) Start(ctx context.Context) error {
+	var wg sync.WaitGroup
+	for i := 0; i < connectionProbeWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for p.processNext(ctx) {
+			}
+		}()
+	}
+	<-ctx.Done()
+	p.queue.ShutDown()
+	wg.Wait()
+	return nil
+}
+
+func (p *connectionProber) processNext(ctx context.Context) bool {
+	item, shutdown := p.queue.Get()
+	if shutdown {
+		return false
+	}
+	defer p.queue.Done(item)
+	key := item.(types.NamespacedName)
+	logger := ctrl.Log.WithName("connection-prober").WithValues("DBaaS Connection", key)
+
+	keep, err := p.probeConnection(ctx, key)
+	if err != nil {
+		logger.Error(err, "Error probing the database of the DBaaS Connection")
+	}
+	if keep {
+		p.queue.AddAfter(key, p.interval)
+	} else {
+		p.untrack(key)
+	}
+	return true
+}
+
+// probeConnection probes the database of a connection and updates its DatabaseReachable condition,
+// it returns false if the connection is no longer probed
+func (p *connectionProber) probeConnection(ctx context.Context, key types.NamespacedName) (bool, error) {
+	connection := &v1beta1.DBaaSConnection{}
+	if err := p.Get(ctx, key, connection); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return true, err
+	}
+	if connection.DeletionTimestamp != nil || connection.Status.ConnectionInfoRef == nil ||
+		!apimeta.IsStatusConditionTrue(connection.Status.Conditions, v1beta1.DBaaSConnectionReadyType) {
+		return false, nil
+	}
+	connectionInfo := &v1.ConfigMap{}
+	if err := p.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: connection.Namespace}, connectionInfo); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return true, err
+	}
+	var providerName string
+	inventory := &v1beta1.DBaaSInventory{}
+	if err := p.Get(ctx, refKey(connection.Spec.InventoryRef, connection.Namespace), inventory); err == nil {
+		providerName = inventory.Spec.ProviderRef.Name
+	}
+
+	cond := metav1.Condition{
+		Type:    v1beta1.DBaaSConnectionReachableType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.Ready,
+		Message: v1beta1.MsgDatabaseReachable,
+	}
+	target, err := probe.TargetFromConnectionInfo(connectionInfo.Data)
+	if err == nil {
+		probeCtx, cancel := context.WithTimeout(ctx, p.timeout)
+		var latency time.Duration
+		latency, err = probe.Probe(probeCtx, target)
+		cancel()
+		metrics.SetConnectionProbeMetrics(providerName, *connection, latency, err == nil)
+	}
+	if err != nil {
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = v1beta1.DBaaSDatabaseUnreachable
+		cond.Message = err.Error()
+	}
+
+	if current := apimeta.FindStatusCondition(connection.Status.Conditions, cond.Type); current != nil &&
+		current.Status == cond.Status && current.Reason == cond.Reason && current.Message == cond.Message {
+		return true, nil
+	}
+	apimeta.SetStatusCondition(&connection.Status.Conditions, cond)
+	if err := p.Status().Update(ctx, connection); err != nil && !errors.IsConflict(err) {
+		return true, err
+	}
+	// on a conflict, the condition is updated by the next probe
+	return true, nil
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// DBaaSDatabaseReconciler reconciles a DBaaSDatabase object
+type DBaaSDatabaseReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+
+// Reconcile creates the provider database object of a DBaaSDatabase, for the provider of the referenced instance,
+// and syncs its status. The namespace of the database must be a valid connection namespace for the inventory of the instance.
+func (r *DBaaSDatabaseReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var database v1beta1.DBaaSDatabase
+	if err := r.Get(ctx, req.NamespacedName, &database); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Database resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Database for reconcile")
+		return ctrl.Result{}, err
+	}
+	if database.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	instance, err := r.getInstance(ctx, database.Spec.DatabaseServiceRef, database.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "DBaaS Instance resource not found for DBaaS Database", "DBaaS Database", database.Name)
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &database, &database.Status.Conditions, databaseNotReady(v1beta1.DBaaSServiceNotAvailable, err.Error()))
+		}
+		logger.Error(err, "Error fetching the DBaaS Instance of the DBaaS Database", "DBaaS Database", database.Name)
+		return ctrl.Result{}, err
+	}
+
+	inventory, validNS, _, err := r.checkInventory(ctx, instance.Spec.InventoryRef, &database, func(reason string, message string) {
+		apimeta.SetStatusCondition(&database.Status.Conditions, databaseNotReady(reason, message))
+	}, logger)
+	if err != nil || !validNS {
+		return ctrl.Result{}, err
+	}
+	if len(instance.Status.InstanceID) == 0 {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &database, &database.Status.Conditions, databaseNotReady(v1beta1.DBaaSServiceNotAvailable, "instance ID is not available"))
+	}
+
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	// the provider database objects are only defined by the v1beta1 API
+	if len(provider.Spec.DatabaseKind) == 0 || r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &database, &database.Status.Conditions, databaseNotReady(v1beta1.DBaaSDatabaseNotSupported, v1beta1.MsgDatabaseNotSupported))
+	}
+
+	return r.reconcileProviderResource(ctx,
+		inventory.Spec.ProviderRef.Name,
+		&database,
+		func(provider *v1beta1.DBaaSProvider) string {
+			return provider.Spec.DatabaseKind
+		},
+		func() interface{} {
+			return &v1beta1.DBaaSProviderDatabaseSpec{
+				DBaaSDatabaseSpec: *database.Spec.DeepCopy(),
+				InventoryRef:      instance.Spec.InventoryRef,
+				DatabaseServiceID: instance.Status.InstanceID,
+			}
+		},
+		func() interface{} {
+			return &v1beta1.DBaaSProviderDatabase{}
+		},
+		func(i interface{}) metav1.Condition {
+			return mergeDatabaseStatus(&database, i.(*v1beta1.DBaaSProviderDatabase))
+		},
+		func() *[]metav1.Condition {
+			return &database.Status.Conditions
+		},
+		v1beta1.DBaaSDatabaseReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSDatabaseReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSDatabase{}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instanceToDatabases))
+	return r.watchConnectionNamespaces(bldr, r.databaseInventoryUsers).
+		Build(r)
+}
+
+// instanceToDatabases maps an instance to the databases referencing it
+func (r *DBaaSDatabaseReconciler) instanceToDatabases(obj client.Object) []reconcile.Request {
+	var databaseList v1beta1.DBaaSDatabaseList
+	if err := r.List(context.Background(), &databaseList); err != nil {
+		ctrl.Log.WithName("DBaaSDatabaseReconciler").Error(err, "Error listing the DBaaS Databases referencing an instance", "DBaaS Instance", objectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range databaseList.Items {
+		if refKey(databaseList.Items[i].Spec.DatabaseServiceRef, databaseList.Items[i].Namespace) == client.ObjectKeyFromObject(obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&databaseList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// databaseInventoryUsers lists the databases with the inventories of the instances they reference
+func (r *DBaaSDatabaseReconciler) databaseInventoryUsers(ctx context.Context) ([]inventoryUser, error) {
+	var databaseList v1beta1.DBaaSDatabaseList
+	if err := r.List(ctx, &databaseList); err != nil {
+		return nil, err
+	}
+	inventories, err := r.instanceInventories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var users []inventoryUser
+	for i := range databaseList.Items {
+		if inventory, ok := inventories[refKey(databaseList.Items[i].Spec.DatabaseServiceRef, databaseList.Items[i].Namespace)]; ok {
+			users = append(users, inventoryUser{key: client.ObjectKeyFromObject(&databaseList.Items[i]), inventory: inventory})
+		}
+	}
+	return users, nil
+}
+
+func databaseNotReady(reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSDatabaseReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// mergeDatabaseStatus: merge the status from DBaaSProviderDatabase into the current DBaaSDatabase status
+func mergeDatabaseStatus(database *v1beta1.DBaaSDatabase, providerDatabase *v1beta1.DBaaSProviderDatabase) metav1.Condition {
+	providerDatabase.Status.DeepCopyInto(&database.Status)
+	// Update database status condition (type: DBaaSDatabaseReadyType) based on the provider status
+	specSync := apimeta.FindStatusCondition(providerDatabase.Status.Conditions, v1beta1.DBaaSDatabaseProviderSyncType)
+	if specSync != nil && specSync.Status == metav1.ConditionTrue {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSDatabaseReadyType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.Ready,
+			Message: v1beta1.MsgProviderCRStatusSyncDone,
+		}
+	}
+	if specSync != nil {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSDatabaseReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.ProviderReconcileError,
+			Message: fmt.Sprintf("%s: %s", specSync.Reason, specSync.Message),
+		}
+	}
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSDatabaseReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.ProviderReconcileInprogress,
+		Message: v1beta1.MsgProviderCRReconcileInProgress,
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("DBaaSDatabase controller with errors", func() {
+	Context("after creating DBaaSDatabase without instance", func() {
+		createdDBaaSDatabase := &v1beta1.DBaaSDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-database-no-instance",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSDatabaseSpec{
+				DatabaseServiceRef: v1beta1.NamespacedName{Name: "test-instance-no-exist-ref"},
+				Databases:          []v1beta1.LogicalDatabase{{Name: "orders"}},
+			},
+		}
+
+		BeforeEach(assertResourceCreation(createdDBaaSDatabase))
+		AfterEach(assertResourceDeletion(createdDBaaSDatabase))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSDatabase, metav1.ConditionFalse, v1beta1.DBaaSServiceNotAvailable))
+	})
+
+	Context("after creating DBaaSDatabase for an instance without inventory", func() {
+		createdDBaaSInstance := &v1beta1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-database-instance-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSInstanceSpec{
+				InventoryRef: v1beta1.NamespacedName{
+					Name:      "test-inventory-no-exist-ref",
+					Namespace: testNamespace,
+				},
+				ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+					v1beta1.ProvisioningName:          "test-instance",
+					v1beta1.ProvisioningCloudProvider: "aws",
+					v1beta1.ProvisioningRegions:       "test-region",
+					v1beta1.ProvisioningPlan:          v1beta1.ProvisioningPlanFreeTrial,
+				},
+			},
+		}
+		createdDBaaSDatabase := &v1beta1.DBaaSDatabase{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-database-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSDatabaseSpec{
+				DatabaseServiceRef: v1beta1.NamespacedName{Name: createdDBaaSInstance.Name},
+				Databases:          []v1beta1.LogicalDatabase{{Name: "orders"}},
+			},
+		}
+
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		BeforeEach(assertResourceCreation(createdDBaaSDatabase))
+		AfterEach(assertResourceDeletion(createdDBaaSDatabase))
+		AfterEach(assertResourceDeletion(createdDBaaSInstance))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSDatabase, metav1.ConditionFalse, v1beta1.DBaaSInventoryNotFound))
+	})
+})
@@ -18,14 +18,23 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -33,6 +42,9 @@ import (
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
 )
 
+// instanceDeletionRequeueDelay is the delay between checks of a provider instance that is being deleted
+const instanceDeletionRequeueDelay = 30 * time.Second
+
 // DBaaSInstanceReconciler reconciles a DBaaSInstance object
 type DBaaSInstanceReconciler struct {
 	*DBaaSReconciler
@@ -53,7 +65,6 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	logger := ctrl.LoggerFrom(ctx)
 	var instance v1beta1.DBaaSInstance
 	metricLabelErrCdValue := ""
-	event := ""
 
 	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
 		if errors.IsNotFound(err) {
@@ -68,10 +79,30 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 
 	if instance.DeletionTimestamp != nil {
-		event = metrics.LabelEventValueDelete
-	} else {
-		event = metrics.LabelEventValueCreate
+		inventory := &v1beta1.DBaaSInventory{}
+		defer func() {
+			metrics.SetInstanceMetrics(inventory.Spec.ProviderRef.Name, inventory.Name, instance, execution, metrics.LabelEventValueDelete, metricLabelErrCdValue)
+		}()
+		if !controllerutil.ContainsFinalizer(&instance, v1beta1.DBaaSInstanceFinalizer) {
+			return ctrl.Result{}, nil
+		}
+		if err := r.Get(ctx, types.NamespacedName{Namespace: instance.Spec.InventoryRef.Namespace, Name: instance.Spec.InventoryRef.Name}, inventory); err != nil {
+			if !errors.IsNotFound(err) {
+				logger.Error(err, "Error fetching the DBaaS Inventory of the DBaaS Instance for deletion", "DBaaS Instance", instance.Name)
+				metricLabelErrCdValue = metrics.LabelErrorCdValueErrorCheckingInstanceInventory
+				return ctrl.Result{}, err
+			}
+			// the provider instance cannot be found without the inventory, the instance is only released once the inventory is restored
+			logger.Info("DBaaS Inventory not found, cannot release DBaaS Instance", "DBaaS Instance", instance.Name)
+			return r.setDeletionBlocked(ctx, &instance, v1beta1.DBaaSInventoryNotFound, err.Error())
+		}
+		result, err := r.finalizeInstance(ctx, &instance, inventory)
+		if err != nil {
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorDeletingInstance
+		}
+		return result, err
 	}
+	event := metrics.LabelEventValueCreate
 
 	if inventory, validNS, provision, err := r.checkInventory(ctx, instance.Spec.InventoryRef, &instance, func(reason string, message string) {
 		cond := metav1.Condition{
@@ -103,6 +134,44 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 				return ctrl.Result{}, err
 			}
 		}
+		if !provider.GetCapabilities().SupportsProvisioning() {
+			instance.Status.Phase = v1beta1.InstancePhaseError
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &instance, &instance.Status.Conditions, metav1.Condition{
+				Type:    v1beta1.DBaaSInstanceReadyType,
+				Status:  metav1.ConditionFalse,
+				Reason:  v1beta1.DBaaSProvisioningNotSupported,
+				Message: v1beta1.MsgProvisioningNotSupported,
+			})
+		}
+		cloneSource, notReady, err := r.getInstanceSource(ctx, &instance, provider)
+		if err != nil {
+			logger.Error(err, "Error resolving the source of the DBaaS Instance", "DBaaS Instance", instance.Name)
+			return ctrl.Result{}, err
+		}
+		if notReady != nil {
+			logger.Info("DBaaS Instance cannot be cloned from its source", "DBaaS Instance", instance.Name, "reason", notReady.Reason)
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &instance, &instance.Status.Conditions, *notReady)
+		}
+		if updated, err := r.reconcileFinalizer(ctx, &instance); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Instance modified, retry reconciling finalizer", "DBaaS Instance", instance)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error reconciling the DBaaS Instance finalizer", "DBaaS Instance", instance)
+			return ctrl.Result{}, err
+		} else if updated {
+			logger.Info("DBaaS Instance finalizer reconciled", "DBaaS Instance", instance.Name, "deletionPolicy", instance.Spec.DeletionPolicy)
+		}
+		if updated, err := r.reconcileUpdateRequest(ctx, &instance, provider); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Instance modified, retry requesting the update", "DBaaS Instance", instance)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error requesting the update of the DBaaS Instance", "DBaaS Instance", instance)
+			return ctrl.Result{}, err
+		} else if updated {
+			logger.Info("DBaaS Instance update requested", "DBaaS Instance", instance.Name)
+		}
 		result, err := r.reconcileProviderResource(ctx,
 			inventory.Spec.ProviderRef.Name,
 			&instance,
@@ -117,7 +186,11 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					}
 					return spec
 				}
-				return instance.Spec.DeepCopy()
+				return &v1beta1.DBaaSProviderInstanceSpec{
+					DBaaSInstanceSpec: *instance.Spec.DeepCopy(),
+					SourceInstanceID:  cloneSource.instanceID,
+					SourceBackupID:    cloneSource.backupID,
+				}
 			},
 			func() interface{} {
 				if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
@@ -133,7 +206,15 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 					return mergeInstanceStatus(&instance, providerInsV1beta1)
 				}
 				providerIns := i.(*v1beta1.DBaaSProviderInstance)
-				return mergeInstanceStatus(&instance, providerIns)
+				updateCond := apimeta.FindStatusCondition(instance.Status.Conditions, v1beta1.DBaaSInstanceUpdateType)
+				if updateCond != nil {
+					updateCond = updateCond.DeepCopy()
+				}
+				cond := mergeInstanceStatus(&instance, providerIns)
+				if updateCond = progressUpdateCondition(updateCond, providerIns); updateCond != nil {
+					apimeta.SetStatusCondition(&instance.Status.Conditions, *updateCond)
+				}
+				return cond
 			},
 			func() *[]metav1.Condition {
 				return &instance.Status.Conditions
@@ -151,15 +232,126 @@ func (r *DBaaSInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSInstanceReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
-	return ctrl.NewControllerManagedBy(mgr).
+	bldr := ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSInstance{}).
 		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.sourceToClones)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSBackup{}}, handler.EnqueueRequestsFromMapFunc(r.sourceToClones))
+	return r.watchConnectionNamespaces(bldr, r.instanceInventoryUsers).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
+// sourceToClones maps an instance or a backup to the instances cloned from it
+func (r *DBaaSInstanceReconciler) sourceToClones(obj client.Object) []reconcile.Request {
+	var instanceList v1beta1.DBaaSInstanceList
+	if err := r.List(context.Background(), &instanceList); err != nil {
+		ctrl.Log.WithName("DBaaSInstanceReconciler").Error(err, "Error listing the DBaaS Instances cloned from a source", "Source", objectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range instanceList.Items {
+		clone := &instanceList.Items[i]
+		if clone.Spec.Source == nil {
+			continue
+		}
+		sourceRef := clone.Spec.Source.InstanceRef
+		if _, ok := obj.(*v1beta1.DBaaSBackup); ok {
+			sourceRef = clone.Spec.Source.BackupRef
+		}
+		if sourceRef != nil && refKey(*sourceRef, clone.Namespace) == client.ObjectKeyFromObject(obj) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(clone)})
+		}
+	}
+	return requests
+}
+
+// instanceInventoryUsers lists the instances with the inventories they reference
+func (r *DBaaSInstanceReconciler) instanceInventoryUsers(ctx context.Context) ([]inventoryUser, error) {
+	var instanceList v1beta1.DBaaSInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, err
+	}
+	users := make([]inventoryUser, len(instanceList.Items))
+	for i := range instanceList.Items {
+		users[i] = inventoryUser{key: client.ObjectKeyFromObject(&instanceList.Items[i]), inventory: instanceList.Items[i].Spec.InventoryRef}
+	}
+	return users, nil
+}
+
+// instanceSource holds the provider identifiers of the source of a cloned instance
+type instanceSource struct {
+	instanceID string
+	backupID   string
+}
+
+// getInstanceSource resolves the provider identifiers of the source of a cloned instance, empty if the instance is not a clone.
+// The identifiers already passed to the provider instance are kept, so that a clone no longer depends on its source once created.
+// It returns the not ready condition of the instance, with its phase set, if the instance cannot be cloned from its source yet.
+func (r *DBaaSInstanceReconciler) getInstanceSource(ctx context.Context, instance *v1beta1.DBaaSInstance, provider *v1beta1.DBaaSProvider) (*instanceSource, *metav1.Condition, error) {
+	if instance.Spec.Source == nil {
+		return &instanceSource{}, nil, nil
+	}
+	notReady := func(phase v1beta1.DBaasInstancePhase, reason, message string) (*instanceSource, *metav1.Condition, error) {
+		instance.Status.Phase = phase
+		return nil, &metav1.Condition{
+			Type:    v1beta1.DBaaSInstanceReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  reason,
+			Message: message,
+		}, nil
+	}
+	if !provider.GetCapabilities().SupportsCloning() {
+		return notReady(v1beta1.InstancePhaseError, v1beta1.DBaaSCloningNotSupported, v1beta1.MsgCloningNotSupported)
+	}
+
+	providerObject := r.createProviderObject(instance, provider.GetDBaaSAPIGroupVersion(), provider.Spec.InstanceKind)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject); err == nil {
+		if instanceID, _, _ := unstructured.NestedString(providerObject.Object, "spec", "sourceInstanceID"); len(instanceID) > 0 {
+			backupID, _, _ := unstructured.NestedString(providerObject.Object, "spec", "sourceBackupID")
+			return &instanceSource{instanceID: instanceID, backupID: backupID}, nil, nil
+		}
+	} else if !errors.IsNotFound(err) {
+		return nil, nil, err
+	}
+
+	cloneSource := &instanceSource{}
+	sourceRef := instance.Spec.Source.InstanceRef
+	namespace := instance.Namespace
+	if instance.Spec.Source.BackupRef != nil {
+		var backup v1beta1.DBaaSBackup
+		if err := r.Get(ctx, refKey(*instance.Spec.Source.BackupRef, instance.Namespace), &backup); err != nil {
+			if errors.IsNotFound(err) {
+				return notReady(v1beta1.InstancePhasePending, v1beta1.DBaaSCloneSourceNotReady, err.Error())
+			}
+			return nil, nil, err
+		}
+		if backup.Status.Phase != v1beta1.BackupPhaseCompleted || len(backup.Status.BackupID) == 0 {
+			return notReady(v1beta1.InstancePhasePending, v1beta1.DBaaSCloneSourceNotReady, v1beta1.MsgBackupNotCompleted)
+		}
+		cloneSource.backupID = backup.Status.BackupID
+		sourceRef = &backup.Spec.InstanceRef
+		namespace = backup.Namespace
+	}
+	sourceInstance, err := r.getInstance(ctx, *sourceRef, namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			return notReady(v1beta1.InstancePhasePending, v1beta1.DBaaSCloneSourceNotReady, err.Error())
+		}
+		return nil, nil, err
+	}
+	if sourceInstance.Spec.InventoryRef != instance.Spec.InventoryRef {
+		return notReady(v1beta1.InstancePhaseError, v1beta1.DBaaSInvalidCloneSource, v1beta1.MsgInvalidCloneSource)
+	}
+	if len(sourceInstance.Status.InstanceID) == 0 {
+		return notReady(v1beta1.InstancePhasePending, v1beta1.DBaaSCloneSourceNotReady, v1beta1.MsgCloneSourceNotReady)
+	}
+	cloneSource.instanceID = sourceInstance.Status.InstanceID
+	return cloneSource, nil, nil
+}
+
 // mergeInstanceStatus: merge the status from DBaaSProviderInstance into the current DBaaSInstance status
 func mergeInstanceStatus(instance *v1beta1.DBaaSInstance, providerInst *v1beta1.DBaaSProviderInstance) metav1.Condition {
 	providerInst.Status.DeepCopyInto(&instance.Status)
@@ -184,6 +376,209 @@ func mergeInstanceStatus(instance *v1beta1.DBaaSInstance, providerInst *v1beta1.
 	}
 }
 
+// reconcileUpdateRequest compares the provisioning parameters of an instance with the ones of the provider instance, before they are synced.
+// A change of the parameters of a provisioned instance is tracked with the UpdateInProgress condition, listing the old and new values.
+func (r *DBaaSInstanceReconciler) reconcileUpdateRequest(ctx context.Context, instance *v1beta1.DBaaSInstance, provider *v1beta1.DBaaSProvider) (bool, error) {
+	// the provisioning parameters are only passed as such to v1beta1 providers
+	if r.getProviderSpecStatusVersion(provider).String() == v1alpha1.GroupVersion.String() {
+		return false, nil
+	}
+	providerObject := r.createProviderObject(instance, provider.GetDBaaSAPIGroupVersion(), provider.Spec.InstanceKind)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject); err != nil {
+		if errors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	applied, _, err := unstructured.NestedStringMap(providerObject.Object, "spec", "provisioningParameters")
+	if err != nil {
+		return false, err
+	}
+	changes := provisioningParameterChanges(applied, instance.Spec.ProvisioningParameters)
+	if len(changes) == 0 {
+		return false, nil
+	}
+
+	message := "Updating provisioning parameters: " + strings.Join(changes, ", ")
+	for key := range instance.Spec.ProvisioningParameters {
+		if provider.Spec.ProvisioningParameters[key].Mutability == v1beta1.ParameterRequiresRestart && applied[string(key)] != instance.Spec.ProvisioningParameters[key] {
+			message += ", the database instance is restarted"
+			break
+		}
+	}
+	apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInstanceUpdateType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.DBaaSUpdateRequested,
+		Message: message,
+	})
+	return true, r.Client.Status().Update(ctx, instance)
+}
+
+// provisioningParameterChanges lists the changed provisioning parameters, with their old and new values
+func provisioningParameterChanges(applied map[string]string, values map[v1beta1.ProvisioningParameterType]string) []string {
+	var changes []string
+	for key, value := range values {
+		if oldValue, ok := applied[string(key)]; !ok || oldValue != value {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", key, oldValue, value))
+		}
+	}
+	for key, oldValue := range applied {
+		if _, ok := values[v1beta1.ProvisioningParameterType(key)]; !ok {
+			changes = append(changes, fmt.Sprintf("%s: %q -> %q", key, oldValue, ""))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+// progressUpdateCondition follows the update of a provider instance through its phase.
+// The update completes when the provider reports the instance as ready after updating it, or after observing
+// the generation of the provider instance carrying the updated parameters.
+func progressUpdateCondition(cond *metav1.Condition, providerInst *v1beta1.DBaaSProviderInstance) *metav1.Condition {
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return cond
+	}
+	switch providerInst.Status.Phase {
+	case v1beta1.InstancePhaseUpdating:
+		cond.Reason = v1beta1.DBaaSUpdating
+	case v1beta1.InstancePhaseError, v1beta1.InstancePhaseFailed:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = v1beta1.DBaaSUpdateFailed
+	case v1beta1.InstancePhaseReady:
+		// a provider may update the instance without reporting the Updating phase in between,
+		// the update is then completed once the provider has synced the updated spec of the provider instance
+		specSync := apimeta.FindStatusCondition(providerInst.Status.Conditions, v1beta1.DBaaSInstanceProviderSyncType)
+		if cond.Reason == v1beta1.DBaaSUpdating ||
+			(specSync != nil && specSync.Status == metav1.ConditionTrue && specSync.ObservedGeneration >= providerInst.Generation) {
+			cond.Status = metav1.ConditionFalse
+			cond.Reason = v1beta1.DBaaSUpdateCompleted
+		}
+	}
+	return cond
+}
+
+// reconcileFinalizer adds the finalizer applying the deletion policy to an instance
+func (r *DBaaSInstanceReconciler) reconcileFinalizer(ctx context.Context, instance *v1beta1.DBaaSInstance) (bool, error) {
+	if !controllerutil.AddFinalizer(instance, v1beta1.DBaaSInstanceFinalizer) {
+		return false, nil
+	}
+	return true, r.Update(ctx, instance)
+}
+
+// finalizeInstance applies the deletion policy to the provider instance, and removes the finalizer once the provider instance is released
+func (r *DBaaSInstanceReconciler) finalizeInstance(ctx context.Context, instance *v1beta1.DBaaSInstance, inventory *v1beta1.DBaaSInventory) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("DBaaS Provider not found, cannot release DBaaS Instance", "DBaaS Instance", instance.Name)
+			return r.setDeletionBlocked(ctx, instance, v1beta1.DBaaSProviderNotFound, err.Error())
+		}
+		logger.Error(err, "Error fetching the DBaaS Provider for deletion", "DBaaS Instance", instance.Name)
+		return ctrl.Result{}, err
+	}
+	providerObject := r.createProviderObject(instance, provider.GetDBaaSAPIGroupVersion(), provider.Spec.InstanceKind)
+	if err := r.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Info("Provider instance not found, releasing DBaaS Instance", "DBaaS Instance", instance.Name)
+			return r.removeFinalizer(ctx, instance)
+		}
+		logger.Error(err, "Error fetching the Provider instance for deletion", "DBaaS Instance", instance.Name)
+		return ctrl.Result{}, err
+	}
+
+	if owns, err := isOwner(instance, providerObject, r.Scheme); err != nil {
+		return ctrl.Result{}, err
+	} else if !owns {
+		logger.Info("Provider instance is not owned by the DBaaS Instance, releasing DBaaS Instance", "DBaaS Instance", instance.Name)
+		return r.removeFinalizer(ctx, instance)
+	}
+
+	if instance.Spec.DeletionPolicy == v1beta1.DeletionPolicyRetain {
+		var ownerRefs []metav1.OwnerReference
+		for _, ref := range providerObject.GetOwnerReferences() {
+			if ref.UID != instance.UID {
+				ownerRefs = append(ownerRefs, ref)
+			}
+		}
+		providerObject.SetOwnerReferences(ownerRefs)
+		if err := r.Update(ctx, providerObject); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("Provider instance modified, retry detaching", "Provider Object", providerObject)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error detaching the Provider instance", "Provider Object", providerObject)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Provider instance detached and retained", "Provider Object", providerObject.GetName())
+		return r.removeFinalizer(ctx, instance)
+	}
+
+	if phase, _, _ := unstructured.NestedString(providerObject.Object, "status", "phase"); phase == string(v1beta1.InstancePhaseDeleted) {
+		logger.Info("Provider instance deleted, releasing DBaaS Instance", "DBaaS Instance", instance.Name)
+		return r.removeFinalizer(ctx, instance)
+	}
+
+	if providerObject.GetDeletionTimestamp() == nil {
+		if err := r.Client.Delete(ctx, providerObject); err != nil && !errors.IsNotFound(err) {
+			logger.Error(err, "Error deleting the Provider instance", "Provider Object", providerObject)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Provider instance deletion requested", "Provider Object", providerObject.GetName())
+		if err := r.Get(ctx, client.ObjectKeyFromObject(providerObject), providerObject); err != nil {
+			if errors.IsNotFound(err) {
+				return r.removeFinalizer(ctx, instance)
+			}
+			return ctrl.Result{}, err
+		}
+	}
+
+	// a provider that does not report the deletion of its instances is not waited for
+	if !provider.GetCapabilities().SupportsDeletion() {
+		logger.Info("Provider does not report instance deletions, releasing DBaaS Instance", "DBaaS Instance", instance.Name)
+		return r.removeFinalizer(ctx, instance)
+	}
+
+	return r.setDeletionBlocked(ctx, instance, v1beta1.ProviderDeletionInprogress, v1beta1.MsgProviderCRDeletionInProgress)
+}
+
+// setDeletionBlocked reports why an instance being deleted is not released yet, and checks it again after a delay
+func (r *DBaaSInstanceReconciler) setDeletionBlocked(ctx context.Context, instance *v1beta1.DBaaSInstance, reason, message string) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInstanceReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	instance.Status.Phase = v1beta1.InstancePhaseDeleting
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Instance modified, retry syncing status", "DBaaS Instance", instance)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Instance status", "DBaaS Instance", instance)
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: instanceDeletionRequeueDelay}, nil
+}
+
+func (r *DBaaSInstanceReconciler) removeFinalizer(ctx context.Context, instance *v1beta1.DBaaSInstance) (ctrl.Result, error) {
+	if controllerutil.RemoveFinalizer(instance, v1beta1.DBaaSInstanceFinalizer) {
+		if err := r.Update(ctx, instance); err != nil {
+			if errors.IsConflict(err) {
+				return ctrl.Result{Requeue: true}, nil
+			}
+			if !errors.IsNotFound(err) {
+				return ctrl.Result{}, err
+			}
+		}
+	}
+	return ctrl.Result{}, nil
+}
+
 // Delete implements a handler for the Delete event.
 func (r *DBaaSInstanceReconciler) Delete(e event.DeleteEvent) error {
 	execution := metrics.PlatformInstallStart()
@@ -17,11 +17,19 @@ limitations under the License.
 package controllers
 
 import (
+	"time"
+
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 )
@@ -311,11 +319,97 @@ var _ = Describe("DBaaSInstance controller - nominal", func() {
 					}
 					It("should update provider instance spec", assertProviderResourceSpecUpdated(createdDBaaSInstance, mongoProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec))
 				})
+
+				Context("when updating DBaaSInstance provisioning parameters", func() {
+					It("should track the update of the provider instance", func() {
+						assertProviderResourceCreated(createdDBaaSInstance, mongoProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec)()
+						setProviderInstancePhase(createdDBaaSInstance, v1beta1.InstancePhaseReady)
+
+						By("updating the provisioning parameters")
+						Eventually(func() error {
+							if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance); err != nil {
+								return err
+							}
+							createdDBaaSInstance.Spec.ProvisioningParameters[v1beta1.ProvisioningRegions] = "updated-test-region"
+							return dRec.Update(ctx, createdDBaaSInstance)
+						}, timeout).Should(Succeed())
+						assertInstanceUpdateCondition(createdDBaaSInstance, metav1.ConditionTrue, v1beta1.DBaaSUpdateRequested,
+							`regions: "test-region" -> "updated-test-region"`)
+
+						By("reporting the progress of the provider update")
+						setProviderInstancePhase(createdDBaaSInstance, v1beta1.InstancePhaseUpdating)
+						assertInstanceUpdateCondition(createdDBaaSInstance, metav1.ConditionTrue, v1beta1.DBaaSUpdating, "")
+						setProviderInstancePhase(createdDBaaSInstance, v1beta1.InstancePhaseReady)
+						assertInstanceUpdateCondition(createdDBaaSInstance, metav1.ConditionFalse, v1beta1.DBaaSUpdateCompleted, "")
+					})
+
+					It("should complete an update synced by the provider without an Updating phase", func() {
+						assertProviderResourceCreated(createdDBaaSInstance, mongoProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec)()
+						setProviderInstancePhase(createdDBaaSInstance, v1beta1.InstancePhaseReady)
+
+						By("updating the provisioning parameters")
+						Eventually(func() error {
+							if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance); err != nil {
+								return err
+							}
+							createdDBaaSInstance.Spec.ProvisioningParameters[v1beta1.ProvisioningRegions] = "other-test-region"
+							return dRec.Update(ctx, createdDBaaSInstance)
+						}, timeout).Should(Succeed())
+						assertInstanceUpdateCondition(createdDBaaSInstance, metav1.ConditionTrue, v1beta1.DBaaSUpdateRequested, "other-test-region")
+
+						By("reporting the updated generation of the provider instance")
+						Eventually(func(g Gomega) {
+							providerInstance := &unstructured.Unstructured{}
+							providerInstance.SetGroupVersionKind(mongoProvider.GetDBaaSAPIGroupVersion().WithKind(testInstanceKind))
+							g.Expect(dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), providerInstance)).Should(Succeed())
+							region, _, _ := unstructured.NestedString(providerInstance.Object, "spec", "provisioningParameters", string(v1beta1.ProvisioningRegions))
+							g.Expect(region).Should(Equal("other-test-region"))
+						}, timeout).Should(Succeed())
+						setProviderInstancePhase(createdDBaaSInstance, v1beta1.InstancePhaseReady)
+						assertInstanceUpdateCondition(createdDBaaSInstance, metav1.ConditionFalse, v1beta1.DBaaSUpdateCompleted, "")
+					})
+				})
 			})
 		})
 	})
 })
 
+// setProviderInstancePhase reports the phase of the provider instance of a DBaaSInstance
+func setProviderInstancePhase(instance *v1beta1.DBaaSInstance, phase v1beta1.DBaasInstancePhase) {
+	providerInstance := &unstructured.Unstructured{}
+	providerInstance.SetGroupVersionKind(mongoProvider.GetDBaaSAPIGroupVersion().WithKind(testInstanceKind))
+	Eventually(func() error {
+		if err := dRec.Get(ctx, client.ObjectKeyFromObject(instance), providerInstance); err != nil {
+			return err
+		}
+		providerInstance.UnstructuredContent()["status"] = map[string]interface{}{
+			"phase": string(phase),
+			"conditions": []interface{}{
+				map[string]interface{}{
+					"type":               v1beta1.DBaaSInstanceProviderSyncType,
+					"status":             string(metav1.ConditionTrue),
+					"reason":             "SyncOK",
+					"lastTransitionTime": metav1.Now().UTC().Format(time.RFC3339),
+					"observedGeneration": providerInstance.GetGeneration(),
+				},
+			},
+		}
+		return dRec.Status().Update(ctx, providerInstance)
+	}, timeout).Should(Succeed())
+}
+
+// assertInstanceUpdateCondition checks the UpdateInProgress condition of a DBaaSInstance
+func assertInstanceUpdateCondition(instance *v1beta1.DBaaSInstance, status metav1.ConditionStatus, reason, message string) {
+	Eventually(func(g Gomega) {
+		g.Expect(dRec.Get(ctx, client.ObjectKeyFromObject(instance), instance)).Should(Succeed())
+		cond := apimeta.FindStatusCondition(instance.Status.Conditions, v1beta1.DBaaSInstanceUpdateType)
+		g.Expect(cond).ShouldNot(BeNil())
+		g.Expect(cond.Status).Should(Equal(status))
+		g.Expect(cond.Reason).Should(Equal(reason))
+		g.Expect(cond.Message).Should(ContainSubstring(message))
+	}, timeout).Should(Succeed())
+}
+
 var _ = Describe("DBaaSInstance controller - valid dev namespaces", func() {
 	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
 	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
@@ -742,3 +836,171 @@ var _ = Describe("DBaaSInstance controller - nominal", func() {
 		})
 	})
 })
+
+var _ = Describe("DBaaSInstance controller - deletion policy", func() {
+	BeforeEach(assertResourceCreationIfNotExists(&testSecret))
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
+
+	inventoryRefName := "test-inventory-deletion-policy"
+	createdDBaaSInventory := &v1beta1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      inventoryRefName,
+			Namespace: testNamespace,
+		},
+		Spec: v1beta1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1beta1.NamespacedName{
+				Name: testProviderName,
+			},
+			DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+				CredentialsRef: &v1beta1.LocalObjectReference{
+					Name: testSecret.Name,
+				},
+			},
+		},
+	}
+	providerInventoryStatus := &v1beta1.DBaaSInventoryStatus{
+		Conditions: []metav1.Condition{
+			{
+				Type:               "SpecSynced",
+				Status:             metav1.ConditionTrue,
+				Reason:             "SyncOK",
+				LastTransitionTime: metav1.Time{Time: getLastTransitionTimeForTest()},
+			},
+		},
+	}
+	BeforeEach(assertResourceCreationWithProviderStatus(createdDBaaSInventory, mongoProvider.GetDBaaSAPIGroupVersion(), metav1.ConditionTrue, testInventoryKind, providerInventoryStatus))
+	AfterEach(assertResourceDeletion(createdDBaaSInventory))
+
+	Context("after creating DBaaSInstance with the Delete policy", func() {
+		DBaaSInstanceSpec := &v1beta1.DBaaSInstanceSpec{
+			InventoryRef: v1beta1.NamespacedName{
+				Name:      inventoryRefName,
+				Namespace: testNamespace,
+			},
+			ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningName: "test-instance-delete",
+			},
+			DeletionPolicy: v1beta1.DeletionPolicyDelete,
+		}
+		createdDBaaSInstance := &v1beta1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-delete",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSInstanceSpec,
+		}
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		BeforeEach(assertProviderResourceCreated(createdDBaaSInstance, mongoProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec))
+
+		It("should add the finalizer and delete the provider instance", func() {
+			Eventually(func() bool {
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance); err != nil {
+					return false
+				}
+				return controllerutil.ContainsFinalizer(createdDBaaSInstance, v1beta1.DBaaSInstanceFinalizer)
+			}, timeout).Should(BeTrue())
+
+			assertResourceDeletion(createdDBaaSInstance)()
+
+			providerInstance := &unstructured.Unstructured{}
+			providerInstance.SetGroupVersionKind(mongoProvider.GetDBaaSAPIGroupVersion().WithKind(testInstanceKind))
+			err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), providerInstance)
+			Expect(errors.IsNotFound(err)).Should(BeTrue())
+		})
+	})
+
+	Context("after creating DBaaSInstance with the Retain policy", func() {
+		DBaaSInstanceSpec := &v1beta1.DBaaSInstanceSpec{
+			InventoryRef: v1beta1.NamespacedName{
+				Name:      inventoryRefName,
+				Namespace: testNamespace,
+			},
+			ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningName: "test-instance-retain",
+			},
+			DeletionPolicy: v1beta1.DeletionPolicyRetain,
+		}
+		createdDBaaSInstance := &v1beta1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-retain",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSInstanceSpec,
+		}
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		BeforeEach(assertProviderResourceCreated(createdDBaaSInstance, mongoProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec))
+
+		It("should detach and keep the provider instance", func() {
+			Eventually(func() bool {
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance); err != nil {
+					return false
+				}
+				return controllerutil.ContainsFinalizer(createdDBaaSInstance, v1beta1.DBaaSInstanceFinalizer)
+			}, timeout).Should(BeTrue())
+
+			assertResourceDeletion(createdDBaaSInstance)()
+
+			providerInstance := &unstructured.Unstructured{}
+			providerInstance.SetGroupVersionKind(mongoProvider.GetDBaaSAPIGroupVersion().WithKind(testInstanceKind))
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), providerInstance)).Should(Succeed())
+			Expect(providerInstance.GetOwnerReferences()).Should(BeEmpty())
+			Expect(dRec.Delete(ctx, providerInstance)).Should(Succeed())
+		})
+	})
+
+	Context("after deleting the DBaaSInventory of a DBaaSInstance", func() {
+		DBaaSInstanceSpec := &v1beta1.DBaaSInstanceSpec{
+			InventoryRef: v1beta1.NamespacedName{
+				Name:      inventoryRefName,
+				Namespace: testNamespace,
+			},
+			ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+				v1beta1.ProvisioningName: "test-instance-no-inventory",
+			},
+			DeletionPolicy: v1beta1.DeletionPolicyDelete,
+		}
+		createdDBaaSInstance := &v1beta1.DBaaSInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-instance-no-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: *DBaaSInstanceSpec,
+		}
+		BeforeEach(assertResourceCreation(createdDBaaSInstance))
+		BeforeEach(assertProviderResourceCreated(createdDBaaSInstance, mongoProvider.GetDBaaSAPIGroupVersion(), testInstanceKind, DBaaSInstanceSpec))
+
+		It("should not release the DBaaSInstance until the provider instance is deleted", func() {
+			Eventually(func() bool {
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance); err != nil {
+					return false
+				}
+				return controllerutil.ContainsFinalizer(createdDBaaSInstance, v1beta1.DBaaSInstanceFinalizer)
+			}, timeout).Should(BeTrue())
+
+			assertResourceDeletion(createdDBaaSInventory)()
+			Expect(dRec.Delete(ctx, createdDBaaSInstance)).Should(Succeed())
+
+			By("checking the missing inventory is reported")
+			Eventually(func(g Gomega) {
+				g.Expect(dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance)).Should(Succeed())
+				cond := apimeta.FindStatusCondition(createdDBaaSInstance.Status.Conditions, v1beta1.DBaaSInstanceReadyType)
+				g.Expect(cond).ShouldNot(BeNil())
+				g.Expect(cond.Reason).Should(Equal(v1beta1.DBaaSInventoryNotFound))
+			}, timeout).Should(Succeed())
+			Expect(createdDBaaSInstance.Finalizers).Should(ContainElement(v1beta1.DBaaSInstanceFinalizer))
+
+			By("restoring the inventory")
+			assertResourceCreationWithProviderStatus(createdDBaaSInventory, mongoProvider.GetDBaaSAPIGroupVersion(), metav1.ConditionTrue, testInventoryKind, providerInventoryStatus)()
+			Eventually(func() bool {
+				return errors.IsNotFound(dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), createdDBaaSInstance))
+			}, instanceDeletionRequeueDelay+timeout).Should(BeTrue())
+
+			providerInstance := &unstructured.Unstructured{}
+			providerInstance.SetGroupVersionKind(mongoProvider.GetDBaaSAPIGroupVersion().WithKind(testInstanceKind))
+			err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInstance), providerInstance)
+			Expect(errors.IsNotFound(err)).Should(BeTrue())
+		})
+	})
+})
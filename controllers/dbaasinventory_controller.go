@@ -18,13 +18,22 @@ package controllers
 
 import (
 	"context"
+	"fmt"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -32,6 +41,9 @@ import (
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
 )
 
+// credentialsNotFoundRetry is the delay before checking again the missing credentials secret of an inventory
+const credentialsNotFoundRetry = 30 * time.Second
+
 // DBaaSInventoryReconciler reconciles a DBaaSInventory object
 type DBaaSInventoryReconciler struct {
 	*DBaaSReconciler
@@ -40,7 +52,7 @@ type DBaaSInventoryReconciler struct {
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
-//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;patch
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;patch
 
 // Reconcile is part of the main kubernetes reconciliation loop which aims to
 // move the current state of the cluster closer to the desired state.
@@ -81,6 +93,7 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 	activePolicy := getActivePolicy(policyList)
 	if activePolicy == nil {
 		logger.Info("No DBaaSPolicy found for the target namespace", "Namespace", req.Namespace)
+		inventory.Status.EffectivePolicyRef = nil
 		cond := metav1.Condition{
 			Type:    v1beta1.DBaaSInventoryReadyType,
 			Status:  metav1.ConditionFalse,
@@ -99,6 +112,7 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		}
 		return ctrl.Result{}, nil
 	}
+	inventory.Status.EffectivePolicyRef = &v1beta1.LocalObjectReference{Name: activePolicy.Name}
 
 	if err := r.checkCredsRefLabel(ctx, inventory); err != nil {
 		if errors.IsConflict(err) {
@@ -116,6 +130,23 @@ func (r *DBaaSInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Reque
 		return ctrl.Result{}, err
 	}
 
+	if valid, err := r.reconcileCredentials(ctx, &inventory, provider); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Inventory resource modified, retry syncing credentials", "DBaaS Inventory", inventory)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error checking the DBaaS Inventory credentials", "DBaaS Inventory", inventory)
+		metricLabelErrCdValue = metrics.LabelErrorCdValueErrCheckingInventoryCredentials
+		return ctrl.Result{}, err
+	} else if !valid {
+		logger.Info("DBaaS Inventory credentials are missing or not valid for the provider", "DBaaS Inventory", inventory.Name, "DBaaS Provider", provider.Name)
+		if cond := apimeta.FindStatusCondition(inventory.Status.Conditions, v1beta1.DBaaSInventoryCredentialsType); cond != nil && cond.Reason == v1beta1.DBaaSCredentialsNotFound {
+			// the creation of an unlabeled secret is not watched
+			return ctrl.Result{RequeueAfter: credentialsNotFoundRetry}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+
 	//
 	// Provider Inventory
 	//
@@ -162,15 +193,154 @@ func (r *DBaaSInventoryReconciler) SetupWithManager(mgr ctrl.Manager) (controlle
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSInventory{}).
 		Watches(&source.Kind{Type: &v1beta1.DBaaSInventory{}}, &EventHandlerWithDelete{Controller: r}).
+		Watches(
+			&source.Kind{Type: &corev1.Secret{}},
+			handler.EnqueueRequestsFromMapFunc(r.credentialsSecretToInventories),
+			builder.WithPredicates(credentialsSecretPredicate),
+			builder.OnlyMetadata,
+		).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.policyToInventories)).
 		WithOptions(
 			controller.Options{MaxConcurrentReconciles: 2},
 		).
 		Build(r)
 }
 
+// only watch secrets labeled as inventory credentials, by checkCredsRefLabel or by their creator
+var credentialsSecretPredicate = predicate.Funcs{
+	CreateFunc: func(e event.CreateEvent) bool {
+		return isCredentialsSecret(e.Object)
+	},
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		return isCredentialsSecret(e.ObjectNew)
+	},
+	DeleteFunc: func(e event.DeleteEvent) bool {
+		return isCredentialsSecret(e.Object)
+	},
+	GenericFunc: func(e event.GenericEvent) bool {
+		return isCredentialsSecret(e.Object)
+	},
+}
+
+// credentialsSecretToInventories maps a credentials secret to the inventories referencing it
+func (r *DBaaSInventoryReconciler) credentialsSecretToInventories(secret client.Object) []reconcile.Request {
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(context.Background(), &inventoryList, client.InNamespace(secret.GetNamespace())); err != nil {
+		ctrl.Log.WithName("DBaaSInventoryReconciler").Error(err, "Error listing DBaaS Inventories for credentials secret", "Secret", objectKeyFromObject(secret))
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, inventory := range inventoryList.Items {
+		if inventory.Spec.CredentialsRef != nil && inventory.Spec.CredentialsRef.Name == secret.GetName() {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventory)})
+		}
+	}
+	return requests
+}
+
+// policyToInventories maps a policy to the inventories of its namespace, to update the policy governing them
+func (r *DBaaSInventoryReconciler) policyToInventories(policy client.Object) []reconcile.Request {
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(context.Background(), &inventoryList, client.InNamespace(policy.GetNamespace())); err != nil {
+		ctrl.Log.WithName("DBaaSInventoryReconciler").Error(err, "Error listing DBaaS Inventories for policy", "DBaaS Policy", objectKeyFromObject(policy))
+		return nil
+	}
+	requests := make([]reconcile.Request, len(inventoryList.Items))
+	for i := range inventoryList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&inventoryList.Items[i])}
+	}
+	return requests
+}
+
+// reconcileCredentials validates the credentials secret against the provider credential fields, and records a
+// rotation when the secret changed since the last sync. It returns false if the credentials are missing or not valid.
+func (r *DBaaSInventoryReconciler) reconcileCredentials(ctx context.Context, inventory *v1beta1.DBaaSInventory, provider *v1beta1.DBaaSProvider) (bool, error) {
+	if inventory.Spec.CredentialsRef == nil || len(inventory.Spec.CredentialsRef.Name) == 0 {
+		return true, nil
+	}
+	secret := corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: inventory.Spec.CredentialsRef.Name, Namespace: inventory.Namespace}, &secret); err != nil {
+		if errors.IsNotFound(err) {
+			// the inventory is requeued until the secret is created
+			return false, r.setCredentialsNotValid(ctx, inventory, v1beta1.DBaaSCredentialsNotFound, err.Error())
+		}
+		return false, err
+	}
+
+	if err := validateCredentials(&secret, provider); err != nil {
+		return false, r.setCredentialsNotValid(ctx, inventory, v1beta1.DBaaSInvalidCredentials, err.Error())
+	}
+
+	// the fingerprint and the rotation time are persisted by the status update syncing the provider inventory
+	hash := dataHash(credentialsData(&secret, provider))
+	if len(inventory.Status.CredentialsHash) > 0 && inventory.Status.CredentialsHash != hash {
+		now := metav1.Now()
+		inventory.Status.CredentialsLastRotated = &now
+	}
+	inventory.Status.CredentialsHash = hash
+	apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInventoryCredentialsType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.Ready,
+		Message: v1beta1.MsgCredentialsValid,
+	})
+	return true, nil
+}
+
+// setCredentialsNotValid reports missing or invalid credentials, which also make the inventory not ready
+func (r *DBaaSInventoryReconciler) setCredentialsNotValid(ctx context.Context, inventory *v1beta1.DBaaSInventory, reason, message string) error {
+	apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInventoryCredentialsType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInventoryReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	})
+	return r.Client.Status().Update(ctx, inventory)
+}
+
+// validateCredentials checks that the secret holds all the credential fields required by the provider
+func validateCredentials(secret *corev1.Secret, provider *v1beta1.DBaaSProvider) error {
+	for _, credField := range provider.Spec.CredentialFields {
+		if credField.Required {
+			if value, ok := secret.Data[credField.Key]; !ok || len(value) == 0 {
+				return fmt.Errorf("%s is required in secret %s", credField.Key, secret.Name)
+			}
+		}
+	}
+	return nil
+}
+
+// credentialsData returns the credential fields of the provider held by the secret, or all its data if the provider declares none
+func credentialsData(secret *corev1.Secret, provider *v1beta1.DBaaSProvider) map[string][]byte {
+	if len(provider.Spec.CredentialFields) == 0 {
+		return secret.Data
+	}
+	data := map[string][]byte{}
+	for _, credField := range provider.Spec.CredentialFields {
+		if value, ok := secret.Data[credField.Key]; ok {
+			data[credField.Key] = value
+		}
+	}
+	return data
+}
+
 // mergeInventoryStatus: merge the status from DBaaSProviderInventory into the current DBaaSInventory status
 func mergeInventoryStatus(inv *v1beta1.DBaaSInventory, providerInv *v1beta1.DBaaSProviderInventory) metav1.Condition {
-	providerInv.Status.DeepCopyInto(&inv.Status)
+	// Keep the credentials condition set by the DBaaS operator
+	var credsCond *metav1.Condition
+	if cond := apimeta.FindStatusCondition(inv.Status.Conditions, v1beta1.DBaaSInventoryCredentialsType); cond != nil {
+		credsCond = cond.DeepCopy()
+	}
+	providerInv.Status.DeepCopyInto(&inv.Status.DBaaSInventoryStatus)
+	if credsCond != nil {
+		apimeta.SetStatusCondition(&inv.Status.Conditions, *credsCond)
+	}
 	// Update inventory status condition (type: DBaaSInventoryReadyType) based on the provider status
 	specSync := apimeta.FindStatusCondition(providerInv.Status.Conditions, v1beta1.DBaaSInventoryProviderSyncType)
 	if specSync != nil && specSync.Status == metav1.ConditionTrue {
@@ -21,7 +21,9 @@ import (
 	. "github.com/onsi/gomega"
 
 	v1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
@@ -58,6 +60,50 @@ var _ = Describe("DBaaSInventory controller with errors", func() {
 		It("reconcile with error", assertDBaaSResourceStatusUpdated(testCreatedDBaaSInventory, metav1.ConditionFalse, v1beta1.DBaaSPolicyNotFound))
 	})
 
+	Context("after creating DBaaSInventory without its credentials secret", func() {
+		missingSecret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-credentials-missing",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				"field1": []byte("test1"),
+			},
+		}
+		testCreatedDBaaSInventory := &v1beta1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-inventory-missing-credentials",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1beta1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+					CredentialsRef: &v1beta1.LocalObjectReference{
+						Name: missingSecret.Name,
+					},
+				},
+			},
+		}
+		BeforeEach(assertResourceCreation(testCreatedDBaaSInventory))
+		AfterEach(assertResourceDeletion(testCreatedDBaaSInventory))
+		AfterEach(assertResourceDeletion(missingSecret))
+		It("should wait for the credentials secret", func() {
+			assertDBaaSResourceStatusUpdated(testCreatedDBaaSInventory, metav1.ConditionFalse, v1beta1.DBaaSCredentialsNotFound)()
+
+			By("creating the credentials secret")
+			assertResourceCreation(missingSecret)()
+			Eventually(func() bool {
+				inv := &v1beta1.DBaaSInventory{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(testCreatedDBaaSInventory), inv); err != nil {
+					return false
+				}
+				return apimeta.IsStatusConditionTrue(inv.Status.Conditions, v1beta1.DBaaSInventoryCredentialsType)
+			}, timeout).Should(BeTrue())
+		})
+	})
+
 	Context("after creating DBaaSInventory without valid provider", func() {
 		inventoryName := "test-inventory-no-provider"
 		providerName := "provider-no-exist"
@@ -123,6 +169,15 @@ var _ = Describe("DBaaSInventory controller - nominal", func() {
 			AfterEach(assertResourceDeletion(createdDBaaSInventory))
 
 			It("should create a provider inventory", assertProviderResourceCreated(createdDBaaSInventory, mongoProvider.GetDBaaSAPIGroupVersion(), testInventoryKind, DBaaSInventorySpec))
+			It("should report the policy governing the inventory", func() {
+				Eventually(func() (*v1beta1.LocalObjectReference, error) {
+					inventory := &v1beta1.DBaaSInventory{}
+					if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), inventory); err != nil {
+						return nil, err
+					}
+					return inventory.Status.EffectivePolicyRef, nil
+				}, timeout).Should(Equal(&v1beta1.LocalObjectReference{Name: defaultPolicy.Name}))
+			})
 
 			Context("when updating provider inventory status", func() {
 				lastTransitionTime := getLastTransitionTimeForTest()
@@ -257,3 +312,96 @@ var _ = Describe("DBaaSInventory controller for v1alpha1 provider - nominal", fu
 		})
 	})
 })
+
+var _ = Describe("DBaaSInventory controller - credentials rotation", func() {
+	BeforeEach(assertResourceCreationIfNotExists(mongoProvider))
+	BeforeEach(assertResourceCreationIfNotExists(&defaultPolicy))
+	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
+
+	Context("after creating DBaaSInventory", func() {
+		rotationSecret := &v1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-credentials-rotation",
+				Namespace: testNamespace,
+			},
+			Data: map[string][]byte{
+				"field1": []byte("test1"),
+			},
+		}
+		DBaaSInventorySpec := &v1beta1.DBaaSInventorySpec{
+			CredentialsRef: &v1beta1.LocalObjectReference{
+				Name: rotationSecret.Name,
+			},
+		}
+		createdDBaaSInventory := &v1beta1.DBaaSInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-inventory-rotation",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSOperatorInventorySpec{
+				ProviderRef: v1beta1.NamespacedName{
+					Name: testProviderName,
+				},
+				DBaaSInventorySpec: *DBaaSInventorySpec,
+			},
+		}
+		BeforeEach(assertResourceCreation(rotationSecret))
+		BeforeEach(assertResourceCreation(createdDBaaSInventory))
+		AfterEach(assertResourceDeletion(createdDBaaSInventory))
+		AfterEach(assertResourceDeletion(rotationSecret))
+
+		It("should re-sync the provider inventory when the credentials change", func() {
+			By("checking the credentials are valid")
+			var firstHash string
+			Eventually(func() bool {
+				inv := &v1beta1.DBaaSInventory{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), inv); err != nil {
+					return false
+				}
+				firstHash = inv.Status.CredentialsHash
+				return len(firstHash) > 0 && apimeta.IsStatusConditionTrue(inv.Status.Conditions, v1beta1.DBaaSInventoryCredentialsType)
+			}, timeout).Should(BeTrue())
+
+			By("changing the metadata of the credentials")
+			secret := &v1.Secret{}
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(rotationSecret), secret)).Should(Succeed())
+			secret.Annotations = map[string]string{"test": "test"}
+			Expect(dRec.Update(ctx, secret)).Should(Succeed())
+			Consistently(func() bool {
+				inv := &v1beta1.DBaaSInventory{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), inv); err != nil {
+					return false
+				}
+				return inv.Status.CredentialsHash == firstHash && inv.Status.CredentialsLastRotated == nil
+			}).Should(BeTrue())
+
+			By("rotating the credentials")
+			Expect(dRec.Get(ctx, client.ObjectKeyFromObject(rotationSecret), secret)).Should(Succeed())
+			secret.Data["field1"] = []byte("rotated")
+			Expect(dRec.Update(ctx, secret)).Should(Succeed())
+
+			By("checking the rotation is recorded")
+			Eventually(func() bool {
+				inv := &v1beta1.DBaaSInventory{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), inv); err != nil {
+					return false
+				}
+				return inv.Status.CredentialsHash != firstHash && inv.Status.CredentialsLastRotated != nil
+			}, timeout).Should(BeTrue())
+
+			By("checking the provider inventory is updated")
+			Eventually(func() bool {
+				inv := &v1beta1.DBaaSInventory{}
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), inv); err != nil {
+					return false
+				}
+				providerInv := &unstructured.Unstructured{}
+				providerInv.SetGroupVersionKind(mongoProvider.GetDBaaSAPIGroupVersion().WithKind(testInventoryKind))
+				if err := dRec.Get(ctx, client.ObjectKeyFromObject(createdDBaaSInventory), providerInv); err != nil {
+					return false
+				}
+				return providerInv.GetAnnotations()[v1beta1.CredentialsHashAnnotation] == inv.Status.CredentialsHash
+			}, timeout).Should(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,85 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// DBaaSInventoryGrantReconciler reconciles a DBaaSInventoryGrant object
+type DBaaSInventoryGrantReconciler struct {
+	*DBaaSReconciler
+}
+
+// Reconcile reports whether a grant is active, and requeues the grant when it expires.
+// The connections allowed by the grant are re-evaluated by the DBaaSConnection reconciler.
+func (r *DBaaSInventoryGrantReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var grant v1beta1.DBaaSInventoryGrant
+	if err := r.Get(ctx, req.NamespacedName, &grant); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Inventory Grant for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	now := time.Now()
+	var result ctrl.Result
+	cond := metav1.Condition{
+		Type:    v1beta1.DBaaSInventoryGrantReadyType,
+		Status:  metav1.ConditionTrue,
+		Reason:  v1beta1.Ready,
+		Message: v1beta1.MsgGrantReady,
+	}
+	if grant.IsExpired(now) {
+		cond = metav1.Condition{
+			Type:    v1beta1.DBaaSInventoryGrantReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.DBaaSGrantExpired,
+			Message: v1beta1.MsgGrantExpired,
+		}
+	} else if grant.Spec.ExpiresAt != nil {
+		result.RequeueAfter = grant.Spec.ExpiresAt.Sub(now)
+	}
+	apimeta.SetStatusCondition(&grant.Status.Conditions, cond)
+	if err := r.Client.Status().Update(ctx, &grant); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Inventory Grant resource modified, retry syncing status", "DBaaS Inventory Grant", grant)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the DBaaS Inventory Grant resource status", "DBaaS Inventory Grant", grant)
+		return ctrl.Result{}, err
+	}
+	return result, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSInventoryGrantReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSInventoryGrant{}).
+		Complete(r)
+}
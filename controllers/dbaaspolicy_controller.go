@@ -27,8 +27,12 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 )
 
 // DBaaSPolicyReconciler reconciles a DBaaSPolicy object
@@ -60,15 +64,15 @@ func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		metricLabelErrCdValue = metrics.LabelErrorCdValueUnableToListPolicies
 		return ctrl.Result{}, err
 	}
-	activePolicy := getActivePolicy(policyList)
+	activePolicy := v1beta1.ElectActivePolicy(policyList.Items)
 
 	var policy v1beta1.DBaaSPolicy
 	if err := r.Get(ctx, req.NamespacedName, &policy); err != nil {
 		if errors.IsNotFound(err) {
 			// CR deleted since request queued
-			if len(policyList.Items) > 0 && activePolicy == nil {
-				// reconcile another policy to ensure one is active
-				policy = policyList.Items[0]
+			if activePolicy != nil && !apimeta.IsStatusConditionTrue(activePolicy.Status.Conditions, v1beta1.DBaaSPolicyReadyType) {
+				// reconcile the elected policy to ensure it is active
+				policy = *activePolicy
 			} else {
 				// child objects getting GC'd, no requeue
 				return ctrl.Result{}, nil
@@ -97,7 +101,7 @@ func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		Message: v1beta1.MsgPolicyReady,
 	}
 
-	// if an active policy exists, and it's not this one... set status to false
+	// if another policy is elected... set status to false
 	if activePolicy != nil &&
 		activePolicy.GetName() != policy.Name {
 		cond = &metav1.Condition{
@@ -138,17 +142,97 @@ func (r *DBaaSPolicyReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		}
 	}
 
+	policy.Status.QuotaUsage = nil
+	policy.Status.EffectivePolicy = nil
+	if cond.Status == metav1.ConditionTrue {
+		clusterPolicy, err := r.getClusterPolicy(ctx)
+		if err != nil {
+			logger.Error(err, "Error fetching the DBaaS Cluster Policy", "DBaaS Policy", policy)
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorFetchingDBaaSPolicyResources
+			return ctrl.Result{}, err
+		}
+		policy.Status.EffectivePolicy = v1beta1.NarrowPolicy(clusterPolicy, &policy.Spec)
+		if quotas := policy.Status.EffectivePolicy.Quotas; quotas != nil {
+			usage, err := r.quotaUsage(ctx, &policy, quotas)
+			if err != nil {
+				logger.Error(err, "Error counting the usage of the DBaaS Policy quotas", "DBaaS Policy", policy)
+				metricLabelErrCdValue = metrics.LabelErrorCdValueErrCountingQuotaUsage
+				return ctrl.Result{}, err
+			}
+			policy.Status.QuotaUsage = usage
+		}
+	}
+
 	return r.updateStatusCondition(ctx, policy, cond)
 }
 
+// quotaUsage counts the instances and connections using the inventories of a policy
+func (r *DBaaSPolicyReconciler) quotaUsage(ctx context.Context, policy *v1beta1.DBaaSPolicy, quotas *v1beta1.DBaaSQuotaPolicy) ([]v1beta1.DBaaSQuotaUsage, error) {
+	var inventoryList v1beta1.DBaaSInventoryList
+	if err := r.List(ctx, &inventoryList, client.InNamespace(policy.Namespace)); err != nil {
+		return nil, err
+	}
+	var instanceList v1beta1.DBaaSInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, err
+	}
+	var connectionList v1beta1.DBaaSConnectionList
+	if err := r.List(ctx, &connectionList); err != nil {
+		return nil, err
+	}
+	return quotas.QuotaUsage(inventoryList.Items, instanceList.Items, connectionList.Items), nil
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *DBaaSPolicyReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&v1beta1.DBaaSPolicy{}).
 		Owns(&v1.ResourceQuota{}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.inventoryPolicyMapFn(func(obj client.Object) string {
+			return obj.(*v1beta1.DBaaSInstance).Spec.InventoryRef.Namespace
+		}))).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSConnection{}}, handler.EnqueueRequestsFromMapFunc(r.inventoryPolicyMapFn(func(obj client.Object) string {
+			return obj.(*v1beta1.DBaaSConnection).Spec.InventoryRef.Namespace
+		}))).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSClusterPolicy{}}, handler.EnqueueRequestsFromMapFunc(r.clusterPolicyMapFn)).
 		Complete(r)
 }
 
+// clusterPolicyMapFn maps the cluster policy to all the policies, to update their effective policy
+func (r *DBaaSPolicyReconciler) clusterPolicyMapFn(obj client.Object) []reconcile.Request {
+	if obj.GetName() != v1beta1.ClusterPolicyName {
+		return nil
+	}
+	policyList, err := r.policyListByNS(context.Background(), "")
+	if err != nil {
+		ctrl.Log.WithName("DBaaSPolicyReconciler").Error(err, "unable to list policies")
+		return nil
+	}
+	requests := make([]reconcile.Request, len(policyList.Items))
+	for i := range policyList.Items {
+		requests[i] = reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policyList.Items[i])}
+	}
+	return requests
+}
+
+// inventoryPolicyMapFn maps an instance or a connection to the policies with quotas of its inventory namespace, to update their usage
+func (r *DBaaSPolicyReconciler) inventoryPolicyMapFn(inventoryNamespaceFn func(client.Object) string) handler.MapFunc {
+	return func(obj client.Object) []reconcile.Request {
+		policyList, err := r.policyListByNS(context.Background(), inventoryNamespaceFn(obj))
+		if err != nil {
+			ctrl.Log.WithName("DBaaSPolicyReconciler").Error(err, "unable to list policies")
+			return nil
+		}
+		var requests []reconcile.Request
+		for _, policy := range policyList.Items {
+			if policy.Spec.Quotas != nil {
+				requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&policy)})
+			}
+		}
+		return requests
+	}
+}
+
 func (r *DBaaSPolicyReconciler) updateStatusCondition(ctx context.Context, policy v1beta1.DBaaSPolicy, cond *metav1.Condition) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 	apimeta.SetStatusCondition(&policy.Status.Conditions, *cond)
@@ -163,12 +247,11 @@ func (r *DBaaSPolicyReconciler) updateStatusCondition(ctx context.Context, polic
 	return ctrl.Result{}, nil
 }
 
-// get active policy, return nil if none exists
+// get active policy, the elected policy once its status is ready, return nil if none exists
 func getActivePolicy(policyList v1beta1.DBaaSPolicyList) *v1beta1.DBaaSPolicy {
-	for i := range policyList.Items {
-		if apimeta.IsStatusConditionTrue(policyList.Items[i].Status.Conditions, v1beta1.DBaaSPolicyReadyType) {
-			return &policyList.Items[i]
-		}
+	if policy := v1beta1.ElectActivePolicy(policyList.Items); policy != nil &&
+		apimeta.IsStatusConditionTrue(policy.Status.Conditions, v1beta1.DBaaSPolicyReadyType) {
+		return policy
 	}
 	return nil
 }
@@ -21,6 +21,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -30,18 +31,67 @@ var _ = Describe("DBaaSPolicy controller", func() {
 	BeforeEach(assertDBaaSResourceStatusUpdated(&defaultPolicy, metav1.ConditionTrue, v1beta1.Ready))
 
 	Describe("reconcile", func() {
-		Context("w/ status NotReady", func() {
-			policy2 := getDefaultPolicy(testNamespace)
-			policy2.Name = "test"
-			BeforeEach(assertResourceCreationIfNotExists(&policy2))
-			BeforeEach(assertDBaaSResourceStatusUpdated(&policy2, metav1.ConditionFalse, v1beta1.DBaaSPolicyNotReady))
-
-			It("should return second policy with existing policy name in status message", func() {
-				getPolicy := v1beta1.DBaaSPolicy{}
-				err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy2), &getPolicy)
-				Expect(err).NotTo(HaveOccurred())
-				Expect(getPolicy.Status.Conditions).Should(HaveLen(1))
-				Expect(getPolicy.Status.Conditions[0].Message).Should(Equal(v1beta1.MsgPolicyNotReady + " - " + defaultPolicy.GetName()))
+		Context("w/ a second policy", func() {
+			It("should reject the second policy of a namespace", func() {
+				policy2 := getDefaultPolicy(testNamespace)
+				policy2.Name = "test"
+				err := dRec.Create(ctx, &policy2)
+				Expect(err).Should(MatchError(ContainSubstring("the namespace " + testNamespace + " already has the policy " + defaultPolicy.Name)))
+			})
+		})
+
+		Context("w/ quotas", func() {
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "test-namespace-quotas",
+				},
+			}
+			maxInstances := int32(3)
+			policy := getDefaultPolicy(ns.Name)
+			policy.Spec.Quotas = &v1beta1.DBaaSQuotaPolicy{MaxInstancesPerInventory: &maxInstances}
+			inventory := &v1beta1.DBaaSInventory{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-quota-inventory",
+					Namespace: ns.Name,
+				},
+				Spec: v1beta1.DBaaSOperatorInventorySpec{
+					ProviderRef: v1beta1.NamespacedName{
+						Name: testProviderName,
+					},
+				},
+			}
+			instance := &v1beta1.DBaaSInstance{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "test-quota-instance",
+					Namespace: testNamespace,
+				},
+				Spec: v1beta1.DBaaSInstanceSpec{
+					InventoryRef: v1beta1.NamespacedName{
+						Name:      inventory.Name,
+						Namespace: ns.Name,
+					},
+				},
+			}
+			BeforeEach(assertResourceCreationIfNotExists(ns))
+			BeforeEach(assertResourceCreationIfNotExists(&policy))
+			BeforeEach(assertDBaaSResourceStatusUpdated(&policy, metav1.ConditionTrue, v1beta1.Ready))
+			BeforeEach(assertResourceCreation(inventory))
+			AfterEach(assertResourceDeletion(inventory))
+
+			It("should report the usage of the quotas", func() {
+				assertResourceCreation(instance)()
+				Eventually(func() ([]v1beta1.DBaaSQuotaUsage, error) {
+					err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy), &policy)
+					return policy.Status.QuotaUsage, err
+				}, timeout).Should(Equal([]v1beta1.DBaaSQuotaUsage{
+					{Type: v1beta1.QuotaInstancesPerInventory, Name: inventory.Name, Used: 1, Limit: 3},
+				}))
+
+				assertResourceDeletion(instance)()
+				Eventually(func() ([]v1beta1.DBaaSQuotaUsage, error) {
+					err := dRec.Get(ctx, client.ObjectKeyFromObject(&policy), &policy)
+					return policy.Status.QuotaUsage, err
+				}, timeout).Should(BeEmpty())
 			})
 		})
 	})
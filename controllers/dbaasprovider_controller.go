@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"reflect"
 
 	"k8s.io/apimachinery/pkg/api/errors"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -36,6 +37,9 @@ type DBaaSProviderReconciler struct {
 	ConnectionCtrl controller.Controller
 	InventoryCtrl  controller.Controller
 	InstanceCtrl   controller.Controller
+	DatabaseCtrl   controller.Controller
+	BackupCtrl     controller.Controller
+	RestoreCtrl    controller.Controller
 }
 
 //+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -95,10 +99,53 @@ func (r *DBaaSProviderReconciler) Reconcile(ctx context.Context, req ctrl.Reques
 	}
 	logger.Info("Watching Provider Instance CR", "Kind", provider.Spec.InstanceKind)
 
+	// the database kind is optional, only providers managing databases inside their instances define it
+	if len(provider.Spec.DatabaseKind) > 0 {
+		if err := r.watchDBaaSProviderObject(r.DatabaseCtrl, &v1beta1.DBaaSDatabase{}, provider.Spec.DatabaseKind, &groupVersion); err != nil {
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorWatchingDatabaseCR
+			logger.Error(err, "Error watching Provider Database CR", "Kind", provider.Spec.DatabaseKind)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Watching Provider Database CR", "Kind", provider.Spec.DatabaseKind)
+	}
+
+	if len(provider.Spec.BackupKind) > 0 {
+		if err := r.watchDBaaSProviderObject(r.BackupCtrl, &v1beta1.DBaaSBackup{}, provider.Spec.BackupKind, &groupVersion); err != nil {
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorWatchingBackupCR
+			logger.Error(err, "Error watching Provider Backup CR", "Kind", provider.Spec.BackupKind)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Watching Provider Backup CR", "Kind", provider.Spec.BackupKind)
+	}
+
+	if len(provider.Spec.RestoreKind) > 0 {
+		if err := r.watchDBaaSProviderObject(r.RestoreCtrl, &v1beta1.DBaaSRestore{}, provider.Spec.RestoreKind, &groupVersion); err != nil {
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorWatchingRestoreCR
+			logger.Error(err, "Error watching Provider Restore CR", "Kind", provider.Spec.RestoreKind)
+			return ctrl.Result{}, err
+		}
+		logger.Info("Watching Provider Restore CR", "Kind", provider.Spec.RestoreKind)
+	}
+
 	defer func() {
 		metrics.SetProviderMetrics(provider, provider.Name, execution, event, metricLabelErrCdValue)
 	}()
 
+	// surface the capabilities the operator applies to the requests for the provider
+	if capabilities := provider.GetCapabilities(); !reflect.DeepEqual(provider.Status.Capabilities, &capabilities) {
+		provider.Status.Capabilities = &capabilities
+		if err := r.Client.Status().Update(ctx, &provider); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("DBaaS Provider modified, retry syncing status", "DBaaS Provider", provider.Name)
+				return ctrl.Result{Requeue: true}, nil
+			}
+			metricLabelErrCdValue = metrics.LabelErrorCdValueErrorUpdatingProviderStatus
+			logger.Error(err, "Error updating the DBaaS Provider status", "DBaaS Provider", provider.Name)
+			return ctrl.Result{}, err
+		}
+		logger.Info("DBaaS Provider capabilities updated", "DBaaS Provider", provider.Name)
+	}
+
 	return ctrl.Result{}, nil
 }
 
@@ -0,0 +1,310 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// DBaaSRestoreReconciler reconciles a DBaaSRestore object
+type DBaaSRestoreReconciler struct {
+	*DBaaSReconciler
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=*/finalizers,verbs=update
+//+kubebuilder:rbac:groups=authorization.k8s.io,resources=subjectaccessreviews,verbs=create
+
+// Reconcile creates the provider restore object of a DBaaSRestore once its backup is completed, for the provider of the backed up instance,
+// and syncs its status. The backup is restored to an instance of the same inventory, which is created first for a restore to a new instance.
+// The namespace of the restore must be a valid connection namespace for the inventory, and the target instance must be in the namespace of the restore.
+func (r *DBaaSRestoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var restore v1beta1.DBaaSRestore
+	if err := r.Get(ctx, req.NamespacedName, &restore); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("DBaaS Restore resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching DBaaS Restore for reconcile")
+		return ctrl.Result{}, err
+	}
+	if restore.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	var backup v1beta1.DBaaSBackup
+	if err := r.Get(ctx, refKey(restore.Spec.BackupRef, restore.Namespace), &backup); err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "DBaaS Backup resource not found for DBaaS Restore", "DBaaS Restore", restore.Name)
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSServiceNotAvailable, err.Error()))
+		}
+		logger.Error(err, "Error fetching the DBaaS Backup of the DBaaS Restore", "DBaaS Restore", restore.Name)
+		return ctrl.Result{}, err
+	}
+	sourceInstance, err := r.getInstance(ctx, backup.Spec.InstanceRef, backup.Namespace)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "Backed up DBaaS Instance resource not found for DBaaS Restore", "DBaaS Restore", restore.Name)
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSServiceNotAvailable, err.Error()))
+		}
+		logger.Error(err, "Error fetching the backed up DBaaS Instance of the DBaaS Restore", "DBaaS Restore", restore.Name)
+		return ctrl.Result{}, err
+	}
+
+	inventory, validNS, _, err := r.checkInventory(ctx, sourceInstance.Spec.InventoryRef, &restore, func(reason string, message string) {
+		apimeta.SetStatusCondition(&restore.Status.Conditions, restoreNotReady(reason, message))
+	}, logger)
+	if err != nil || !validNS {
+		return ctrl.Result{}, err
+	}
+
+	provider, err := r.getDBaaSProvider(ctx, inventory.Spec.ProviderRef.Name)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !provider.GetCapabilities().SupportsBackups() || len(provider.Spec.RestoreKind) == 0 {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSRestoreNotSupported, v1beta1.MsgRestoreNotSupported))
+	}
+	if backup.Status.Phase != v1beta1.BackupPhaseCompleted || len(backup.Status.BackupID) == 0 {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSBackupNotCompleted, v1beta1.MsgBackupNotCompleted))
+	}
+
+	targetKey := restoreTargetKey(&restore, sourceInstance)
+	if targetKey.Namespace != restore.Namespace {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSInvalidRestoreTarget, v1beta1.MsgRestoreTargetNamespace))
+	}
+	target, err := r.getRestoreTarget(ctx, &restore, targetKey, sourceInstance)
+	if err != nil {
+		if errors.IsNotFound(err) {
+			logger.Error(err, "Target DBaaS Instance resource not found for DBaaS Restore", "DBaaS Restore", restore.Name)
+			return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSServiceNotAvailable, err.Error()))
+		}
+		if errors.IsConflict(err) {
+			logger.V(1).Info("DBaaS Restore modified, retry creating the target instance", "DBaaS Restore", restore.Name)
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error getting the target DBaaS Instance of the DBaaS Restore", "DBaaS Restore", restore.Name)
+		return ctrl.Result{}, err
+	}
+	if target.Spec.InventoryRef != sourceInstance.Spec.InventoryRef {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSInvalidRestoreTarget, v1beta1.MsgInvalidRestoreTarget))
+	}
+	if len(target.Status.InstanceID) == 0 {
+		return ctrl.Result{}, r.updateStatusCondition(ctx, &restore, &restore.Status.Conditions, restoreNotReady(v1beta1.DBaaSServiceNotAvailable, "target instance ID is not available"))
+	}
+
+	return r.reconcileProviderResource(ctx,
+		inventory.Spec.ProviderRef.Name,
+		&restore,
+		func(provider *v1beta1.DBaaSProvider) string {
+			return provider.Spec.RestoreKind
+		},
+		func() interface{} {
+			return &v1beta1.DBaaSProviderRestoreSpec{
+				InventoryRef:            sourceInstance.Spec.InventoryRef,
+				BackupID:                backup.Status.BackupID,
+				SourceDatabaseServiceID: sourceInstance.Status.InstanceID,
+				DatabaseServiceID:       target.Status.InstanceID,
+			}
+		},
+		func() interface{} {
+			return &v1beta1.DBaaSProviderRestore{}
+		},
+		func(i interface{}) metav1.Condition {
+			return mergeRestoreStatus(&restore, i.(*v1beta1.DBaaSProviderRestore))
+		},
+		func() *[]metav1.Condition {
+			return &restore.Status.Conditions
+		},
+		v1beta1.DBaaSRestoreReadyType,
+		logger,
+	)
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *DBaaSRestoreReconciler) SetupWithManager(mgr ctrl.Manager) (controller.Controller, error) {
+	bldr := ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.DBaaSRestore{}).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSBackup{}}, handler.EnqueueRequestsFromMapFunc(r.backupToRestores)).
+		Watches(&source.Kind{Type: &v1beta1.DBaaSInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instanceToRestores))
+	return r.watchConnectionNamespaces(bldr, r.restoreInventoryUsers).
+		Build(r)
+}
+
+// restoreTargetKey returns the key of the instance to restore the backup to, the backed up instance if no target is set
+func restoreTargetKey(restore *v1beta1.DBaaSRestore, sourceInstance *v1beta1.DBaaSInstance) types.NamespacedName {
+	if restore.Spec.TargetInstanceRef != nil {
+		return refKey(*restore.Spec.TargetInstanceRef, restore.Namespace)
+	}
+	if restore.Spec.NewInstance != nil {
+		return types.NamespacedName{Namespace: restore.Namespace, Name: restore.Spec.NewInstance.Name}
+	}
+	return client.ObjectKeyFromObject(sourceInstance)
+}
+
+// getRestoreTarget returns the instance to restore the backup to, and records it in the status of the restore.
+// The instance of a restore to a new instance is created, with the inventory of the backed up instance, if it does not exist yet.
+// The create access of the user to DBaaSInstances is checked by the restore webhook.
+func (r *DBaaSRestoreReconciler) getRestoreTarget(ctx context.Context, restore *v1beta1.DBaaSRestore, key types.NamespacedName, sourceInstance *v1beta1.DBaaSInstance) (*v1beta1.DBaaSInstance, error) {
+	if restore.Status.TargetInstanceRef == nil {
+		restore.Status.TargetInstanceRef = &v1beta1.NamespacedName{Namespace: key.Namespace, Name: key.Name}
+		if err := r.Client.Status().Update(ctx, restore); err != nil {
+			return nil, err
+		}
+	}
+
+	target := &v1beta1.DBaaSInstance{}
+	err := r.Get(ctx, key, target)
+	if err == nil || !errors.IsNotFound(err) || restore.Spec.NewInstance == nil {
+		return target, err
+	}
+	// the new instance is not owned by the restore, it outlives it
+	target = &v1beta1.DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: v1beta1.DBaaSInstanceSpec{
+			InventoryRef:           sourceInstance.Spec.InventoryRef,
+			ProvisioningParameters: restore.Spec.NewInstance.ProvisioningParameters,
+		},
+	}
+	if err := r.Create(ctx, target); err != nil {
+		return nil, err
+	}
+	ctrl.LoggerFrom(ctx).Info("DBaaS Instance created to restore the backup to", "DBaaS Restore", restore.Name, "DBaaS Instance", key)
+	return target, nil
+}
+
+// backupToRestores maps a backup to the restores referencing it
+func (r *DBaaSRestoreReconciler) backupToRestores(obj client.Object) []reconcile.Request {
+	return r.restoreRequests(obj, func(restore *v1beta1.DBaaSRestore) bool {
+		return refKey(restore.Spec.BackupRef, restore.Namespace) == client.ObjectKeyFromObject(obj)
+	})
+}
+
+// instanceToRestores maps an instance to the restores targeting it
+func (r *DBaaSRestoreReconciler) instanceToRestores(obj client.Object) []reconcile.Request {
+	return r.restoreRequests(obj, func(restore *v1beta1.DBaaSRestore) bool {
+		return restore.Status.TargetInstanceRef != nil && refKey(*restore.Status.TargetInstanceRef, restore.Namespace) == client.ObjectKeyFromObject(obj)
+	})
+}
+
+func (r *DBaaSRestoreReconciler) restoreRequests(obj client.Object, affects func(*v1beta1.DBaaSRestore) bool) []reconcile.Request {
+	var restoreList v1beta1.DBaaSRestoreList
+	if err := r.List(context.Background(), &restoreList); err != nil {
+		ctrl.Log.WithName("DBaaSRestoreReconciler").Error(err, "Error listing the DBaaS Restores", "Object", objectKeyFromObject(obj))
+		return nil
+	}
+	var requests []reconcile.Request
+	for i := range restoreList.Items {
+		if affects(&restoreList.Items[i]) {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&restoreList.Items[i])})
+		}
+	}
+	return requests
+}
+
+// restoreInventoryUsers lists the restores with the inventories of the instances backed up by their backups
+func (r *DBaaSRestoreReconciler) restoreInventoryUsers(ctx context.Context) ([]inventoryUser, error) {
+	var restoreList v1beta1.DBaaSRestoreList
+	if err := r.List(ctx, &restoreList); err != nil {
+		return nil, err
+	}
+	var backupList v1beta1.DBaaSBackupList
+	if err := r.List(ctx, &backupList); err != nil {
+		return nil, err
+	}
+	inventories, err := r.instanceInventories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	backupInventories := make(map[types.NamespacedName]v1beta1.NamespacedName, len(backupList.Items))
+	for i := range backupList.Items {
+		if inventory, ok := inventories[refKey(backupList.Items[i].Spec.InstanceRef, backupList.Items[i].Namespace)]; ok {
+			backupInventories[client.ObjectKeyFromObject(&backupList.Items[i])] = inventory
+		}
+	}
+	var users []inventoryUser
+	for i := range restoreList.Items {
+		if inventory, ok := backupInventories[refKey(restoreList.Items[i].Spec.BackupRef, restoreList.Items[i].Namespace)]; ok {
+			users = append(users, inventoryUser{key: client.ObjectKeyFromObject(&restoreList.Items[i]), inventory: inventory})
+		}
+	}
+	return users, nil
+}
+
+func restoreNotReady(reason, message string) metav1.Condition {
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSRestoreReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+// mergeRestoreStatus: merge the status from DBaaSProviderRestore into the current DBaaSRestore status
+func mergeRestoreStatus(restore *v1beta1.DBaaSRestore, providerRestore *v1beta1.DBaaSProviderRestore) metav1.Condition {
+	target := restore.Status.TargetInstanceRef
+	providerRestore.Status.DeepCopyInto(&restore.Status)
+	restore.Status.TargetInstanceRef = target
+	if len(restore.Status.Phase) == 0 {
+		restore.Status.Phase = v1beta1.BackupPhaseUnknown
+	}
+	// Update restore status condition (type: DBaaSRestoreReadyType) based on the provider status
+	specSync := apimeta.FindStatusCondition(providerRestore.Status.Conditions, v1beta1.DBaaSRestoreProviderSyncType)
+	if specSync != nil && specSync.Status == metav1.ConditionTrue {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSRestoreReadyType,
+			Status:  metav1.ConditionTrue,
+			Reason:  v1beta1.Ready,
+			Message: v1beta1.MsgProviderCRStatusSyncDone,
+		}
+	}
+	if specSync != nil {
+		return metav1.Condition{
+			Type:    v1beta1.DBaaSRestoreReadyType,
+			Status:  metav1.ConditionFalse,
+			Reason:  v1beta1.ProviderReconcileError,
+			Message: fmt.Sprintf("%s: %s", specSync.Reason, specSync.Message),
+		}
+	}
+	return metav1.Condition{
+		Type:    v1beta1.DBaaSRestoreReadyType,
+		Status:  metav1.ConditionFalse,
+		Reason:  v1beta1.ProviderReconcileInprogress,
+		Message: v1beta1.MsgProviderCRReconcileInProgress,
+	}
+}
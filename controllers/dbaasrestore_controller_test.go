@@ -0,0 +1,42 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	. "github.com/onsi/ginkgo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("DBaaSRestore controller with errors", func() {
+	Context("after creating DBaaSRestore without backup", func() {
+		createdDBaaSRestore := &v1beta1.DBaaSRestore{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-restore-no-backup",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSRestoreSpec{
+				BackupRef: v1beta1.NamespacedName{Name: "test-backup-no-exist-ref"},
+			},
+		}
+
+		BeforeEach(assertResourceCreation(createdDBaaSRestore))
+		AfterEach(assertResourceDeletion(createdDBaaSRestore))
+		It("reconcile with error", assertDBaaSResourceStatusUpdated(createdDBaaSRestore, metav1.ConditionFalse, v1beta1.DBaaSServiceNotAvailable))
+	})
+})
@@ -0,0 +1,388 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// LocalConnectionReconciler reconciles a LocalConnection object
+type LocalConnectionReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+const (
+	// localConnectionFinalizer drops the database role of a connection before the connection is deleted
+	localConnectionFinalizer = "dbaas.redhat.com/local-connection-role"
+
+	// roleJobRequeueDelay is the delay between checks of the Jobs creating and dropping the database role of a connection
+	roleJobRequeueDelay = 5 * time.Second
+
+	roleJobCreate = "create"
+	roleJobDrop   = "drop"
+)
+
+// the psql scripts of the Jobs creating and dropping the database role of a connection
+const (
+	createRoleScript = `psql -v ON_ERROR_STOP=1 -v role="$ROLE_NAME" -v password="$ROLE_PASSWORD" <<'EOF'
+SELECT format('CREATE ROLE %I', :'role') WHERE NOT EXISTS (SELECT FROM pg_roles WHERE rolname = :'role') \gexec
+ALTER ROLE :"role" WITH LOGIN PASSWORD :'password';
+SELECT format('GRANT ALL PRIVILEGES ON DATABASE %I TO %I', current_database(), :'role') \gexec
+GRANT ALL ON SCHEMA public TO :"role";
+EOF`
+	dropRoleScript = `psql -v ON_ERROR_STOP=1 -v role="$ROLE_NAME" <<'EOF'
+SELECT format('REASSIGN OWNED BY %1$I TO %2$I; DROP OWNED BY %1$I; DROP ROLE %1$I', :'role', current_user)
+WHERE EXISTS (SELECT FROM pg_roles WHERE rolname = :'role') \gexec
+EOF`
+)
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localconnections,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localconnections/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups="",resources=secrets;configmaps,verbs=get;create;update;patch;delete
+//+kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create;delete
+
+// Reconcile creates a database role in a local instance for a LocalConnection, and publishes its credentials and the connection information.
+// The role is dropped when the connection is deleted.
+func (r *LocalConnectionReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var connection v1beta1.LocalConnection
+	if err := r.Get(ctx, req.NamespacedName, &connection); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Local Connection resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Local Connection for reconcile")
+		return ctrl.Result{}, err
+	}
+	if connection.DeletionTimestamp != nil {
+		return r.finalizeConnection(ctx, &connection)
+	}
+	if controllerutil.AddFinalizer(&connection, localConnectionFinalizer) {
+		if err := r.Update(ctx, &connection); err != nil {
+			if errors.IsConflict(err) {
+				logger.V(1).Info("Local Connection modified, retry adding the finalizer")
+				return ctrl.Result{Requeue: true}, nil
+			}
+			logger.Error(err, "Error adding the finalizer to the Local Connection")
+			return ctrl.Result{}, err
+		}
+	}
+
+	instance, err := r.getInstance(ctx, &connection)
+	if err != nil {
+		logger.Error(err, "Error fetching the Local Instance for Local Connection")
+		return ctrl.Result{}, err
+	}
+	if instance == nil {
+		return r.updateStatus(ctx, &connection, metav1.ConditionFalse, reasonNotFound,
+			fmt.Sprintf("local instance %s not found", connection.Spec.DatabaseServiceID))
+	}
+	if instance.Status.Phase != v1beta1.InstancePhaseReady {
+		return r.updateStatus(ctx, &connection, metav1.ConditionFalse, reasonProvisioning,
+			fmt.Sprintf("local instance %s is not ready", instance.Name))
+	}
+
+	roleSecret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: roleObjectName(&connection), Namespace: instance.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, roleSecret, func() error {
+		roleSecret.Labels = instanceLabels(instance)
+		if roleSecret.Data == nil {
+			roleSecret.Data = map[string][]byte{}
+		}
+		roleSecret.Data[usernameKey] = []byte(roleName(&connection))
+		if len(roleSecret.Data[passwordKey]) == 0 {
+			password, err := generatePassword()
+			if err != nil {
+				return err
+			}
+			roleSecret.Data[passwordKey] = []byte(password)
+		}
+		// the role secret is only needed by the Jobs running in the namespace of the instance
+		return setOwner(instance, roleSecret, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the database role Secret for Local Connection")
+		return ctrl.Result{}, err
+	}
+
+	if done, err := r.runRoleJob(ctx, instance, &connection, roleJobCreate); err != nil {
+		logger.Error(err, "Error creating the database role for Local Connection")
+		if _, errStatus := r.updateStatus(ctx, &connection, metav1.ConditionFalse, v1beta1.ProviderReconcileError, err.Error()); errStatus != nil {
+			logger.Error(errStatus, "Error updating the Local Connection status")
+		}
+		return ctrl.Result{}, err
+	} else if !done {
+		result, err := r.updateStatus(ctx, &connection, metav1.ConditionFalse, reasonProvisioning, "Waiting for the database role of the connection to be created")
+		if err == nil && !result.Requeue {
+			result.RequeueAfter = roleJobRequeueDelay
+		}
+		return result, err
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: connectionSecretName(connection.Name), Namespace: connection.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, func() error {
+		secret.Type = corev1.SecretTypeOpaque
+		secret.Data = map[string][]byte{
+			usernameKey: roleSecret.Data[usernameKey],
+			passwordKey: roleSecret.Data[passwordKey],
+		}
+		return setOwner(&connection, secret, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the credentials Secret for Local Connection")
+		return ctrl.Result{}, err
+	}
+
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: connectionConfigMapName(connection.Name), Namespace: connection.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, configMap, func() error {
+		configMap.Data = map[string]string{
+			"type":     databaseType,
+			"provider": GetProviderSpec().Provider.Name,
+			"host":     instance.Status.InstanceInfo["host"],
+			"port":     instance.Status.InstanceInfo["port"],
+			"database": instance.Status.InstanceInfo["database"],
+			"sslmode":  "disable",
+		}
+		return setOwner(&connection, configMap, r.Scheme)
+	}); err != nil {
+		logger.Error(err, "Error reconciling the connection info ConfigMap for Local Connection")
+		return ctrl.Result{}, err
+	}
+
+	connection.Status.CredentialsRef = &corev1.LocalObjectReference{Name: secret.Name}
+	connection.Status.ConnectionInfoRef = &corev1.LocalObjectReference{Name: configMap.Name}
+	return r.updateStatus(ctx, &connection, metav1.ConditionTrue, reasonSyncOK, "Connection information is ready")
+}
+
+// finalizeConnection drops the database role of a connection, and removes the finalizer once the role is dropped.
+// The role of a connection to a deleted instance is deleted with the instance.
+func (r *LocalConnectionReconciler) finalizeConnection(ctx context.Context, connection *v1beta1.LocalConnection) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+	if !controllerutil.ContainsFinalizer(connection, localConnectionFinalizer) {
+		return ctrl.Result{}, nil
+	}
+
+	instance, err := r.getInstance(ctx, connection)
+	if err != nil {
+		logger.Error(err, "Error fetching the Local Instance for Local Connection")
+		return ctrl.Result{}, err
+	}
+	if instance != nil && instance.DeletionTimestamp == nil {
+		if done, err := r.runRoleJob(ctx, instance, connection, roleJobDrop); err != nil {
+			logger.Error(err, "Error dropping the database role of Local Connection")
+			return ctrl.Result{}, err
+		} else if !done {
+			return ctrl.Result{RequeueAfter: roleJobRequeueDelay}, nil
+		}
+		for _, obj := range []client.Object{
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: roleJobName(connection, roleJobCreate), Namespace: instance.Namespace}},
+			&batchv1.Job{ObjectMeta: metav1.ObjectMeta{Name: roleJobName(connection, roleJobDrop), Namespace: instance.Namespace}},
+			&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: roleObjectName(connection), Namespace: instance.Namespace}},
+		} {
+			if err := r.Delete(ctx, obj, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				logger.Error(err, "Error deleting the database role objects of Local Connection")
+				return ctrl.Result{}, err
+			}
+		}
+		logger.Info("Database role of Local Connection dropped", "role", roleName(connection))
+	}
+
+	controllerutil.RemoveFinalizer(connection, localConnectionFinalizer)
+	if err := r.Update(ctx, connection); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("Local Connection modified, retry removing the finalizer")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error removing the finalizer of the Local Connection")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// runRoleJob runs a Job creating or dropping the database role of a connection, with the superuser of the instance.
+// It returns true once the Job has succeeded. A failed Job is deleted, to run again on the next reconcile.
+func (r *LocalConnectionReconciler) runRoleJob(ctx context.Context, instance *v1beta1.LocalInstance, connection *v1beta1.LocalConnection, action string) (bool, error) {
+	job := &batchv1.Job{}
+	if err := r.Get(ctx, types.NamespacedName{Name: roleJobName(connection, action), Namespace: instance.Namespace}, job); err != nil {
+		if !errors.IsNotFound(err) {
+			return false, err
+		}
+		job = r.roleJob(instance, connection, action)
+		if err := setOwner(instance, job, r.Scheme); err != nil {
+			return false, err
+		}
+		return false, r.Create(ctx, job)
+	}
+	for _, cond := range job.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return true, nil
+		case batchv1.JobFailed:
+			if err := r.Delete(ctx, job, client.PropagationPolicy(metav1.DeletePropagationBackground)); client.IgnoreNotFound(err) != nil {
+				return false, err
+			}
+			return false, fmt.Errorf("job %s failed: %s", job.Name, cond.Message)
+		}
+	}
+	return false, nil
+}
+
+// roleJob returns the Job creating or dropping the database role of a connection
+func (r *LocalConnectionReconciler) roleJob(instance *v1beta1.LocalInstance, connection *v1beta1.LocalConnection, action string) *batchv1.Job {
+	script := createRoleScript
+	env := []corev1.EnvVar{
+		{Name: "PGHOST", Value: instance.Status.InstanceInfo["host"]},
+		{Name: "PGPORT", Value: instance.Status.InstanceInfo["port"]},
+		{Name: "PGDATABASE", Value: instance.Status.InstanceInfo["database"]},
+		{Name: "PGUSER", Value: superUser},
+		secretEnvVar("PGPASSWORD", credentialsSecretName(instance.Name), adminPasswordKey),
+		{Name: "ROLE_NAME", Value: roleName(connection)},
+	}
+	if action == roleJobDrop {
+		script = dropRoleScript
+	} else {
+		env = append(env, secretEnvVar("ROLE_PASSWORD", roleObjectName(connection), passwordKey))
+	}
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      roleJobName(connection, action),
+			Namespace: instance.Namespace,
+			Labels:    instanceLabels(instance),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: pointer.Int32(3),
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					RestartPolicy: corev1.RestartPolicyNever,
+					Containers: []corev1.Container{
+						{
+							Name:            "psql",
+							Image:           getPostgresImage(),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Command:         []string{"sh", "-c", script},
+							Env:             env,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LocalConnectionReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.LocalConnection{}).
+		Watches(&source.Kind{Type: &v1beta1.LocalInstance{}}, handler.EnqueueRequestsFromMapFunc(r.instanceToConnections)).
+		Complete(r)
+}
+
+// instanceToConnections maps a local instance to the connections using it
+func (r *LocalConnectionReconciler) instanceToConnections(obj client.Object) []reconcile.Request {
+	instance := obj.(*v1beta1.LocalInstance)
+	if len(instance.Status.InstanceID) == 0 {
+		return nil
+	}
+	var connectionList v1beta1.LocalConnectionList
+	if err := r.List(context.Background(), &connectionList); err != nil {
+		ctrl.Log.WithName("LocalConnectionReconciler").Error(err, "Error listing Local Connections")
+		return nil
+	}
+	var requests []reconcile.Request
+	for _, connection := range connectionList.Items {
+		if connection.Spec.DatabaseServiceID == instance.Status.InstanceID {
+			requests = append(requests, reconcile.Request{NamespacedName: client.ObjectKeyFromObject(&connection)})
+		}
+	}
+	return requests
+}
+
+// getInstance returns the local instance matching the database service ID of a connection, or nil if none matches
+func (r *LocalConnectionReconciler) getInstance(ctx context.Context, connection *v1beta1.LocalConnection) (*v1beta1.LocalInstance, error) {
+	var instanceList v1beta1.LocalInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		return nil, err
+	}
+	for i := range instanceList.Items {
+		if len(connection.Spec.DatabaseServiceID) > 0 && instanceList.Items[i].Status.InstanceID == connection.Spec.DatabaseServiceID {
+			return &instanceList.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *LocalConnectionReconciler) updateStatus(ctx context.Context, connection *v1beta1.LocalConnection,
+	status metav1.ConditionStatus, reason, message string) (ctrl.Result, error) {
+	apimeta.SetStatusCondition(&connection.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSConnectionProviderSyncType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Client.Status().Update(ctx, connection); err != nil {
+		if errors.IsConflict(err) {
+			ctrl.LoggerFrom(ctx).V(1).Info("Local Connection modified, retry syncing status")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// roleName returns the name of the database role of a connection, unique across the connections of an instance
+func roleName(connection *v1beta1.LocalConnection) string {
+	return "dbaas_" + strings.ReplaceAll(string(connection.UID), "-", "")
+}
+
+// roleObjectName returns the name of the Secret holding the database role credentials of a connection, in the namespace of the instance
+func roleObjectName(connection *v1beta1.LocalConnection) string {
+	return "connection-role-" + string(connection.UID)
+}
+
+func roleJobName(connection *v1beta1.LocalConnection, action string) string {
+	return roleObjectName(connection) + "-" + action
+}
+
+func connectionSecretName(connectionName string) string {
+	return connectionName + "-credentials"
+}
+
+func connectionConfigMapName(connectionName string) string {
+	return connectionName + "-configs"
+}
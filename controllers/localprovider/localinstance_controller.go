@@ -0,0 +1,320 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// LocalInstanceReconciler reconciles a LocalInstance object
+type LocalInstanceReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinstances,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinstances/status,verbs=get;update;patch
+//+kubebuilder:rbac:groups=apps,resources=statefulsets,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=services,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups="",resources=secrets,verbs=get;create;update;patch;delete
+
+// Reconcile provisions a PostgreSQL StatefulSet for a LocalInstance, and reports its status.
+func (r *LocalInstanceReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var instance v1beta1.LocalInstance
+	if err := r.Get(ctx, req.NamespacedName, &instance); err != nil {
+		if errors.IsNotFound(err) {
+			// CR deleted since request queued, child objects getting GC'd, no requeue
+			logger.V(1).Info("Local Instance resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Local Instance for reconcile")
+		return ctrl.Result{}, err
+	}
+	if instance.DeletionTimestamp != nil {
+		return ctrl.Result{}, nil
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: credentialsSecretName(instance.Name), Namespace: instance.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, secret, r.secretMutateFn(&instance, secret)); err != nil {
+		logger.Error(err, "Error reconciling the credentials Secret for Local Instance")
+		return r.reconcileError(ctx, &instance, err)
+	}
+
+	service := &corev1.Service{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, service, r.serviceMutateFn(&instance, service)); err != nil {
+		logger.Error(err, "Error reconciling the Service for Local Instance")
+		return r.reconcileError(ctx, &instance, err)
+	}
+
+	statefulSet := &appsv1.StatefulSet{ObjectMeta: metav1.ObjectMeta{Name: instance.Name, Namespace: instance.Namespace}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, statefulSet, r.statefulSetMutateFn(&instance, statefulSet)); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("StatefulSet modified, retry reconciling")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error reconciling the StatefulSet for Local Instance")
+		return r.reconcileError(ctx, &instance, err)
+	}
+
+	instance.Status.InstanceID = string(instance.UID)
+	instance.Status.InstanceInfo = map[string]string{
+		"host":     serviceHost(service),
+		"port":     strconv.Itoa(postgresPort),
+		"database": databaseName(&instance),
+		"type":     databaseType,
+	}
+	if statefulSet.Status.ReadyReplicas < 1 {
+		return r.updateStatus(ctx, &instance, v1beta1.InstancePhaseCreating, metav1.ConditionFalse, reasonProvisioning, "Waiting for the PostgreSQL StatefulSet to be ready")
+	}
+	return r.updateStatus(ctx, &instance, v1beta1.InstancePhaseReady, metav1.ConditionTrue, reasonSyncOK, "PostgreSQL instance is ready")
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LocalInstanceReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.LocalInstance{}).
+		Owns(&appsv1.StatefulSet{}).
+		Complete(r)
+}
+
+// reconcileError reports a reconcile error in the instance status
+func (r *LocalInstanceReconciler) reconcileError(ctx context.Context, instance *v1beta1.LocalInstance, err error) (ctrl.Result, error) {
+	if _, errStatus := r.updateStatus(ctx, instance, v1beta1.InstancePhaseError, metav1.ConditionFalse, v1beta1.ProviderReconcileError, err.Error()); errStatus != nil {
+		ctrl.LoggerFrom(ctx).Error(errStatus, "Error updating the Local Instance status")
+	}
+	return ctrl.Result{}, err
+}
+
+func (r *LocalInstanceReconciler) updateStatus(ctx context.Context, instance *v1beta1.LocalInstance, phase v1beta1.DBaasInstancePhase,
+	status metav1.ConditionStatus, reason, message string) (ctrl.Result, error) {
+	instance.Status.Phase = phase
+	apimeta.SetStatusCondition(&instance.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInstanceProviderSyncType,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := r.Client.Status().Update(ctx, instance); err != nil {
+		if errors.IsConflict(err) {
+			ctrl.LoggerFrom(ctx).V(1).Info("Local Instance modified, retry syncing status")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *LocalInstanceReconciler) secretMutateFn(instance *v1beta1.LocalInstance, secret *corev1.Secret) controllerutil.MutateFn {
+	return func() error {
+		secret.Labels = instanceLabels(instance)
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		if len(secret.Data[usernameKey]) == 0 {
+			secret.Data[usernameKey] = []byte(adminUser)
+		}
+		// the password of the superuser lets the connections manage their own database roles
+		for _, key := range []string{passwordKey, adminPasswordKey} {
+			if len(secret.Data[key]) == 0 {
+				password, err := generatePassword()
+				if err != nil {
+					return err
+				}
+				secret.Data[key] = []byte(password)
+			}
+		}
+		return setOwner(instance, secret, r.Scheme)
+	}
+}
+
+func (r *LocalInstanceReconciler) serviceMutateFn(instance *v1beta1.LocalInstance, service *corev1.Service) controllerutil.MutateFn {
+	return func() error {
+		service.Labels = instanceLabels(instance)
+		service.Spec.Selector = instanceLabels(instance)
+		service.Spec.Ports = []corev1.ServicePort{
+			{
+				Name:       databaseType,
+				Port:       postgresPort,
+				TargetPort: intstr.FromInt(postgresPort),
+				Protocol:   corev1.ProtocolTCP,
+			},
+		}
+		return setOwner(instance, service, r.Scheme)
+	}
+}
+
+func (r *LocalInstanceReconciler) statefulSetMutateFn(instance *v1beta1.LocalInstance, statefulSet *appsv1.StatefulSet) controllerutil.MutateFn {
+	return func() error {
+		statefulSet.Labels = instanceLabels(instance)
+		statefulSet.Spec.Replicas = pointer.Int32(1)
+		// the rest of the spec is immutable, only set it on creation
+		if statefulSet.CreationTimestamp.IsZero() {
+			storage, err := resource.ParseQuantity(storageGib(instance) + "Gi")
+			if err != nil {
+				return fmt.Errorf("invalid %s provisioning parameter: %w", v1beta1.ProvisioningStorageGib, err)
+			}
+			statefulSet.Spec.ServiceName = instance.Name
+			statefulSet.Spec.Selector = &metav1.LabelSelector{MatchLabels: instanceLabels(instance)}
+			statefulSet.Spec.PersistentVolumeClaimRetentionPolicy = &appsv1.StatefulSetPersistentVolumeClaimRetentionPolicy{
+				WhenDeleted: appsv1.DeletePersistentVolumeClaimRetentionPolicyType,
+				WhenScaled:  appsv1.RetainPersistentVolumeClaimRetentionPolicyType,
+			}
+			statefulSet.Spec.Template = corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: instanceLabels(instance),
+				},
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{
+						{
+							Name:            databaseType,
+							Image:           getPostgresImage(),
+							ImagePullPolicy: corev1.PullIfNotPresent,
+							Ports: []corev1.ContainerPort{
+								{
+									Name:          databaseType,
+									ContainerPort: postgresPort,
+									Protocol:      corev1.ProtocolTCP,
+								},
+							},
+							Env: []corev1.EnvVar{
+								secretEnvVar("POSTGRESQL_USER", credentialsSecretName(instance.Name), usernameKey),
+								secretEnvVar("POSTGRESQL_PASSWORD", credentialsSecretName(instance.Name), passwordKey),
+								secretEnvVar("POSTGRESQL_ADMIN_PASSWORD", credentialsSecretName(instance.Name), adminPasswordKey),
+								{Name: "POSTGRESQL_DATABASE", Value: databaseName(instance)},
+							},
+							ReadinessProbe: &corev1.Probe{
+								ProbeHandler: corev1.ProbeHandler{
+									Exec: &corev1.ExecAction{
+										Command: []string{"pg_isready", "-h", "127.0.0.1", "-p", strconv.Itoa(postgresPort)},
+									},
+								},
+								InitialDelaySeconds: 5,
+								PeriodSeconds:       10,
+							},
+							VolumeMounts: []corev1.VolumeMount{
+								{
+									Name:      "data",
+									MountPath: "/var/lib/pgsql/data",
+								},
+							},
+						},
+					},
+				},
+			}
+			statefulSet.Spec.VolumeClaimTemplates = []corev1.PersistentVolumeClaim{
+				{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "data",
+					},
+					Spec: corev1.PersistentVolumeClaimSpec{
+						AccessModes: []corev1.PersistentVolumeAccessMode{corev1.ReadWriteOnce},
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceStorage: storage,
+							},
+						},
+					},
+				},
+			}
+		}
+		return setOwner(instance, statefulSet, r.Scheme)
+	}
+}
+
+func instanceLabels(instance *v1beta1.LocalInstance) map[string]string {
+	return map[string]string{
+		"app.kubernetes.io/name":       databaseType,
+		"app.kubernetes.io/instance":   instance.Name,
+		"app.kubernetes.io/managed-by": "dbaas-operator",
+	}
+}
+
+func secretEnvVar(name, secretName, key string) corev1.EnvVar {
+	return corev1.EnvVar{
+		Name: name,
+		ValueFrom: &corev1.EnvVarSource{
+			SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: secretName},
+				Key:                  key,
+			},
+		},
+	}
+}
+
+func credentialsSecretName(instanceName string) string {
+	return instanceName + "-credentials"
+}
+
+func serviceHost(service *corev1.Service) string {
+	return fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+}
+
+// databaseName returns the name of the database created in the instance, derived from the name provisioning parameter,
+// with the characters not allowed in a database name replaced, and cut to the maximum length of a PostgreSQL identifier
+func databaseName(instance *v1beta1.LocalInstance) string {
+	name := instance.Spec.ProvisioningParameters[v1beta1.ProvisioningName]
+	if len(name) == 0 {
+		name = instance.Name
+	}
+	name = strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, strings.ToLower(name))
+	if len(name) > maxIdentifierLength {
+		name = name[:maxIdentifierLength]
+	}
+	return name
+}
+
+func storageGib(instance *v1beta1.LocalInstance) string {
+	if storage := instance.Spec.ProvisioningParameters[v1beta1.ProvisioningStorageGib]; len(storage) > 0 {
+		return storage
+	}
+	return defaultStorageGib
+}
+
+func generatePassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
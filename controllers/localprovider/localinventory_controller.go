@@ -0,0 +1,113 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// LocalInventoryReconciler reconciles a LocalInventory object
+type LocalInventoryReconciler struct {
+	client.Client
+	Scheme *runtime.Scheme
+}
+
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinventories,verbs=get;list;watch;update;patch
+//+kubebuilder:rbac:groups=dbaas.redhat.com,resources=localinventories/status,verbs=get;update;patch
+
+// Reconcile lists the local instances provisioned against a LocalInventory as its database services.
+func (r *LocalInventoryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	var inventory v1beta1.LocalInventory
+	if err := r.Get(ctx, req.NamespacedName, &inventory); err != nil {
+		if errors.IsNotFound(err) {
+			logger.V(1).Info("Local Inventory resource not found, has been deleted")
+			return ctrl.Result{}, nil
+		}
+		logger.Error(err, "Error fetching Local Inventory for reconcile")
+		return ctrl.Result{}, err
+	}
+
+	var instanceList v1beta1.LocalInstanceList
+	if err := r.List(ctx, &instanceList); err != nil {
+		logger.Error(err, "Error listing Local Instances")
+		return ctrl.Result{}, err
+	}
+	serviceType := localServiceType
+	services := []v1beta1.DatabaseService{}
+	for _, instance := range instanceList.Items {
+		if instanceInventory(&instance) != client.ObjectKeyFromObject(&inventory) || len(instance.Status.InstanceID) == 0 {
+			continue
+		}
+		services = append(services, v1beta1.DatabaseService{
+			ServiceID:   instance.Status.InstanceID,
+			ServiceName: instance.Name,
+			ServiceType: &serviceType,
+			ServiceInfo: instance.Status.InstanceInfo,
+		})
+	}
+
+	inventory.Status.DatabaseServices = services
+	apimeta.SetStatusCondition(&inventory.Status.Conditions, metav1.Condition{
+		Type:    v1beta1.DBaaSInventoryProviderSyncType,
+		Status:  metav1.ConditionTrue,
+		Reason:  reasonSyncOK,
+		Message: "Local instances listed",
+	})
+	if err := r.Client.Status().Update(ctx, &inventory); err != nil {
+		if errors.IsConflict(err) {
+			logger.V(1).Info("Local Inventory modified, retry syncing status")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		logger.Error(err, "Error updating the Local Inventory status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *LocalInventoryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&v1beta1.LocalInventory{}).
+		Watches(&source.Kind{Type: &v1beta1.LocalInstance{}}, handler.EnqueueRequestsFromMapFunc(func(obj client.Object) []reconcile.Request {
+			return []reconcile.Request{{NamespacedName: instanceInventory(obj.(*v1beta1.LocalInstance))}}
+		})).
+		Complete(r)
+}
+
+// instanceInventory returns the key of the inventory referenced by an instance
+func instanceInventory(instance *v1beta1.LocalInstance) types.NamespacedName {
+	ns := instance.Spec.InventoryRef.Namespace
+	if len(ns) == 0 {
+		ns = instance.Namespace
+	}
+	return types.NamespacedName{Namespace: ns, Name: instance.Spec.InventoryRef.Name}
+}
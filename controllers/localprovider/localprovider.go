@@ -0,0 +1,165 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package localprovider implements a built-in reference DBaaS provider, which provisions PostgreSQL
+// as StatefulSets in the cluster. It exercises the whole provider lifecycle without any external service.
+package localprovider
+
+import (
+	"context"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+const (
+	// PostgresImageEnvVar is the env variable overriding the PostgreSQL image used for local instances
+	PostgresImageEnvVar = "RELATED_IMAGE_LOCAL_PROVIDER_POSTGRESQL"
+
+	defaultPostgresImage = "registry.redhat.io/rhel9/postgresql-15:1-45"
+	postgresPort         = 5432
+	defaultStorageGib    = "1"
+	databaseType         = "postgresql"
+
+	// the maximum length of a PostgreSQL identifier, such as a database name
+	maxIdentifierLength = 63
+
+	// the type of the database services of the local inventories
+	localServiceType v1beta1.DatabaseServiceType = "instance"
+
+	// keys of the secret holding the instance credentials
+	usernameKey      = "username"
+	passwordKey      = "password"
+	adminPasswordKey = "admin-password"
+	adminUser        = "dbaas"
+	superUser        = "postgres"
+
+	// provider condition reasons
+	reasonSyncOK       = "SyncOK"
+	reasonProvisioning = "Provisioning"
+	reasonNotFound     = "NotFound"
+)
+
+// SetupWithManager registers the local DBaaSProvider and sets up its controllers with the Manager.
+func SetupWithManager(mgr ctrl.Manager) error {
+	if err := mgr.Add(manager.RunnableFunc(func(ctx context.Context) error {
+		return registerProvider(ctx, mgr.GetClient())
+	})); err != nil {
+		return err
+	}
+	if err := (&LocalInventoryReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	if err := (&LocalInstanceReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	return (&LocalConnectionReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+	}).SetupWithManager(mgr)
+}
+
+// registerProvider creates or updates the DBaaSProvider of the local provider
+func registerProvider(ctx context.Context, cli client.Client) error {
+	logger := ctrl.LoggerFrom(ctx)
+	provider := &v1beta1.DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: v1beta1.LocalRegistration,
+		},
+	}
+	res, err := controllerutil.CreateOrUpdate(ctx, cli, provider, func() error {
+		if provider.Labels == nil {
+			provider.Labels = map[string]string{}
+		}
+		provider.Labels["app.kubernetes.io/managed-by"] = "dbaas-operator"
+		provider.Spec = GetProviderSpec()
+		return nil
+	})
+	if err != nil {
+		logger.Error(err, "Error registering the local DBaaS Provider")
+		return err
+	}
+	logger.Info("Local DBaaS Provider registered", "result", res)
+	return nil
+}
+
+// GetProviderSpec returns the spec of the DBaaSProvider of the local provider
+func GetProviderSpec() v1beta1.DBaaSProviderSpec {
+	return v1beta1.DBaaSProviderSpec{
+		Provider: v1beta1.DatabaseProviderInfo{
+			Name:               "Red Hat DBaaS / Local PostgreSQL",
+			DisplayName:        "Local PostgreSQL",
+			DisplayDescription: "A reference provider running PostgreSQL in the cluster, for development and testing.",
+			Icon: v1beta1.ProviderIcon{
+				Data:      "PHN2ZyB4bWxucz0iaHR0cDovL3d3dy53My5vcmcvMjAwMC9zdmciIHZpZXdCb3g9IjAgMCAzMiAzMiI+PGNpcmNsZSBjeD0iMTYiIGN5PSIxNiIgcj0iMTQiIGZpbGw9IiMzMzY3OTEiLz48L3N2Zz4=",
+				MediaType: "image/svg+xml",
+			},
+		},
+		GroupVersion:                 v1beta1.GroupVersion.String(),
+		InventoryKind:                v1beta1.LocalInventoryKind,
+		ConnectionKind:               v1beta1.LocalConnectionKind,
+		InstanceKind:                 v1beta1.LocalInstanceKind,
+		CredentialFields:             []v1beta1.CredentialField{},
+		AllowsFreeTrial:              true,
+		ExternalProvisionURL:         "",
+		ExternalProvisionDescription: "",
+		ProvisioningParameters: map[v1beta1.ProvisioningParameterType]v1beta1.ProvisioningParameter{
+			v1beta1.ProvisioningName: {
+				DisplayName: "Cluster name",
+			},
+			v1beta1.ProvisioningStorageGib: {
+				DisplayName: "Storage (GiB)",
+				ConditionalData: []v1beta1.ConditionalProvisioningParameterData{
+					{
+						DefaultValue: defaultStorageGib,
+					},
+				},
+			},
+		},
+		Capabilities: &v1beta1.DBaaSProviderCapabilities{
+			Updates:              pointer.Bool(false),
+			AccessModes:          []v1beta1.ConnectionAccessMode{v1beta1.AccessModeReadWrite},
+			DatabaseServiceTypes: []v1beta1.DatabaseServiceType{localServiceType},
+		},
+	}
+}
+
+func getPostgresImage() string {
+	if image, found := os.LookupEnv(PostgresImageEnvVar); found && len(image) > 0 {
+		return image
+	}
+	return defaultPostgresImage
+}
+
+// setOwner sets the owner of a child object, replacing any previous owner
+func setOwner(owner, object client.Object, scheme *runtime.Scheme) error {
+	object.SetOwnerReferences(nil)
+	return ctrl.SetControllerReference(owner, object, scheme)
+}
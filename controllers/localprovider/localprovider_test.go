@@ -0,0 +1,190 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package localprovider
+
+import (
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+var _ = Describe("Local provider", func() {
+	It("should register the local DBaaSProvider", func() {
+		provider := &v1beta1.DBaaSProvider{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, types.NamespacedName{Name: v1beta1.LocalRegistration}, provider)
+		}, timeout).Should(Succeed())
+		Expect(provider.Spec.InventoryKind).Should(Equal(v1beta1.LocalInventoryKind))
+		Expect(provider.Spec.ConnectionKind).Should(Equal(v1beta1.LocalConnectionKind))
+		Expect(provider.Spec.InstanceKind).Should(Equal(v1beta1.LocalInstanceKind))
+		Expect(provider.GetCapabilities().DatabaseServiceTypes).Should(Equal([]v1beta1.DatabaseServiceType{localServiceType}))
+	})
+
+	It("should derive a valid database name", func() {
+		instance := &v1beta1.LocalInstance{ObjectMeta: metav1.ObjectMeta{Name: "test-instance.example"}}
+		Expect(databaseName(instance)).Should(Equal("test_instance_example"))
+		instance.Spec.ProvisioningParameters = map[v1beta1.ProvisioningParameterType]string{
+			v1beta1.ProvisioningName: "My DB-" + strings.Repeat("x", 70),
+		}
+		Expect(databaseName(instance)).Should(Equal("my_db_" + strings.Repeat("x", 57)))
+	})
+
+	Context("after creating the inventory, instance and connection", func() {
+		inventory := &v1beta1.LocalInventory{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "local-inventory",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSInventorySpec{
+				CredentialsRef: &v1beta1.LocalObjectReference{Name: "local-credentials"},
+			},
+		}
+		instance := &v1beta1.LocalInstance{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "local-instance",
+				Namespace: testNamespace,
+			},
+			Spec: v1beta1.DBaaSInstanceSpec{
+				InventoryRef: v1beta1.NamespacedName{
+					Name:      inventory.Name,
+					Namespace: testNamespace,
+				},
+				ProvisioningParameters: map[v1beta1.ProvisioningParameterType]string{
+					v1beta1.ProvisioningName: "my-db",
+				},
+			},
+		}
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, inventory)).Should(Succeed())
+			Expect(k8sClient.Create(ctx, instance)).Should(Succeed())
+		})
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, instance)).Should(Succeed())
+			Expect(k8sClient.Delete(ctx, inventory)).Should(Succeed())
+		})
+
+		It("should provision the instance and bind the connection", func() {
+			By("checking the instance resources are created")
+			statefulSet := &appsv1.StatefulSet{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), statefulSet)
+			}, timeout).Should(Succeed())
+			Expect(k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), &corev1.Service{})).Should(Succeed())
+			secret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: credentialsSecretName(instance.Name), Namespace: testNamespace}, secret)).Should(Succeed())
+			Expect(secret.Data[passwordKey]).ShouldNot(BeEmpty())
+
+			By("checking the instance is creating")
+			Eventually(func() (v1beta1.DBaasInstancePhase, error) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+				return instance.Status.Phase, err
+			}, timeout).Should(Equal(v1beta1.InstancePhaseCreating))
+			Expect(instance.Status.InstanceID).Should(Equal(string(instance.UID)))
+			Expect(instance.Status.InstanceInfo["database"]).Should(Equal("my_db"))
+
+			By("making the StatefulSet ready")
+			statefulSet.Status.Replicas = 1
+			statefulSet.Status.ReadyReplicas = 1
+			Expect(k8sClient.Status().Update(ctx, statefulSet)).Should(Succeed())
+			Eventually(func() (v1beta1.DBaasInstancePhase, error) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(instance), instance)
+				return instance.Status.Phase, err
+			}, timeout).Should(Equal(v1beta1.InstancePhaseReady))
+			Expect(apimeta.IsStatusConditionTrue(instance.Status.Conditions, v1beta1.DBaaSInstanceProviderSyncType)).Should(BeTrue())
+
+			By("checking the inventory lists the instance")
+			Eventually(func() ([]v1beta1.DatabaseService, error) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(inventory), inventory)
+				return inventory.Status.DatabaseServices, err
+			}, timeout).Should(HaveLen(1))
+			Expect(inventory.Status.DatabaseServices[0].ServiceID).Should(Equal(instance.Status.InstanceID))
+			Expect(apimeta.IsStatusConditionTrue(inventory.Status.Conditions, v1beta1.DBaaSInventoryProviderSyncType)).Should(BeTrue())
+
+			By("creating a connection to the instance")
+			connection := &v1beta1.LocalConnection{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "local-connection",
+					Namespace: testNamespace,
+				},
+				Spec: v1beta1.DBaaSConnectionSpec{
+					InventoryRef: v1beta1.NamespacedName{
+						Name:      inventory.Name,
+						Namespace: testNamespace,
+					},
+					DatabaseServiceID: instance.Status.InstanceID,
+				},
+			}
+			Expect(k8sClient.Create(ctx, connection)).Should(Succeed())
+
+			By("creating the database role of the connection")
+			completeRoleJob(connection, roleJobCreate)
+			Eventually(func() (bool, error) {
+				err := k8sClient.Get(ctx, client.ObjectKeyFromObject(connection), connection)
+				return apimeta.IsStatusConditionTrue(connection.Status.Conditions, v1beta1.DBaaSConnectionProviderSyncType), err
+			}, timeout).Should(BeTrue())
+			Expect(connection.Finalizers).Should(ContainElement(localConnectionFinalizer))
+
+			By("checking the connection credentials and info")
+			roleSecret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: roleObjectName(connection), Namespace: testNamespace}, roleSecret)).Should(Succeed())
+			connSecret := &corev1.Secret{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: connection.Status.CredentialsRef.Name, Namespace: testNamespace}, connSecret)).Should(Succeed())
+			Expect(string(connSecret.Data[usernameKey])).Should(Equal(roleName(connection)))
+			Expect(connSecret.Data[passwordKey]).Should(Equal(roleSecret.Data[passwordKey]))
+			Expect(connSecret.Data[passwordKey]).ShouldNot(Equal(secret.Data[passwordKey]))
+			configMap := &corev1.ConfigMap{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: connection.Status.ConnectionInfoRef.Name, Namespace: testNamespace}, configMap)).Should(Succeed())
+			Expect(configMap.Data["host"]).Should(Equal("local-instance.default.svc"))
+			Expect(configMap.Data["database"]).Should(Equal("my_db"))
+
+			By("dropping the database role of the deleted connection")
+			Expect(k8sClient.Delete(ctx, connection)).Should(Succeed())
+			completeRoleJob(connection, roleJobDrop)
+			Eventually(func() bool {
+				return errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(connection), connection))
+			}, timeout).Should(BeTrue())
+			Expect(errors.IsNotFound(k8sClient.Get(ctx, client.ObjectKeyFromObject(roleSecret), roleSecret))).Should(BeTrue())
+		})
+	})
+})
+
+// completeRoleJob reports the success of a Job managing the database role of a connection, as there is no Job controller in the test environment
+func completeRoleJob(connection *v1beta1.LocalConnection, action string) {
+	job := &batchv1.Job{}
+	Eventually(func() error {
+		return k8sClient.Get(ctx, types.NamespacedName{Name: roleJobName(connection, action), Namespace: testNamespace}, job)
+	}, timeout).Should(Succeed())
+	Expect(job.Spec.Template.Spec.Containers[0].Command).Should(ContainElement(ContainSubstring("psql")))
+	now := metav1.Now()
+	job.Status.StartTime = &now
+	job.Status.CompletionTime = &now
+	job.Status.Succeeded = 1
+	job.Status.Conditions = []batchv1.JobCondition{{Type: batchv1.JobComplete, Status: corev1.ConditionTrue}}
+	Expect(k8sClient.Status().Update(ctx, job)).Should(Succeed())
+}
@@ -14,7 +14,11 @@ import (
 
 const (
 	// Metric Names
-	MetricNameConnectionStatusReady = "dbaas_connection_status_ready"
+	MetricNameConnectionStatusReady  = "dbaas_connection_status_ready"
+	MetricNameConnectionProbeLatency = "dbaas_connection_probe_latency_seconds"
+	MetricNameConnectionReachable    = "dbaas_connection_reachable"
+	MetricNameConnectionExpiry       = "dbaas_connection_expiry_timestamp_seconds"
+	MetricNameConnectionExpired      = "dbaas_connection_expired"
 
 	// Resource label values
 	LabelResourceValueConnection = "dbaas_connection"
@@ -25,6 +29,12 @@ const (
 	LabelErrorCdValueInvalidNameSpace              = "dbaas_connection_err_invalid_name_space"
 	LabelErrorCdCannotReadInstance                 = "dbaas_connection_cannot_read_instance"
 	LabelErrorCdValueErrorDeletingConnection       = "error_deleting_connection"
+	LabelErrorCdValueErrReconcilingServiceBinding  = "dbaas_connection_err_reconciling_service_binding"
+	LabelErrorCdValueErrRestartingWorkloads        = "dbaas_connection_err_restarting_workloads"
+	LabelErrorCdValueErrInjectingWorkloads         = "dbaas_connection_err_injecting_workloads"
+	LabelErrorCdValueErrProbingConnection          = "dbaas_connection_err_probing_connection"
+	LabelErrorCdValueErrRevokingConnection         = "dbaas_connection_err_revoking_connection"
+	LabelErrorCdValueErrExpiringConnection         = "dbaas_connection_err_expiring_connection"
 
 	// Label Names
 	MetricLabelConnectionName = "name"
@@ -36,6 +46,67 @@ var DBaaSConnectionStatusGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
 	Help: "The status of DBaaS connections, values ( ready=1, error / not ready=0 )",
 }, []string{MetricLabelProvider, MetricLabelAccountName, MetricLabelInstanceID, MetricLabelConnectionName, MetricLabelNameSpace, MetricLabelStatus, MetricLabelReason, MetricLabelCreationTimestamp})
 
+// DBaaSConnectionProbeLatencyHistogram defines a histogram for the latency of the connection probes
+var DBaaSConnectionProbeLatencyHistogram = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    MetricNameConnectionProbeLatency,
+	Help:    "The latency of the probes checking DBaaS connections are reachable from the cluster",
+	Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5},
+}, []string{MetricLabelProvider, MetricLabelConnectionName, MetricLabelNameSpace})
+
+// DBaaSConnectionReachableGauge defines a gauge for the result of the connection probes
+var DBaaSConnectionReachableGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: MetricNameConnectionReachable,
+	Help: "Whether the database of DBaaS connections is reachable from the cluster, values ( reachable=1, unreachable=0 )",
+}, []string{MetricLabelProvider, MetricLabelConnectionName, MetricLabelNameSpace})
+
+// DBaaSConnectionExpiryGauge defines a gauge for the expiry time of the expiring connections
+var DBaaSConnectionExpiryGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: MetricNameConnectionExpiry,
+	Help: "The time DBaaS connections expire, in seconds since the epoch, for the connections with an expiresAt or a ttl",
+}, []string{MetricLabelProvider, MetricLabelConnectionName, MetricLabelNameSpace})
+
+// DBaaSConnectionExpiredGauge defines a gauge for whether the expiring connections expired
+var DBaaSConnectionExpiredGauge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: MetricNameConnectionExpired,
+	Help: "Whether DBaaS connections with an expiresAt or a ttl expired, values ( expired=1, expiring=0 )",
+}, []string{MetricLabelProvider, MetricLabelConnectionName, MetricLabelNameSpace})
+
+// SetConnectionExpiryMetrics set the Metrics for an expiring connection
+func SetConnectionExpiryMetrics(provider string, connection dbaasv1beta1.DBaaSConnection, expiresAt time.Time, expired bool) {
+	labels := prometheus.Labels{MetricLabelProvider: provider, MetricLabelConnectionName: connection.Name, MetricLabelNameSpace: connection.Namespace}
+	DBaaSConnectionExpiryGauge.With(labels).Set(float64(expiresAt.Unix()))
+	if expired {
+		DBaaSConnectionExpiredGauge.With(labels).Set(1)
+	} else {
+		DBaaSConnectionExpiredGauge.With(labels).Set(0)
+	}
+}
+
+// DeleteConnectionExpiryMetrics deletes the Metrics of a deleted connection, or of a connection no longer expiring
+func DeleteConnectionExpiryMetrics(connection dbaasv1beta1.DBaaSConnection) {
+	labels := prometheus.Labels{MetricLabelConnectionName: connection.Name, MetricLabelNameSpace: connection.Namespace}
+	DBaaSConnectionExpiryGauge.DeletePartialMatch(labels)
+	DBaaSConnectionExpiredGauge.DeletePartialMatch(labels)
+}
+
+// SetConnectionProbeMetrics set the Metrics for a connection probe, the latency is only observed for a reachable database
+func SetConnectionProbeMetrics(provider string, connection dbaasv1beta1.DBaaSConnection, latency time.Duration, reachable bool) {
+	labels := prometheus.Labels{MetricLabelProvider: provider, MetricLabelConnectionName: connection.Name, MetricLabelNameSpace: connection.Namespace}
+	if reachable {
+		DBaaSConnectionProbeLatencyHistogram.With(labels).Observe(latency.Seconds())
+		DBaaSConnectionReachableGauge.With(labels).Set(1)
+	} else {
+		DBaaSConnectionReachableGauge.With(labels).Set(0)
+	}
+}
+
+// DeleteConnectionProbeMetrics deletes the Metrics of the probes of a deleted connection
+func DeleteConnectionProbeMetrics(connection dbaasv1beta1.DBaaSConnection) {
+	labels := prometheus.Labels{MetricLabelConnectionName: connection.Name, MetricLabelNameSpace: connection.Namespace}
+	DBaaSConnectionProbeLatencyHistogram.DeletePartialMatch(labels)
+	DBaaSConnectionReachableGauge.DeletePartialMatch(labels)
+}
+
 // SetConnectionMetrics set the Metrics for a connection
 func SetConnectionMetrics(provider string, account string, connection dbaasv1beta1.DBaaSConnection, execution Execution, event string, errCd string) {
 	log := ctrl.Log.WithName("Setting DBaaSConnection Metrics")
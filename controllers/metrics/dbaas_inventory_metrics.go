@@ -24,6 +24,7 @@ const (
 	LabelErrorCdValueErrorUpdatingInventoryStatus         = "error_updating_inventory_status"
 	LabelErrorCdValueErrorDeletingInventory               = "error_deleting_inventory"
 	LabelErrorCdValueErrCheckingInventory                 = "error_checking_inventory"
+	LabelErrorCdValueErrCheckingInventoryCredentials      = "error_checking_inventory_credentials"
 )
 
 // DBaaSInventoryStatusGauge defines a gauge for DBaaSInventoryStatus
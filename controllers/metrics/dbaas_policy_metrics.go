@@ -17,6 +17,7 @@ const (
 	LabelErrorCdValueErrorResourceQuotaModified        = "error_resource_quota_modified"
 	LabelErrorCdValueErrUpdatingResourceQuota          = "error_updating_resource_quota"
 	LabelErrorCdValueErrorDeletingPolicy               = "error_deleting_dbaas_policy"
+	LabelErrorCdValueErrCountingQuotaUsage             = "error_counting_quota_usage"
 )
 
 // SetPolicyMetrics set the Metrics for policy
@@ -17,7 +17,11 @@ const (
 	LabelErrorCdValueErrorWatchingInventoryCR            = "error_watching_inventory_cr"
 	LabelErrorCdValueErrorWatchingConnectionCR           = "error_watching_connection_cr"
 	LabelErrorCdValueErrorWatchingInstanceCR             = "error_watching_instance_cr"
+	LabelErrorCdValueErrorWatchingDatabaseCR             = "error_watching_database_cr"
+	LabelErrorCdValueErrorWatchingBackupCR               = "error_watching_backup_cr"
+	LabelErrorCdValueErrorWatchingRestoreCR              = "error_watching_restore_cr"
 	LabelErrorCdValueErrorDeletingProvider               = "error_deleting_dbaas_provider"
+	LabelErrorCdValueErrorUpdatingProviderStatus         = "error_updating_dbaas_provider_status"
 )
 
 // setProviderRequestDurationSeconds set the metrics for provider request duration in seconds
@@ -0,0 +1,277 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package probe checks that the database of a DBaaSConnection is reachable from the cluster.
+// It dials the host and port published in the connection information, and completes the first
+// exchange of the database protocol for PostgreSQL, MySQL and MongoDB, without authenticating.
+package probe
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"time"
+)
+
+const (
+	// database types, as published in the type entry of the connection information
+	TypePostgreSQL = "postgresql"
+	TypeMySQL      = "mysql"
+	TypeMongoDB    = "mongodb"
+
+	// connection information entries used by the probe
+	typeKey = "type"
+	hostKey = "host"
+	portKey = "port"
+	srvKey  = "srv"
+	tlsKey  = "tls"
+	sslKey  = "ssl"
+
+	postgresSSLRequestCode = 80877103
+	mongoOpMsg             = 2013
+)
+
+var defaultPorts = map[string]string{
+	TypePostgreSQL: "5432",
+	TypeMySQL:      "3306",
+	TypeMongoDB:    "27017",
+}
+
+// services of the DNS SRV records resolving the endpoints of the database types supporting SRV URIs, such as mongodb+srv
+var srvServices = map[string]string{
+	TypeMongoDB: "mongodb",
+}
+
+// aliases of the database types used by providers
+var typeAliases = map[string]string{
+	"postgres": TypePostgreSQL,
+	"mariadb":  TypeMySQL,
+	"mongo":    TypeMongoDB,
+}
+
+// Target is a database endpoint to probe
+type Target struct {
+	// Type is the database type, the probe is limited to a TCP dial for other types
+	Type string
+	Host string
+	Port string
+	// SRV resolves the endpoint from the DNS SRV record of Host, as done by mongodb+srv URIs, for the types supporting it
+	SRV bool
+	// TLS dials the endpoint with TLS, for the protocols not negotiating it in-band
+	TLS bool
+}
+
+// TargetFromConnectionInfo builds the target of a probe from the connection information published by a provider
+func TargetFromConnectionInfo(data map[string]string) (Target, error) {
+	target := Target{
+		Type: data[typeKey],
+		Host: data[hostKey],
+		Port: data[portKey],
+		SRV:  data[srvKey] == "true",
+		TLS:  data[tlsKey] == "true" || data[sslKey] == "true",
+	}
+	if alias, ok := typeAliases[target.Type]; ok {
+		target.Type = alias
+	}
+	if len(target.Host) == 0 {
+		return target, fmt.Errorf("the connection information has no %s entry", hostKey)
+	}
+	if host, port, err := net.SplitHostPort(target.Host); err == nil {
+		target.Host = host
+		if len(target.Port) == 0 {
+			target.Port = port
+		}
+	}
+	if _, ok := srvServices[target.Type]; target.SRV && !ok {
+		return target, fmt.Errorf("SRV records are not supported for the %q database type", target.Type)
+	}
+	if target.SRV {
+		// the SRV record of a MongoDB Atlas cluster resolves to endpoints requiring TLS
+		target.TLS = true
+	} else if len(target.Port) == 0 {
+		port, ok := defaultPorts[target.Type]
+		if !ok {
+			return target, fmt.Errorf("the connection information has no %s entry", portKey)
+		}
+		target.Port = port
+	}
+	return target, nil
+}
+
+// Probe connects to the target, and returns the latency of the connection and of the protocol exchange.
+// The probe is bounded by the deadline of the context.
+func Probe(ctx context.Context, target Target) (time.Duration, error) {
+	start := time.Now()
+	addr, err := resolve(ctx, target)
+	if err != nil {
+		return 0, err
+	}
+	conn, err := dial(ctx, target, addr)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+	if deadline, ok := ctx.Deadline(); ok {
+		if err := conn.SetDeadline(deadline); err != nil {
+			return 0, err
+		}
+	}
+
+	switch target.Type {
+	case TypePostgreSQL:
+		err = pingPostgreSQL(conn)
+	case TypeMySQL:
+		err = pingMySQL(conn)
+	case TypeMongoDB:
+		err = pingMongoDB(conn)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%s ping to %s failed: %w", target.Type, addr, err)
+	}
+	return time.Since(start), nil
+}
+
+// resolve returns the address to dial
+func resolve(ctx context.Context, target Target) (string, error) {
+	if !target.SRV {
+		return net.JoinHostPort(target.Host, target.Port), nil
+	}
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, srvServices[target.Type], "tcp", target.Host)
+	if err != nil {
+		return "", err
+	}
+	if len(records) == 0 {
+		return "", fmt.Errorf("no SRV record found for %s", target.Host)
+	}
+	return net.JoinHostPort(records[0].Target, strconv.Itoa(int(records[0].Port))), nil
+}
+
+func dial(ctx context.Context, target Target, addr string) (net.Conn, error) {
+	dialer := &net.Dialer{}
+	// PostgreSQL and MySQL negotiate TLS after the first exchange, which is enough for the probe
+	if !target.TLS || target.Type == TypePostgreSQL || target.Type == TypeMySQL {
+		return dialer.DialContext(ctx, "tcp", addr)
+	}
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return nil, err
+	}
+	tlsDialer := &tls.Dialer{
+		NetDialer: dialer,
+		Config:    &tls.Config{ServerName: host, MinVersion: tls.VersionTLS12},
+	}
+	return tlsDialer.DialContext(ctx, "tcp", addr)
+}
+
+// pingPostgreSQL sends an SSLRequest message, which a PostgreSQL server answers with a single byte before any authentication
+func pingPostgreSQL(conn net.Conn) error {
+	request := make([]byte, 8)
+	binary.BigEndian.PutUint32(request[0:4], 8)
+	binary.BigEndian.PutUint32(request[4:8], postgresSSLRequestCode)
+	if _, err := conn.Write(request); err != nil {
+		return err
+	}
+	response := make([]byte, 1)
+	if _, err := io.ReadFull(conn, response); err != nil {
+		return err
+	}
+	switch response[0] {
+	case 'S', 'N':
+		return nil
+	case 'E':
+		return fmt.Errorf("the server returned an error")
+	default:
+		return fmt.Errorf("unexpected response %q", response[0])
+	}
+}
+
+// pingMySQL reads the initial handshake packet a MySQL server sends to a new client
+func pingMySQL(conn net.Conn) error {
+	header := make([]byte, 4)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	length := int(header[0]) | int(header[1])<<8 | int(header[2])<<16
+	if length == 0 {
+		return fmt.Errorf("empty handshake packet")
+	}
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(conn, payload); err != nil {
+		return err
+	}
+	switch payload[0] {
+	case 0x0a:
+		return nil
+	case 0xff:
+		// error packet: 0xff, error code, message
+		if len(payload) > 3 {
+			return fmt.Errorf("the server returned error %d: %s", binary.LittleEndian.Uint16(payload[1:3]), payload[3:])
+		}
+		return fmt.Errorf("the server returned an error")
+	default:
+		return fmt.Errorf("unsupported protocol version %d", payload[0])
+	}
+}
+
+// pingMongoDB runs the hello command, which a MongoDB server answers before any authentication
+func pingMongoDB(conn net.Conn) error {
+	const requestID = 1
+	// BSON document {hello: 1, $db: "admin"}
+	var doc []byte
+	doc = append(doc, 0x10)
+	doc = append(doc, "hello\x00"...)
+	doc = appendUint32(doc, 1)
+	doc = append(doc, 0x02)
+	doc = append(doc, "$db\x00"...)
+	doc = appendUint32(doc, uint32(len("admin")+1))
+	doc = append(doc, "admin\x00"...)
+	doc = append(doc, 0x00)
+	doc = append(appendUint32(nil, uint32(len(doc)+4)), doc...)
+
+	// OP_MSG: header, flag bits, body section
+	length := 16 + 4 + 1 + len(doc)
+	message := make([]byte, 0, length)
+	message = appendUint32(message, uint32(length))
+	message = appendUint32(message, requestID)
+	message = appendUint32(message, 0)
+	message = appendUint32(message, mongoOpMsg)
+	message = appendUint32(message, 0)
+	message = append(message, 0x00)
+	message = append(message, doc...)
+	if _, err := conn.Write(message); err != nil {
+		return err
+	}
+
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(conn, header); err != nil {
+		return err
+	}
+	if responseTo := binary.LittleEndian.Uint32(header[8:12]); responseTo != requestID {
+		return fmt.Errorf("unexpected response to request %d", responseTo)
+	}
+	if opCode := binary.LittleEndian.Uint32(header[12:16]); opCode != mongoOpMsg {
+		return fmt.Errorf("unexpected response op code %d", opCode)
+	}
+	return nil
+}
+
+func appendUint32(b []byte, v uint32) []byte {
+	return append(b, byte(v), byte(v>>8), byte(v>>16), byte(v>>24))
+}
@@ -0,0 +1,194 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package probe
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
+)
+
+func TestProbe(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecsWithDefaultAndCustomReporters(t,
+		"Connection Probe Suite",
+		[]Reporter{printer.NewlineReporter{}})
+}
+
+// standInServer accepts connections on a local port, and serves each of them with the given function
+func standInServer(serve func(conn net.Conn)) (Target, func()) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	Expect(err).ShouldNot(HaveOccurred())
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				defer conn.Close()
+				serve(conn)
+			}()
+		}
+	}()
+	host, port, err := net.SplitHostPort(listener.Addr().String())
+	Expect(err).ShouldNot(HaveOccurred())
+	return Target{Host: host, Port: port}, func() { listener.Close() }
+}
+
+func probe(target Target) (time.Duration, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	return Probe(ctx, target)
+}
+
+var _ = Describe("Connection probe", func() {
+	Context("target", func() {
+		It("should read the endpoint from the connection information", func() {
+			Expect(TargetFromConnectionInfo(map[string]string{"type": "postgres", "host": "db.example.com"})).
+				Should(Equal(Target{Type: TypePostgreSQL, Host: "db.example.com", Port: "5432"}))
+			Expect(TargetFromConnectionInfo(map[string]string{"type": "mysql", "host": "db.example.com:3307"})).
+				Should(Equal(Target{Type: TypeMySQL, Host: "db.example.com", Port: "3307"}))
+			Expect(TargetFromConnectionInfo(map[string]string{"type": "mongodb", "host": "cluster.example.com", "srv": "true"})).
+				Should(Equal(Target{Type: TypeMongoDB, Host: "cluster.example.com", SRV: true, TLS: true}))
+		})
+		It("should fail without an endpoint", func() {
+			_, err := TargetFromConnectionInfo(map[string]string{"type": "postgresql"})
+			Expect(err).Should(HaveOccurred())
+			_, err = TargetFromConnectionInfo(map[string]string{"type": "other", "host": "db.example.com"})
+			Expect(err).Should(HaveOccurred())
+		})
+		It("should fail with an SRV record for a type without SRV URIs", func() {
+			_, err := TargetFromConnectionInfo(map[string]string{"type": "postgresql", "host": "db.example.com", "srv": "true"})
+			Expect(err).Should(MatchError(ContainSubstring("SRV records are not supported")))
+		})
+	})
+
+	Context("TCP", func() {
+		It("should reach a listening server", func() {
+			target, stop := standInServer(func(conn net.Conn) {})
+			defer stop()
+			latency, err := probe(target)
+			Expect(err).ShouldNot(HaveOccurred())
+			Expect(latency).Should(BeNumerically(">", 0))
+		})
+		It("should not reach a closed port", func() {
+			target, stop := standInServer(func(conn net.Conn) {})
+			stop()
+			_, err := probe(target)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("PostgreSQL", func() {
+		It("should ping a server", func() {
+			target, stop := standInServer(func(conn net.Conn) {
+				request := make([]byte, 8)
+				if _, err := io.ReadFull(conn, request); err != nil {
+					return
+				}
+				if binary.BigEndian.Uint32(request[4:8]) == postgresSSLRequestCode {
+					_, _ = conn.Write([]byte{'N'})
+				}
+			})
+			defer stop()
+			target.Type = TypePostgreSQL
+			_, err := probe(target)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("should not ping another server", func() {
+			target, stop := standInServer(func(conn net.Conn) {
+				_, _ = conn.Write([]byte("HTTP/1.1 400 Bad Request\r\n\r\n"))
+			})
+			defer stop()
+			target.Type = TypePostgreSQL
+			_, err := probe(target)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+
+	Context("MySQL", func() {
+		It("should ping a server", func() {
+			target, stop := standInServer(func(conn net.Conn) {
+				payload := append([]byte{0x0a}, "8.0.32\x00"...)
+				_, _ = conn.Write(append([]byte{byte(len(payload)), 0, 0, 0}, payload...))
+			})
+			defer stop()
+			target.Type = TypeMySQL
+			_, err := probe(target)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("should report the error of a server refusing the client", func() {
+			target, stop := standInServer(func(conn net.Conn) {
+				payload := append([]byte{0xff, 0x6a, 0x04}, "Host is not allowed to connect"...)
+				_, _ = conn.Write(append([]byte{byte(len(payload)), 0, 0, 0}, payload...))
+			})
+			defer stop()
+			target.Type = TypeMySQL
+			_, err := probe(target)
+			Expect(err).Should(MatchError(ContainSubstring("Host is not allowed to connect")))
+		})
+	})
+
+	Context("MongoDB", func() {
+		It("should ping a server", func() {
+			target, stop := standInServer(func(conn net.Conn) {
+				header := make([]byte, 16)
+				if _, err := io.ReadFull(conn, header); err != nil {
+					return
+				}
+				body := make([]byte, binary.LittleEndian.Uint32(header[0:4])-16)
+				if _, err := io.ReadFull(conn, body); err != nil {
+					return
+				}
+				// reply with an OP_MSG with an empty document
+				reply := appendUint32(nil, 16+4+1+5)
+				reply = appendUint32(reply, 2)
+				reply = append(reply, header[4:8]...)
+				reply = appendUint32(reply, mongoOpMsg)
+				reply = appendUint32(reply, 0)
+				reply = append(reply, 0x00)
+				reply = append(reply, 5, 0, 0, 0, 0)
+				_, _ = conn.Write(reply)
+			})
+			defer stop()
+			target.Type = TypeMongoDB
+			_, err := probe(target)
+			Expect(err).ShouldNot(HaveOccurred())
+		})
+		It("should time out on a silent server", func() {
+			target, stop := standInServer(func(conn net.Conn) {
+				_, _ = io.Copy(io.Discard, conn)
+			})
+			defer stop()
+			target.Type = TypeMongoDB
+			ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+			defer cancel()
+			_, err := Probe(ctx, target)
+			Expect(err).Should(HaveOccurred())
+		})
+	})
+})
@@ -61,6 +61,9 @@ var dRec *DBaaSReconciler
 var iCtrl *spyctrl
 var cCtrl *spyctrl
 var inCtrl *spyctrl
+var dbCtrl *spyctrl
+var bkCtrl *spyctrl
+var rsCtrl *spyctrl
 
 const (
 	testNamespace = "default"
@@ -164,6 +167,8 @@ var _ = BeforeSuite(func() {
 
 	connectionCtrl, err := (&DBaaSConnectionReconciler{
 		DBaaSReconciler: dRec,
+		// the workload injection webhook is not served by the test environment
+		DisableInjection: true,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
@@ -172,20 +177,51 @@ var _ = BeforeSuite(func() {
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	databaseCtrl, err := (&DBaaSDatabaseReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	backupCtrl, err := (&DBaaSBackupReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	restoreCtrl, err := (&DBaaSRestoreReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = (&DBaaSBackupScheduleReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	err = (&DBaaSDefaultPolicyReconciler{
 		DBaaSReconciler: dRec,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
+	err = (&DBaaSInventoryGrantReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(k8sManager)
+	Expect(err).ToNot(HaveOccurred())
+
 	iCtrl = newSpyController(inventoryCtrl)
 	cCtrl = newSpyController(connectionCtrl)
 	inCtrl = newSpyController(instanceCtrl)
+	dbCtrl = newSpyController(databaseCtrl)
+	bkCtrl = newSpyController(backupCtrl)
+	rsCtrl = newSpyController(restoreCtrl)
 
 	err = (&DBaaSProviderReconciler{
 		DBaaSReconciler: dRec,
 		InventoryCtrl:   iCtrl,
 		ConnectionCtrl:  cCtrl,
 		InstanceCtrl:    inCtrl,
+		DatabaseCtrl:    dbCtrl,
+		BackupCtrl:      bkCtrl,
+		RestoreCtrl:     rsCtrl,
 	}).SetupWithManager(k8sManager)
 	Expect(err).ToNot(HaveOccurred())
 
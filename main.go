@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -51,6 +52,7 @@ import (
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
 	"github.com/RHEcosystemAppEng/dbaas-operator/controllers"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers/localprovider"
 	metrics "github.com/RHEcosystemAppEng/dbaas-operator/controllers/metrics"
 	//+kubebuilder:scaffold:imports
 )
@@ -71,6 +73,10 @@ func init() {
 	customMetrics.Registry.MustRegister(metrics.DBaaSInventoryStatusGauge)
 	customMetrics.Registry.MustRegister(metrics.DBaaSInstanceStatusGauge)
 	customMetrics.Registry.MustRegister(metrics.DBaaSConnectionStatusGauge)
+	customMetrics.Registry.MustRegister(metrics.DBaaSConnectionProbeLatencyHistogram)
+	customMetrics.Registry.MustRegister(metrics.DBaaSConnectionReachableGauge)
+	customMetrics.Registry.MustRegister(metrics.DBaaSConnectionExpiryGauge)
+	customMetrics.Registry.MustRegister(metrics.DBaaSConnectionExpiredGauge)
 	customMetrics.Registry.MustRegister(metrics.DBaaSInstancePhaseGauge)
 
 	utilruntime.Must(v1alpha1.AddToScheme(scheme))
@@ -94,9 +100,30 @@ func main() {
 	var enableLeaderElection bool
 	var probeAddr string
 	var logLevel string
+	var enableLocalProvider bool
+	var disableDevTopology bool
+	var rolloutRestartInterval time.Duration
+	var probeInterval time.Duration
+	var probeTimeout time.Duration
+	var revokeInvalidConnections bool
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.StringVar(&logLevel, "log-level", "info", "Log level.")
+	flag.BoolVar(&enableLocalProvider, "enable-local-provider", false,
+		"Enable the built-in local provider, which provisions PostgreSQL instances in the cluster. "+
+			"Intended for development clusters and CI.")
+	flag.BoolVar(&disableDevTopology, "disable-dev-topology", false,
+		"Disable the ConfigMaps representing DBaaSConnections in the topology view of the console.")
+	flag.DurationVar(&rolloutRestartInterval, "rollout-restart-interval", controllers.DefaultRolloutRestartInterval,
+		"The minimum interval between two restarts of the workloads bound to a DBaaSConnection when its credentials change.")
+	flag.DurationVar(&probeInterval, "connection-probe-interval", 0,
+		"The interval between two probes checking the database of a DBaaSConnection is reachable. "+
+			"The probes are disabled if not set.")
+	flag.DurationVar(&probeTimeout, "connection-probe-timeout", controllers.DefaultProbeTimeout,
+		"The timeout of a probe checking the database of a DBaaSConnection is reachable.")
+	flag.BoolVar(&revokeInvalidConnections, "revoke-invalid-connections", false,
+		"Revoke the credentials of a DBaaSConnection when its namespace is no longer a valid connection namespace for its inventory, "+
+			"by deleting the provider connection.")
 
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
@@ -143,8 +170,15 @@ func main() {
 	if DBaaSReconciler.InstallNamespace, err = controllers.GetInstallNamespace(); err != nil {
 		setupLog.Error(err, "unable to retrieve install namespace. default Policy object cannot be installed")
 	}
+	enableWebhooks := os.Getenv("ENABLE_WEBHOOKS") != "false"
 	connectionCtrl, err := (&controllers.DBaaSConnectionReconciler{
-		DBaaSReconciler: DBaaSReconciler,
+		DBaaSReconciler:          DBaaSReconciler,
+		DisableDevTopology:       disableDevTopology,
+		DisableInjection:         !enableWebhooks,
+		RolloutRestartInterval:   rolloutRestartInterval,
+		ProbeInterval:            probeInterval,
+		ProbeTimeout:             probeTimeout,
+		RevokeInvalidConnections: revokeInvalidConnections,
 	}).SetupWithManager(mgr)
 	if err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSConnection")
@@ -164,6 +198,33 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInstance")
 		os.Exit(1)
 	}
+	databaseCtrl, err := (&controllers.DBaaSDatabaseReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSDatabase")
+		os.Exit(1)
+	}
+	backupCtrl, err := (&controllers.DBaaSBackupReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSBackup")
+		os.Exit(1)
+	}
+	restoreCtrl, err := (&controllers.DBaaSRestoreReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSRestore")
+		os.Exit(1)
+	}
+	if err = (&controllers.DBaaSBackupScheduleReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSBackupSchedule")
+		os.Exit(1)
+	}
 	if err = (&controllers.DBaaSDefaultPolicyReconciler{
 		DBaaSReconciler: DBaaSReconciler,
 	}).SetupWithManager(mgr); err != nil {
@@ -175,13 +236,16 @@ func main() {
 		ConnectionCtrl:  connectionCtrl,
 		InventoryCtrl:   inventoryCtrl,
 		InstanceCtrl:    instanceCtrl,
+		DatabaseCtrl:    databaseCtrl,
+		BackupCtrl:      backupCtrl,
+		RestoreCtrl:     restoreCtrl,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSProvider")
 		os.Exit(1)
 	}
 	//We'll just make sure to set `ENABLE_WEBHOOKS=false` when we run locally.
 
-	if os.Getenv("ENABLE_WEBHOOKS") != "false" {
+	if enableWebhooks {
 		if err = (&v1beta1.DBaaSConnection{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSConnection")
 			os.Exit(1)
@@ -194,14 +258,39 @@ func main() {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSPolicy")
 			os.Exit(1)
 		}
+		if err = (&v1beta1.DBaaSClusterPolicy{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSClusterPolicy")
+			os.Exit(1)
+		}
+		if err = (&v1beta1.DBaaSInventoryGrant{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSInventoryGrant")
+			os.Exit(1)
+		}
 		if err = (&v1beta1.DBaaSInstance{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSInstance")
 			os.Exit(1)
 		}
+		if err = (&v1beta1.DBaaSDatabase{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSDatabase")
+			os.Exit(1)
+		}
+		if err = (&v1beta1.DBaaSBackup{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSBackup")
+			os.Exit(1)
+		}
+		if err = (&v1beta1.DBaaSBackupSchedule{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSBackupSchedule")
+			os.Exit(1)
+		}
+		if err = (&v1beta1.DBaaSRestore{}).SetupWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSRestore")
+			os.Exit(1)
+		}
 		if err = (&v1beta1.DBaaSProvider{}).SetupWebhookWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create webhook", "webhook", "DBaaSProvider")
 			os.Exit(1)
 		}
+		v1beta1.SetupWorkloadWebhookWithManager(mgr)
 	}
 	if err = (&controllers.DBaaSPolicyReconciler{
 		DBaaSReconciler: DBaaSReconciler,
@@ -210,6 +299,20 @@ func main() {
 		os.Exit(1)
 	}
 
+	if err = (&controllers.DBaaSClusterPolicyReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSClusterPolicy")
+		os.Exit(1)
+	}
+
+	if err = (&controllers.DBaaSInventoryGrantReconciler{
+		DBaaSReconciler: DBaaSReconciler,
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "DBaaSInventoryGrant")
+		os.Exit(1)
+	}
+
 	if err = (&controllers.DBaaSPlatformReconciler{
 		DBaaSReconciler: DBaaSReconciler,
 		Log:             ctrl.Log.WithName("controllers").WithName("DBaaSPlatform"),
@@ -217,6 +320,12 @@ func main() {
 		setupLog.Error(err, "unable to create controller", "controller", "DBaaSPlatform")
 		os.Exit(1)
 	}
+	if enableLocalProvider {
+		if err = localprovider.SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "LocalProvider")
+			os.Exit(1)
+		}
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
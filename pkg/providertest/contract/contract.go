@@ -0,0 +1,261 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package contract defines the points of the contract between the DBaaS operator and a provider operator,
+// and checks the status a provider reports on its inventory, instance and connection objects against them.
+package contract
+
+import (
+	"encoding/json"
+	"fmt"
+
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+// Point identifies a point of the provider contract
+type Point string
+
+const (
+	// InventorySpecSynced requires the provider inventory to report a SpecSynced condition
+	InventorySpecSynced Point = "Inventory/SpecSynced"
+	// InventoryDatabaseServices requires every database service of a synced inventory to have a service ID
+	InventoryDatabaseServices Point = "Inventory/DatabaseServices"
+	// InstanceProvisionReady requires the provider instance to report a ProvisionReady condition
+	InstanceProvisionReady Point = "Instance/ProvisionReady"
+	// InstancePhase requires the provider instance to report a known phase, Ready once provisioned
+	InstancePhase Point = "Instance/Phase"
+	// InstanceID requires a provisioned provider instance to report its instance ID
+	InstanceID Point = "Instance/InstanceID"
+	// ConnectionReadyForBinding requires the provider connection to report a ReadyForBinding condition
+	ConnectionReadyForBinding Point = "Connection/ReadyForBinding"
+	// ConnectionCredentialsRef requires a ready provider connection to reference its credentials secret
+	ConnectionCredentialsRef Point = "Connection/CredentialsRef"
+	// ConnectionInfoRef requires a ready provider connection to reference its connection info config map
+	ConnectionInfoRef Point = "Connection/ConnectionInfoRef"
+)
+
+// Failure is a contract point a provider does not satisfy
+type Failure struct {
+	Point   Point
+	Message string
+}
+
+// String returns the contract point and the reason of the failure
+func (f Failure) String() string {
+	return fmt.Sprintf("%s: %s", f.Point, f.Message)
+}
+
+var instancePhases = map[v1beta1.DBaasInstancePhase]bool{
+	v1beta1.InstancePhaseUnknown:  true,
+	v1beta1.InstancePhasePending:  true,
+	v1beta1.InstancePhaseCreating: true,
+	v1beta1.InstancePhaseUpdating: true,
+	v1beta1.InstancePhaseDeleting: true,
+	v1beta1.InstancePhaseDeleted:  true,
+	v1beta1.InstancePhaseReady:    true,
+	v1beta1.InstancePhaseError:    true,
+	v1beta1.InstancePhaseFailed:   true,
+}
+
+// SplitConditions separates the condition owned by the DBaaS operator from the conditions reported by the provider
+func SplitConditions(conds []metav1.Condition, condType string) (dbaasCond []metav1.Condition, providerCond []metav1.Condition) {
+	for _, v := range conds {
+		if v.Type != condType { //skip the DBaaS operator specific condition
+			providerCond = append(providerCond, v)
+		} else {
+			dbaasCond = append(dbaasCond, v)
+		}
+	}
+	return
+}
+
+// CheckInventoryStatus checks the status of a provider inventory
+func CheckInventoryStatus(status *v1beta1.DBaaSInventoryStatus) []Failure {
+	var failures []Failure
+	cond, failure := checkCondition(status.Conditions, InventorySpecSynced, v1beta1.DBaaSInventoryProviderSyncType, v1beta1.DBaaSInventoryReadyType)
+	if failure != nil {
+		return append(failures, *failure)
+	}
+	if cond.Status != metav1.ConditionTrue {
+		return failures
+	}
+	for i, service := range status.DatabaseServices {
+		if len(service.ServiceID) == 0 {
+			failures = append(failures, Failure{
+				Point:   InventoryDatabaseServices,
+				Message: fmt.Sprintf("database service %d (%s) has no serviceID", i, service.ServiceName),
+			})
+		}
+	}
+	return failures
+}
+
+// CheckInstanceStatus checks the status of a provider instance
+func CheckInstanceStatus(status *v1beta1.DBaaSInstanceStatus) []Failure {
+	var failures []Failure
+	if !instancePhases[status.Phase] {
+		failures = append(failures, Failure{
+			Point:   InstancePhase,
+			Message: fmt.Sprintf("phase %q is not a known instance phase", status.Phase),
+		})
+	}
+	cond, failure := checkCondition(status.Conditions, InstanceProvisionReady, v1beta1.DBaaSInstanceProviderSyncType, v1beta1.DBaaSInstanceReadyType)
+	if failure != nil {
+		return append(failures, *failure)
+	}
+	if cond.Status != metav1.ConditionTrue {
+		return failures
+	}
+	if status.Phase != v1beta1.InstancePhaseReady {
+		failures = append(failures, Failure{
+			Point:   InstancePhase,
+			Message: fmt.Sprintf("phase is %q while the %s condition is True, expected %q", status.Phase, cond.Type, v1beta1.InstancePhaseReady),
+		})
+	}
+	if len(status.InstanceID) == 0 {
+		failures = append(failures, Failure{
+			Point:   InstanceID,
+			Message: fmt.Sprintf("instanceID is empty while the %s condition is True", cond.Type),
+		})
+	}
+	return failures
+}
+
+// CheckConnectionStatus checks the status of a provider connection
+func CheckConnectionStatus(status *v1beta1.DBaaSConnectionStatus) []Failure {
+	var failures []Failure
+	cond, failure := checkCondition(status.Conditions, ConnectionReadyForBinding, v1beta1.DBaaSConnectionProviderSyncType, v1beta1.DBaaSConnectionReadyType)
+	if failure != nil {
+		return append(failures, *failure)
+	}
+	if cond.Status != metav1.ConditionTrue {
+		return failures
+	}
+	if status.CredentialsRef == nil || len(status.CredentialsRef.Name) == 0 {
+		failures = append(failures, Failure{
+			Point:   ConnectionCredentialsRef,
+			Message: fmt.Sprintf("credentialsRef is not set while the %s condition is True", cond.Type),
+		})
+	}
+	if status.ConnectionInfoRef == nil || len(status.ConnectionInfoRef.Name) == 0 {
+		failures = append(failures, Failure{
+			Point:   ConnectionInfoRef,
+			Message: fmt.Sprintf("connectionInfoRef is not set while the %s condition is True", cond.Type),
+		})
+	}
+	return failures
+}
+
+// InventoryStatus parses the status of a provider inventory, reported in the given DBaaS API group version
+func InventoryStatus(obj *unstructured.Unstructured, statusVersion schema.GroupVersion) (*v1beta1.DBaaSInventoryStatus, error) {
+	status := &v1beta1.DBaaSInventoryStatus{}
+	if statusVersion == v1alpha1.GroupVersion {
+		inventory := &v1alpha1.DBaaSProviderInventory{}
+		if err := parse(obj, inventory); err != nil {
+			return nil, err
+		}
+		inventory.Status.ConvertTo(status)
+		return status, nil
+	}
+	inventory := &v1beta1.DBaaSProviderInventory{}
+	if err := parse(obj, inventory); err != nil {
+		return nil, err
+	}
+	inventory.Status.DeepCopyInto(status)
+	return status, nil
+}
+
+// InstanceStatus parses the status of a provider instance, reported in the given DBaaS API group version
+func InstanceStatus(obj *unstructured.Unstructured, statusVersion schema.GroupVersion) (*v1beta1.DBaaSInstanceStatus, error) {
+	status := &v1beta1.DBaaSInstanceStatus{}
+	if statusVersion == v1alpha1.GroupVersion {
+		instance := &v1alpha1.DBaaSProviderInstance{}
+		if err := parse(obj, instance); err != nil {
+			return nil, err
+		}
+		instance.Status.ConvertTo(status)
+		return status, nil
+	}
+	instance := &v1beta1.DBaaSProviderInstance{}
+	if err := parse(obj, instance); err != nil {
+		return nil, err
+	}
+	instance.Status.DeepCopyInto(status)
+	return status, nil
+}
+
+// ConnectionStatus parses the status of a provider connection, reported in the given DBaaS API group version
+func ConnectionStatus(obj *unstructured.Unstructured, statusVersion schema.GroupVersion) (*v1beta1.DBaaSConnectionStatus, error) {
+	status := &v1beta1.DBaaSConnectionStatus{}
+	if statusVersion == v1alpha1.GroupVersion {
+		connection := &v1alpha1.DBaaSProviderConnection{}
+		if err := parse(obj, connection); err != nil {
+			return nil, err
+		}
+		connection.Status.ConvertTo(status)
+		return status, nil
+	}
+	connection := &v1beta1.DBaaSProviderConnection{}
+	if err := parse(obj, connection); err != nil {
+		return nil, err
+	}
+	connection.Status.DeepCopyInto(status)
+	return status, nil
+}
+
+// checkCondition checks that the provider reports its condition, and leaves the DBaaS operator condition alone
+func checkCondition(conds []metav1.Condition, point Point, condType, dbaasCondType string) (*metav1.Condition, *Failure) {
+	if dbaasConds, _ := SplitConditions(conds, dbaasCondType); len(dbaasConds) > 0 {
+		return nil, &Failure{
+			Point:   point,
+			Message: fmt.Sprintf("the %s condition is owned by the DBaaS operator and must not be set by the provider", dbaasCondType),
+		}
+	}
+	cond := apimeta.FindStatusCondition(conds, condType)
+	if cond == nil {
+		return nil, &Failure{
+			Point:   point,
+			Message: fmt.Sprintf("the %s condition is not reported", condType),
+		}
+	}
+	if cond.Status != metav1.ConditionTrue && cond.Status != metav1.ConditionFalse && cond.Status != metav1.ConditionUnknown {
+		return nil, &Failure{
+			Point:   point,
+			Message: fmt.Sprintf("the %s condition has an invalid status %q", condType, cond.Status),
+		}
+	}
+	if len(cond.Reason) == 0 {
+		return nil, &Failure{
+			Point:   point,
+			Message: fmt.Sprintf("the %s condition has no reason", condType),
+		}
+	}
+	return cond, nil
+}
+
+func parse(obj *unstructured.Unstructured, object interface{}) error {
+	b, err := obj.MarshalJSON()
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(b, object)
+}
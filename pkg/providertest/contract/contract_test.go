@@ -0,0 +1,159 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contract
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+)
+
+func TestContract(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecsWithDefaultAndCustomReporters(t,
+		"Provider Contract Suite",
+		[]Reporter{printer.NewlineReporter{}})
+}
+
+func points(failures []Failure) []Point {
+	var p []Point
+	for _, f := range failures {
+		p = append(p, f.Point)
+	}
+	return p
+}
+
+var _ = Describe("Provider contract", func() {
+	Context("inventory", func() {
+		It("should pass with a synced inventory", func() {
+			status := &v1beta1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				DatabaseServices: []v1beta1.DatabaseService{{ServiceID: "id", ServiceName: "name"}},
+			}
+			Expect(CheckInventoryStatus(status)).Should(BeEmpty())
+		})
+		It("should fail without the SpecSynced condition", func() {
+			Expect(points(CheckInventoryStatus(&v1beta1.DBaaSInventoryStatus{}))).Should(ConsistOf(InventorySpecSynced))
+		})
+		It("should fail if the provider sets the DBaaS condition", func() {
+			status := &v1beta1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+					{Type: v1beta1.DBaaSInventoryReadyType, Status: metav1.ConditionTrue, Reason: "Ready"},
+				},
+			}
+			Expect(points(CheckInventoryStatus(status))).Should(ConsistOf(InventorySpecSynced))
+		})
+		It("should fail with a database service without an ID", func() {
+			status := &v1beta1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				DatabaseServices: []v1beta1.DatabaseService{{ServiceName: "name"}},
+			}
+			Expect(points(CheckInventoryStatus(status))).Should(ConsistOf(InventoryDatabaseServices))
+		})
+	})
+
+	Context("instance", func() {
+		It("should pass with a provisioning instance", func() {
+			status := &v1beta1.DBaaSInstanceStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInstanceProviderSyncType, Status: metav1.ConditionFalse, Reason: "Provisioning"},
+				},
+				Phase: v1beta1.InstancePhaseCreating,
+			}
+			Expect(CheckInstanceStatus(status)).Should(BeEmpty())
+		})
+		It("should fail with a ready instance without a ready phase and ID", func() {
+			status := &v1beta1.DBaaSInstanceStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInstanceProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				Phase: v1beta1.InstancePhaseCreating,
+			}
+			Expect(points(CheckInstanceStatus(status))).Should(ConsistOf(InstancePhase, InstanceID))
+		})
+		It("should fail with an unknown phase", func() {
+			status := &v1beta1.DBaaSInstanceStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInstanceProviderSyncType, Status: metav1.ConditionFalse, Reason: "Provisioning"},
+				},
+				Phase: "Starting",
+			}
+			Expect(points(CheckInstanceStatus(status))).Should(ConsistOf(InstancePhase))
+		})
+	})
+
+	Context("connection", func() {
+		It("should pass with a ready connection", func() {
+			status := &v1beta1.DBaaSConnectionStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSConnectionProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				CredentialsRef:    &corev1.LocalObjectReference{Name: "credentials"},
+				ConnectionInfoRef: &corev1.LocalObjectReference{Name: "configs"},
+			}
+			Expect(CheckConnectionStatus(status)).Should(BeEmpty())
+		})
+		It("should fail with a ready connection without references", func() {
+			status := &v1beta1.DBaaSConnectionStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSConnectionProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+			}
+			Expect(points(CheckConnectionStatus(status))).Should(ConsistOf(ConnectionCredentialsRef, ConnectionInfoRef))
+		})
+		It("should fail with a condition without a reason", func() {
+			status := &v1beta1.DBaaSConnectionStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSConnectionProviderSyncType, Status: metav1.ConditionFalse},
+				},
+			}
+			Expect(points(CheckConnectionStatus(status))).Should(ConsistOf(ConnectionReadyForBinding))
+		})
+	})
+
+	Context("status parsing", func() {
+		It("should convert a v1alpha1 instance status", func() {
+			obj := &unstructured.Unstructured{Object: map[string]interface{}{
+				"apiVersion": v1alpha1.GroupVersion.String(),
+				"kind":       "MongoDBAtlasInstance",
+				"status": map[string]interface{}{
+					"instanceID": "id",
+					"phase":      "Ready",
+				},
+			}}
+			status, err := InstanceStatus(obj, v1alpha1.GroupVersion)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.InstanceID).Should(Equal("id"))
+			Expect(status.Phase).Should(Equal(v1beta1.InstancePhaseReady))
+		})
+	})
+})
@@ -0,0 +1,510 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package providertest is a conformance kit for DBaaSProvider implementations.
+//
+// Run starts a test control plane with the DBaaS and provider CRDs, runs the DBaaS operator controllers against it,
+// and walks an inventory, an instance and a connection through their lifecycle. A Responder stands in for the
+// provider operator and reports the status of the provider objects, which are then checked against the points of
+// the provider contract defined in the contract package.
+package providertest
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	pkgruntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1alpha1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/controllers"
+	"github.com/RHEcosystemAppEng/dbaas-operator/pkg/providertest/contract"
+)
+
+const (
+	defaultNamespace = "default"
+	defaultTimeout   = 30 * time.Second
+	pollInterval     = 250 * time.Millisecond
+
+	objectName = "providertest"
+)
+
+// Responder stands in for a provider operator during a conformance run. It is called once for each provider object
+// created by the DBaaS operator, and reports the status the provider operator would report on it.
+type Responder interface {
+	// RespondInventory reports the status of a provider inventory
+	RespondInventory(ctx context.Context, c client.Client, inventory *unstructured.Unstructured) error
+	// RespondInstance reports the status of a provider instance
+	RespondInstance(ctx context.Context, c client.Client, instance *unstructured.Unstructured) error
+	// RespondConnection reports the status of a provider connection
+	RespondConnection(ctx context.Context, c client.Client, connection *unstructured.Unstructured) error
+}
+
+// Options configures a conformance run
+type Options struct {
+	// The DBaaSProvider manifest of the provider under test.
+	Provider *v1beta1.DBaaSProvider
+
+	// The paths of the CRDs of the provider inventory, instance and connection kinds.
+	ProviderCRDPaths []string
+
+	// The paths of the DBaaS operator CRDs. Defaults to the CRDs shipped with this module.
+	DBaaSCRDPaths []string
+
+	// The fake provider operator.
+	Responder Responder
+
+	// The data of the inventory credentials secret.
+	Credentials map[string][]byte
+
+	// The provisioning parameters of the test instance. The instance is skipped if the provider has no instance kind.
+	ProvisioningParameters map[v1beta1.ProvisioningParameterType]string
+
+	// The namespace of the test objects. Defaults to "default".
+	Namespace string
+
+	// How long to wait for each step of the scenario. Defaults to 30 seconds.
+	Timeout time.Duration
+}
+
+// Report is the outcome of a conformance run
+type Report struct {
+	// The name of the provider under test.
+	Provider string
+
+	// The contract points the provider does not satisfy.
+	Failures []contract.Failure
+
+	// The steps of the scenario that could not be run.
+	Skipped []string
+}
+
+// Passed returns true if the provider satisfies all the contract points checked
+func (r *Report) Passed() bool {
+	return len(r.Failures) == 0
+}
+
+// String returns a readable summary of the report
+func (r *Report) String() string {
+	var sb strings.Builder
+	if r.Passed() {
+		fmt.Fprintf(&sb, "provider %s satisfies the DBaaS provider contract\n", r.Provider)
+	} else {
+		fmt.Fprintf(&sb, "provider %s fails %d contract point(s):\n", r.Provider, len(r.Failures))
+		for _, failure := range r.Failures {
+			fmt.Fprintf(&sb, "  - %s\n", failure)
+		}
+	}
+	for _, skipped := range r.Skipped {
+		fmt.Fprintf(&sb, "  skipped: %s\n", skipped)
+	}
+	return sb.String()
+}
+
+// Run runs the conformance scenario for a provider. An error is returned if the scenario cannot be run,
+// the contract points the provider fails are listed in the report.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Provider == nil {
+		return nil, fmt.Errorf("a DBaaSProvider manifest is required")
+	}
+	if opts.Responder == nil {
+		return nil, fmt.Errorf("a provider responder is required")
+	}
+	if len(opts.Namespace) == 0 {
+		opts.Namespace = defaultNamespace
+	}
+	if opts.Timeout == 0 {
+		opts.Timeout = defaultTimeout
+	}
+	if len(opts.DBaaSCRDPaths) == 0 {
+		opts.DBaaSCRDPaths = []string{defaultDBaaSCRDPath()}
+	}
+
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     append(append([]string{}, opts.DBaaSCRDPaths...), opts.ProviderCRDPaths...),
+		ErrorIfCRDPathMissing: true,
+	}
+	cfg, err := testEnv.Start()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start the test environment: %w", err)
+	}
+	defer func() {
+		_ = testEnv.Stop()
+	}()
+
+	scheme := pkgruntime.NewScheme()
+	for _, addToScheme := range []func(*pkgruntime.Scheme) error{clientgoscheme.AddToScheme, v1alpha1.AddToScheme, v1beta1.AddToScheme} {
+		if err := addToScheme(scheme); err != nil {
+			return nil, err
+		}
+	}
+	mgr, err := ctrl.NewManager(cfg, ctrl.Options{
+		Scheme:             scheme,
+		MetricsBindAddress: "0",
+		ClientDisableCacheFor: []client.Object{
+			&corev1.Secret{},
+			&corev1.ConfigMap{},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := setupControllers(mgr, opts.Namespace); err != nil {
+		return nil, err
+	}
+
+	mgrCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	mgrErr := make(chan error, 1)
+	go func() {
+		mgrErr <- mgr.Start(mgrCtx)
+	}()
+	if !mgr.GetCache().WaitForCacheSync(mgrCtx) {
+		return nil, fmt.Errorf("failed to sync the manager cache: %v", <-mgrErr)
+	}
+
+	s := &scenario{
+		opts:   opts,
+		client: mgr.GetClient(),
+		report: &Report{Provider: opts.Provider.Name},
+	}
+	if err := s.run(ctx); err != nil {
+		return nil, err
+	}
+	return s.report, nil
+}
+
+// setupControllers sets up the DBaaS operator controllers involved in the provider contract
+func setupControllers(mgr ctrl.Manager, namespace string) error {
+	dRec := &controllers.DBaaSReconciler{
+		Client:           mgr.GetClient(),
+		Scheme:           mgr.GetScheme(),
+		InstallNamespace: namespace,
+	}
+	if err := (&controllers.DBaaSPolicyReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr); err != nil {
+		return err
+	}
+	inventoryCtrl, err := (&controllers.DBaaSInventoryReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		return err
+	}
+	connectionCtrl, err := (&controllers.DBaaSConnectionReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		return err
+	}
+	instanceCtrl, err := (&controllers.DBaaSInstanceReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		return err
+	}
+	databaseCtrl, err := (&controllers.DBaaSDatabaseReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		return err
+	}
+	backupCtrl, err := (&controllers.DBaaSBackupReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		return err
+	}
+	restoreCtrl, err := (&controllers.DBaaSRestoreReconciler{
+		DBaaSReconciler: dRec,
+	}).SetupWithManager(mgr)
+	if err != nil {
+		return err
+	}
+	return (&controllers.DBaaSProviderReconciler{
+		DBaaSReconciler: dRec,
+		InventoryCtrl:   inventoryCtrl,
+		ConnectionCtrl:  connectionCtrl,
+		InstanceCtrl:    instanceCtrl,
+		DatabaseCtrl:    databaseCtrl,
+		BackupCtrl:      backupCtrl,
+		RestoreCtrl:     restoreCtrl,
+	}).SetupWithManager(mgr)
+}
+
+// defaultDBaaSCRDPath returns the path of the CRDs shipped with this module
+func defaultDBaaSCRDPath() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "config", "crd", "bases")
+}
+
+// scenario walks the DBaaS objects through their lifecycle and records the contract failures
+type scenario struct {
+	opts   Options
+	client client.Client
+	report *Report
+}
+
+func (s *scenario) run(ctx context.Context) error {
+	if err := s.setup(ctx); err != nil {
+		return err
+	}
+
+	inventory, err := s.runInventory(ctx)
+	if err != nil || inventory == nil {
+		return err
+	}
+
+	var serviceID string
+	var serviceType *v1beta1.DatabaseServiceType
+	if len(s.opts.Provider.Spec.InstanceKind) > 0 {
+		instance, err := s.runInstance(ctx)
+		if err != nil {
+			return err
+		}
+		if instance != nil && len(instance.InstanceID) > 0 {
+			instanceType := v1beta1.DatabaseServiceType("instance")
+			serviceID, serviceType = instance.InstanceID, &instanceType
+		}
+	} else {
+		s.skip("instance: the provider has no instance kind")
+	}
+	if len(serviceID) == 0 && len(inventory.DatabaseServices) > 0 {
+		serviceID = inventory.DatabaseServices[0].ServiceID
+		serviceType = inventory.DatabaseServices[0].ServiceType
+	}
+	if len(serviceID) == 0 {
+		s.skip("connection: no instance or database service to connect to")
+		return nil
+	}
+	return s.runConnection(ctx, serviceID, serviceType)
+}
+
+// setup creates the namespace, the provider, and an active policy
+func (s *scenario) setup(ctx context.Context) error {
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.opts.Namespace}}
+	if err := s.client.Create(ctx, ns); err != nil && !errors.IsAlreadyExists(err) {
+		return err
+	}
+
+	provider := &v1beta1.DBaaSProvider{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        s.opts.Provider.Name,
+			Labels:      s.opts.Provider.Labels,
+			Annotations: s.opts.Provider.Annotations,
+		},
+		Spec: *s.opts.Provider.Spec.DeepCopy(),
+	}
+	if err := s.client.Create(ctx, provider); err != nil {
+		return fmt.Errorf("failed to create the DBaaSProvider: %w", err)
+	}
+
+	policy := &v1beta1.DBaaSPolicy{ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: s.opts.Namespace}}
+	if err := s.client.Create(ctx, policy); err != nil {
+		return fmt.Errorf("failed to create the DBaaSPolicy: %w", err)
+	}
+	if err := s.poll(ctx, func() (bool, error) {
+		if err := s.client.Get(ctx, client.ObjectKeyFromObject(policy), policy); err != nil {
+			return false, err
+		}
+		return apimeta.IsStatusConditionTrue(policy.Status.Conditions, v1beta1.DBaaSPolicyReadyType), nil
+	}); err != nil {
+		return fmt.Errorf("the DBaaSPolicy is not ready: %w", err)
+	}
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      objectName + "-credentials",
+			Namespace: s.opts.Namespace,
+			Labels:    map[string]string{v1beta1.TypeLabelKey: v1beta1.TypeLabelValue},
+		},
+		Data: s.opts.Credentials,
+	}
+	if err := s.client.Create(ctx, secret); err != nil {
+		return fmt.Errorf("failed to create the credentials secret: %w", err)
+	}
+	return nil
+}
+
+func (s *scenario) runInventory(ctx context.Context) (*v1beta1.DBaaSInventoryStatus, error) {
+	inventory := &v1beta1.DBaaSInventory{
+		ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: s.opts.Namespace},
+		Spec: v1beta1.DBaaSOperatorInventorySpec{
+			ProviderRef: v1beta1.NamespacedName{Name: s.opts.Provider.Name},
+			DBaaSInventorySpec: v1beta1.DBaaSInventorySpec{
+				CredentialsRef: &v1beta1.LocalObjectReference{Name: objectName + "-credentials"},
+			},
+		},
+	}
+	if err := s.client.Create(ctx, inventory); err != nil {
+		return nil, fmt.Errorf("failed to create the DBaaSInventory: %w", err)
+	}
+
+	var status *v1beta1.DBaaSInventoryStatus
+	ok, err := s.respond(ctx, inventory, s.opts.Provider.Spec.InventoryKind, contract.InventorySpecSynced,
+		s.opts.Responder.RespondInventory, func(obj *unstructured.Unstructured) ([]contract.Failure, error) {
+			var err error
+			if status, err = contract.InventoryStatus(obj, s.statusVersion()); err != nil {
+				return nil, err
+			}
+			return contract.CheckInventoryStatus(status), nil
+		})
+	if err != nil || !ok {
+		return nil, err
+	}
+	if !apimeta.IsStatusConditionTrue(status.Conditions, v1beta1.DBaaSInventoryProviderSyncType) {
+		s.skip("instance and connection: the provider inventory is not synced")
+		return nil, nil
+	}
+	return status, nil
+}
+
+func (s *scenario) runInstance(ctx context.Context) (*v1beta1.DBaaSInstanceStatus, error) {
+	instance := &v1beta1.DBaaSInstance{
+		ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: s.opts.Namespace},
+		Spec: v1beta1.DBaaSInstanceSpec{
+			InventoryRef:           v1beta1.NamespacedName{Name: objectName, Namespace: s.opts.Namespace},
+			ProvisioningParameters: s.opts.ProvisioningParameters,
+		},
+	}
+	if err := s.client.Create(ctx, instance); err != nil {
+		return nil, fmt.Errorf("failed to create the DBaaSInstance: %w", err)
+	}
+
+	var status *v1beta1.DBaaSInstanceStatus
+	ok, err := s.respond(ctx, instance, s.opts.Provider.Spec.InstanceKind, contract.InstanceProvisionReady,
+		s.opts.Responder.RespondInstance, func(obj *unstructured.Unstructured) ([]contract.Failure, error) {
+			var err error
+			if status, err = contract.InstanceStatus(obj, s.statusVersion()); err != nil {
+				return nil, err
+			}
+			return contract.CheckInstanceStatus(status), nil
+		})
+	if err != nil || !ok {
+		return nil, err
+	}
+	return status, nil
+}
+
+func (s *scenario) runConnection(ctx context.Context, serviceID string, serviceType *v1beta1.DatabaseServiceType) error {
+	connection := &v1beta1.DBaaSConnection{
+		ObjectMeta: metav1.ObjectMeta{Name: objectName, Namespace: s.opts.Namespace},
+		Spec: v1beta1.DBaaSConnectionSpec{
+			InventoryRef:        v1beta1.NamespacedName{Name: objectName, Namespace: s.opts.Namespace},
+			DatabaseServiceID:   serviceID,
+			DatabaseServiceType: serviceType,
+		},
+	}
+	if err := s.client.Create(ctx, connection); err != nil {
+		return fmt.Errorf("failed to create the DBaaSConnection: %w", err)
+	}
+
+	_, err := s.respond(ctx, connection, s.opts.Provider.Spec.ConnectionKind, contract.ConnectionReadyForBinding,
+		s.opts.Responder.RespondConnection, func(obj *unstructured.Unstructured) ([]contract.Failure, error) {
+			status, err := contract.ConnectionStatus(obj, s.statusVersion())
+			if err != nil {
+				return nil, err
+			}
+			return contract.CheckConnectionStatus(status), nil
+		})
+	return err
+}
+
+// respond waits for the DBaaS operator to create the provider object of a DBaaS object, calls the responder on it,
+// and waits for its status to satisfy the contract. The failures left at the timeout are added to the report.
+// It returns false if the provider object was not created.
+func (s *scenario) respond(ctx context.Context, object client.Object, kind string, point contract.Point,
+	respondFn func(context.Context, client.Client, *unstructured.Unstructured) error,
+	checkFn func(*unstructured.Unstructured) ([]contract.Failure, error)) (bool, error) {
+	gv := s.opts.Provider.GetDBaaSAPIGroupVersion()
+	providerObject := &unstructured.Unstructured{}
+	providerObject.SetGroupVersionKind(gv.WithKind(kind))
+	key := client.ObjectKeyFromObject(object)
+
+	if err := s.poll(ctx, func() (bool, error) {
+		if err := s.client.Get(ctx, key, providerObject); err != nil {
+			if errors.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		return true, nil
+	}); err != nil {
+		s.fail(point, fmt.Sprintf("the DBaaS operator did not create the provider %s %s: %v", kind, key, err))
+		return false, nil
+	}
+
+	if err := respondFn(ctx, s.client, providerObject); err != nil {
+		return false, fmt.Errorf("the responder failed on %s %s: %w", kind, key, err)
+	}
+
+	var failures []contract.Failure
+	if err := s.poll(ctx, func() (bool, error) {
+		if err := s.client.Get(ctx, key, providerObject); err != nil {
+			return false, err
+		}
+		var err error
+		if failures, err = checkFn(providerObject); err != nil {
+			return false, err
+		}
+		return len(failures) == 0, nil
+	}); err != nil && len(failures) == 0 {
+		s.fail(point, fmt.Sprintf("the status of the provider %s %s cannot be checked: %v", kind, key, err))
+	}
+	s.report.Failures = append(s.report.Failures, failures...)
+	return true, nil
+}
+
+// statusVersion returns the DBaaS API group version of the status reported by the provider
+func (s *scenario) statusVersion() schema.GroupVersion {
+	if s.opts.Provider.GetDBaaSAPIGroupVersion() == v1alpha1.GroupVersion &&
+		s.opts.Provider.Name != controllers.RDS_PROVIDER {
+		return v1alpha1.GroupVersion
+	}
+	return v1beta1.GroupVersion
+}
+
+func (s *scenario) poll(ctx context.Context, condition func() (bool, error)) error {
+	pollCtx, cancel := context.WithTimeout(ctx, s.opts.Timeout)
+	defer cancel()
+	return wait.PollImmediateUntilWithContext(pollCtx, pollInterval, func(context.Context) (bool, error) {
+		return condition()
+	})
+}
+
+func (s *scenario) fail(point contract.Point, message string) {
+	s.report.Failures = append(s.report.Failures, contract.Failure{Point: point, Message: message})
+}
+
+func (s *scenario) skip(step string) {
+	s.report.Skipped = append(s.report.Skipped, step)
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providertest
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/envtest/printer"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+
+	"github.com/RHEcosystemAppEng/dbaas-operator/api/v1beta1"
+	"github.com/RHEcosystemAppEng/dbaas-operator/pkg/providertest/contract"
+)
+
+func TestProviderTest(t *testing.T) {
+	RegisterFailHandler(Fail)
+
+	RunSpecsWithDefaultAndCustomReporters(t,
+		"Provider Conformance Suite",
+		[]Reporter{printer.NewlineReporter{}})
+}
+
+var _ = BeforeSuite(func() {
+	logf.SetLogger(zap.New(zap.WriteTo(GinkgoWriter), zap.UseDevMode(true)))
+})
+
+var testProvider = &v1beta1.DBaaSProvider{
+	ObjectMeta: metav1.ObjectMeta{
+		Name: "mongodb-atlas",
+	},
+	Spec: v1beta1.DBaaSProviderSpec{
+		Provider: v1beta1.DatabaseProviderInfo{
+			Name: "mongodb-atlas",
+		},
+		GroupVersion:     v1beta1.GroupVersion.String(),
+		InventoryKind:    "MongoDBAtlasInventory",
+		ConnectionKind:   "MongoDBAtlasConnection",
+		InstanceKind:     "MongoDBAtlasInstance",
+		CredentialFields: []v1beta1.CredentialField{},
+	},
+}
+
+var _ = Describe("Provider conformance", func() {
+	options := func(responder Responder) Options {
+		return Options{
+			Provider:         testProvider,
+			ProviderCRDPaths: []string{filepath.Join("..", "..", "test", "crd")},
+			DBaaSCRDPaths:    []string{filepath.Join("..", "..", "config", "crd", "bases")},
+			Responder:        responder,
+			Credentials:      map[string][]byte{"token": []byte("secret")},
+			Timeout:          10 * time.Second,
+		}
+	}
+
+	It("should pass for a conforming provider", func() {
+		report, err := Run(context.Background(), options(&StatusResponder{
+			InventoryStatus: &v1beta1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+			},
+			InstanceStatus: &v1beta1.DBaaSInstanceStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInstanceProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				InstanceID: "test-instance-id",
+				Phase:      v1beta1.InstancePhaseReady,
+			},
+			ConnectionStatus: &v1beta1.DBaaSConnectionStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSConnectionProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				CredentialsRef:    &corev1.LocalObjectReference{Name: "test-credentials"},
+				ConnectionInfoRef: &corev1.LocalObjectReference{Name: "test-configs"},
+			},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Passed()).Should(BeTrue(), report.String())
+		Expect(report.Skipped).Should(BeEmpty())
+	})
+
+	It("should report the contract points a provider fails", func() {
+		report, err := Run(context.Background(), options(&StatusResponder{
+			InventoryStatus: &v1beta1.DBaaSInventoryStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInventoryProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+			},
+			InstanceStatus: &v1beta1.DBaaSInstanceStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSInstanceProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+				InstanceID: "test-instance-id",
+				Phase:      v1beta1.InstancePhaseCreating,
+			},
+			ConnectionStatus: &v1beta1.DBaaSConnectionStatus{
+				Conditions: []metav1.Condition{
+					{Type: v1beta1.DBaaSConnectionProviderSyncType, Status: metav1.ConditionTrue, Reason: "SyncOK"},
+				},
+			},
+		}))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(report.Passed()).Should(BeFalse())
+		var points []contract.Point
+		for _, failure := range report.Failures {
+			points = append(points, failure.Point)
+		}
+		Expect(points).Should(ConsistOf(contract.InstancePhase, contract.ConnectionCredentialsRef, contract.ConnectionInfoRef))
+	})
+})
@@ -0,0 +1,66 @@
+/*
+Copyright 2023.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package providertest
+
+import (
+	"context"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// StatusResponder is a Responder reporting fixed statuses, such as the ones recorded from a provider operator.
+// Each status is a pointer to a typed status, for instance a *v1beta1.DBaaSInventoryStatus, or an unstructured map.
+type StatusResponder struct {
+	InventoryStatus  interface{}
+	InstanceStatus   interface{}
+	ConnectionStatus interface{}
+}
+
+var _ Responder = &StatusResponder{}
+
+// RespondInventory reports the inventory status
+func (r *StatusResponder) RespondInventory(ctx context.Context, c client.Client, inventory *unstructured.Unstructured) error {
+	return updateStatus(ctx, c, inventory, r.InventoryStatus)
+}
+
+// RespondInstance reports the instance status
+func (r *StatusResponder) RespondInstance(ctx context.Context, c client.Client, instance *unstructured.Unstructured) error {
+	return updateStatus(ctx, c, instance, r.InstanceStatus)
+}
+
+// RespondConnection reports the connection status
+func (r *StatusResponder) RespondConnection(ctx context.Context, c client.Client, connection *unstructured.Unstructured) error {
+	return updateStatus(ctx, c, connection, r.ConnectionStatus)
+}
+
+// updateStatus replaces the status of a provider object
+func updateStatus(ctx context.Context, c client.Client, obj *unstructured.Unstructured, status interface{}) error {
+	if status == nil {
+		return nil
+	}
+	content, ok := status.(map[string]interface{})
+	if !ok {
+		var err error
+		if content, err = runtime.DefaultUnstructuredConverter.ToUnstructured(status); err != nil {
+			return err
+		}
+	}
+	obj.UnstructuredContent()["status"] = content
+	return c.Status().Update(ctx, obj)
+}